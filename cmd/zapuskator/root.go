@@ -6,6 +6,7 @@ import (
 	"log"
 	"net"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/denkoren/mi-labs-test/internal/services/background"
@@ -14,9 +15,11 @@ import (
 	"golang.org/x/sync/errgroup"
 	"google.golang.org/grpc"
 
+	"github.com/denkoren/mi-labs-test/internal/core"
 	"github.com/denkoren/mi-labs-test/internal/interconnect/docker"
 	"github.com/denkoren/mi-labs-test/internal/interconnect/registry"
 	"github.com/denkoren/mi-labs-test/internal/services/api"
+	"github.com/denkoren/mi-labs-test/internal/services/gateway"
 	apipb "github.com/denkoren/mi-labs-test/proto/api/v1"
 )
 
@@ -24,6 +27,24 @@ var (
 	// Used for flags.
 	grpcPort int
 	httpPort int
+
+	adminGrpcPort int
+	adminHTTPPort int
+	adminHTTPHost string
+
+	dbPath             string
+	servicesConfigPath string
+
+	// Calculation inputs and results are passed inside whole gRPC messages, so they can be huge.
+	// Bigger inputs are rejected and bigger results fail calculations, both for gRPC and REST clients.
+	maxMessageSize int
+
+	verificationSampleRate float64
+	pullImages             bool
+	cpuset                 string
+	internalNetwork        string
+	network                string
+	addressing             string
 )
 
 var rootCmd = &cobra.Command{
@@ -39,28 +60,60 @@ func runRoot(cmd *cobra.Command, args []string) error {
 	defer cancel()
 
 	var (
-		err      error
-		grpcAddr string
-		group    *errgroup.Group
-		groupCtx context.Context
+		err           error
+		grpcAddr      string
+		adminGrpcAddr string
+		group         *errgroup.Group
+		groupCtx      context.Context
 
 		cRegistry *registry.ContainerRegistry
 		cManager  *docker.Manager
+		services  *core.Services
+		images    map[string]string
+		apiServer *api.Server
 	)
 
 	grpcAddr = fmt.Sprintf("localhost:%d", grpcPort)
+	adminGrpcAddr = fmt.Sprintf("localhost:%d", adminGrpcPort)
+
+	group, groupCtx = errgroup.WithContext(ctx)
 
-	cRegistry, err = initContainerRegistry()
+	cRegistry, err = initContainerRegistry(groupCtx, group)
 	cobra.CheckErr(err)
 
-	cManager, err = initDockerManager()
+	services, err = loadServices(servicesConfigPath)
 	cobra.CheckErr(err)
 
-	group, groupCtx = errgroup.WithContext(ctx)
+	err = checkMaxInputSizes(services)
+	cobra.CheckErr(err)
+
+	cManager, err = initDockerManager(services)
+	cobra.CheckErr(err)
+
+	// Images are pulled before serving: calculation requests never wait for pulls
+	images, err = cManager.EnsureServiceImages(ctx)
+	cobra.CheckErr(err)
 
-	initGrpcAPIServer(groupCtx, group, grpcAddr, cRegistry, cManager)
+	err = restoreServiceImages(ctx, services, cRegistry, cManager, images)
+	cobra.CheckErr(err)
+
+	err = cManager.RestoreCPUAllocation(ctx)
+	cobra.CheckErr(err)
+
+	err = migrateLegacyContainers(ctx, services, cRegistry, cManager)
+	cobra.CheckErr(err)
+
+	err = cManager.EnsureNetwork(ctx)
+	cobra.CheckErr(err)
+
+	err = cManager.CheckSecurity(ctx)
+	cobra.CheckErr(err)
+
+	apiServer = initGrpcAPIServer(groupCtx, group, grpcAddr, services, images, cRegistry, cManager)
 	initRestAPIServer(groupCtx, group, grpcAddr)
-	initBackgroundService(groupCtx, group, cRegistry, cManager)
+	initGrpcAdminServer(groupCtx, group, adminGrpcAddr, apiServer)
+	initRestAdminServer(groupCtx, group, adminGrpcAddr)
+	initBackgroundService(groupCtx, group, services, cRegistry, cManager)
 
 	// FIXME: graceful shutdown by os.signal()
 	return group.Wait()
@@ -69,32 +122,164 @@ func runRoot(cmd *cobra.Command, args []string) error {
 func init() {
 	rootCmd.PersistentFlags().IntVar(&grpcPort, "grpc-port", 4334, "Port to be listened by Zapuskator gRPC service")
 	rootCmd.PersistentFlags().IntVar(&httpPort, "http-port", 4224, "Port to be listened by Zapuskator HTTP service")
+	rootCmd.PersistentFlags().IntVar(&adminGrpcPort, "admin-grpc-port", 4335, "Port to be listened by Zapuskator gRPC admin service")
+	rootCmd.PersistentFlags().IntVar(&adminHTTPPort, "admin-http-port", 4225, "Port to be listened by Zapuskator HTTP admin service")
+	rootCmd.PersistentFlags().StringVar(&adminHTTPHost, "admin-http-host", "localhost", "Interface to be listened by Zapuskator HTTP admin service. Admin API has no authentication, don't expose it publicly")
+	rootCmd.PersistentFlags().StringVar(&servicesConfigPath, "services-config", "", "Path to JSON file with compute services definitions. Single built-in service is used when empty")
+	rootCmd.PersistentFlags().StringVar(&dbPath, "db-path", "", "Path to Zapuskator registry database, e.g. 'zapuskator.db'. Registry is kept in memory only when empty")
+	rootCmd.PersistentFlags().BoolVar(&pullImages, "pull-images", true, "Pull service images absent locally from registry at startup and on service upgrade")
+	rootCmd.PersistentFlags().StringVar(&cpuset, "cpuset", "", "Cores pinned to containers of services with 'cores' resource, e.g. '2-15'. Empty disables CPU pinning")
+	rootCmd.PersistentFlags().StringVar(&network, "network", "", "Docker network of containers, is created if missing. Default bridge network is used when empty")
+	rootCmd.PersistentFlags().StringVar(&addressing, "addressing", "ip", "How containers are reached: 'ip' - by IP addresses, 'dns' - by names in user-defined network, Zapuskator container must be attached to it")
+	rootCmd.PersistentFlags().StringVar(&internalNetwork, "internal-network", "zapuskator-internal", "Docker network without egress for containers of services with 'disable_egress' security option")
+	rootCmd.PersistentFlags().IntVar(&maxMessageSize, "max-message-size", 50000000, "Maximum size of API messages in bytes. Limits calculation inputs and results, inputs of services are limited further by 'max_input_size'")
+	rootCmd.PersistentFlags().Float64Var(&verificationSampleRate, "verification-sample-rate", 0, "Fraction of calculation results recalculated by restarted containers to detect nondeterminism, from 0 to 1")
 }
 
-func initContainerRegistry() (*registry.ContainerRegistry, error) {
-	return registry.NewContainerRegistry()
+// checkMaxInputSizes makes sure inputs allowed by services fit in API messages
+func checkMaxInputSizes(services *core.Services) error {
+	for _, service := range services.All() {
+		if service.MaxInputSize > int64(maxMessageSize) {
+			return fmt.Errorf("service '%s': max input size '%d' exceeds max message size '%d'",
+				service.Name, service.MaxInputSize, maxMessageSize)
+		}
+	}
+
+	return nil
+}
+
+func initContainerRegistry(ctx context.Context, group *errgroup.Group) (*registry.ContainerRegistry, error) {
+	if dbPath == "" {
+		return registry.NewContainerRegistry(registry.NopStore{})
+	}
+
+	store, err := registry.NewBoltStore(dbPath)
+	if err != nil {
+		return nil, err
+	}
+
+	group.Go(func() error {
+		<-ctx.Done()
+		return store.Close()
+	})
+
+	return registry.NewContainerRegistry(store)
 }
 
-func initDockerManager() (*docker.Manager, error) {
+func initDockerManager(services *core.Services) (*docker.Manager, error) {
+	containersAddressing, err := docker.NewAddressing(addressing)
+	if err != nil {
+		return nil, err
+	}
+
 	return docker.NewManager(
 		docker.ManagerConfig{
-			Host:           "",
-			RequestTimeout: time.Second,
-			ImageTag:       "mi-labs-test:latest",
+			Host:            "",
+			RequestTimeout:  time.Second,
+			Services:        services,
+			PullImages:      pullImages,
+			PullTimeout:     10 * time.Minute,
+			CPUSet:          cpuset,
+			InternalNetwork: internalNetwork,
+			Network:         network,
+			Addressing:      containersAddressing,
 		},
 	)
 }
 
-func initGrpcAPIServer(_ context.Context, group *errgroup.Group, addr string, cRegistry *registry.ContainerRegistry, cManager *docker.Manager) {
+// restoreServiceImages replaces configured images of services with the ones set by upgrades before restart.
+func restoreServiceImages(ctx context.Context, services *core.Services, cRegistry *registry.ContainerRegistry, cManager *docker.Manager, images map[string]string) error {
+	upgraded, err := cRegistry.ServiceImages()
+	if err != nil {
+		return err
+	}
+
+	for name, digest := range upgraded {
+		service, err := services.Get(name)
+		if err != nil {
+			log.Printf("[Registry] image '%s' is not restored: %v", digest, err)
+			continue
+		}
+
+		// Image could be removed from Docker while the service was down
+		_, err = cManager.ResolveImage(ctx, service, digest)
+		if err != nil {
+			log.Printf("[Registry] image '%s' of service '%s' is not restored, configured image is used: %v", digest, name, err)
+			continue
+		}
+
+		log.Printf("[Registry] image of service '%s' is restored to '%s'", name, digest)
+		images[name] = digest
+	}
+
+	return nil
+}
+
+// migrateLegacyContainers completes params of containers stored by older versions with service and image,
+// so calculations find these containers again.
+func migrateLegacyContainers(ctx context.Context, services *core.Services, cRegistry *registry.ContainerRegistry, cManager *docker.Manager) error {
+	for _, container := range cRegistry.LegacyContainers() {
+		info := container.Snapshot()
+
+		service, err := services.Get(info.Params.Service)
+		if err != nil {
+			log.Printf("[Registry] container '%s' is not migrated: %v", info.ID, err)
+			continue
+		}
+
+		params := info.Params
+		params.Service = service.Name
+		if params.ImageDigest == "" {
+			params.ImageDigest, err = cManager.ContainerImage(ctx, info.ID)
+			if docker.IsNotFound(err) {
+				// Container is stopped by background checks
+				continue
+			}
+			if err != nil {
+				return err
+			}
+
+			// Images from registry are identified by digests, not by local IDs
+			if digest, err := cManager.ResolveImage(ctx, service, params.ImageDigest); err == nil {
+				params.ImageDigest = digest
+			}
+		}
+
+		err = cRegistry.MigrateParams(container, params)
+		if err != nil {
+			log.Printf("[Registry] container '%s' is not migrated: %v", info.ID, err)
+			continue
+		}
+		log.Printf("[Registry] container '%s' is migrated to service '%s' image '%s'", info.ID, params.Service, params.ImageDigest)
+	}
+
+	return nil
+}
+
+func initGrpcAPIServer(ctx context.Context, group *errgroup.Group, addr string, services *core.Services, images map[string]string, cRegistry *registry.ContainerRegistry, cManager *docker.Manager) *api.Server {
 	lis, err := net.Listen("tcp", addr)
 	cobra.CheckErr(err)
 
 	grpcServer := grpc.NewServer(
 		//grpc.StreamInterceptor(...),
+		grpc.MaxRecvMsgSize(maxMessageSize),
+		grpc.MaxSendMsgSize(maxMessageSize),
 	)
 	srv, err := api.NewServer(
 		api.Config{
-			ContainerWaitTimeout: 200 * time.Second,
+			Services:               services,
+			ServiceImages:          images,
+			ContainerWaitTimeout:   200 * time.Second,
+			CalculationRetries:     2,
+			CalculationTimeout:     150 * time.Second,
+			MaxInputSize:           int64(maxMessageSize),
+			WarmRejectedContainers: true,
+			OrphanedRequestLinger:  30 * time.Second,
+			ResultCacheSize:        1000,
+			ResultCacheTTL:         time.Hour,
+			VerificationSampleRate: verificationSampleRate,
+			VerificationInterval:   10 * time.Second,
+			VerificationMaxAge:     time.Hour,
 		},
 		cRegistry,
 		cManager,
@@ -107,33 +292,97 @@ func initGrpcAPIServer(_ context.Context, group *errgroup.Group, addr string, cR
 		log.Printf("grpc server listening at %v", addr)
 		return grpcServer.Serve(lis)
 	})
+
+	group.Go(func() error {
+		return srv.RunVerification(ctx)
+	})
+
+	return srv
 }
 
 func initRestAPIServer(ctx context.Context, group *errgroup.Group, grpcAddr string) {
 	mux := runtime.NewServeMux(
 		runtime.WithMarshalerOption(runtime.MIMEWildcard, &runtime.JSONPb{OrigName: true, EmitDefaults: true}),
+		runtime.WithMarshalerOption(gateway.MIMEOctetStream, &gateway.RawMarshaler{
+			JSONPb: runtime.JSONPb{OrigName: true, EmitDefaults: true},
+		}),
+		runtime.WithProtoErrorHandler(gateway.HTTPError),
+		runtime.WithMetadata(gateway.InputBodyMetadata),
 	)
 	opts := []grpc.DialOption{
 		grpc.WithInsecure(),
-		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(50000000)),
+		grpc.WithDefaultCallOptions(
+			grpc.MaxCallRecvMsgSize(maxMessageSize),
+			grpc.MaxCallSendMsgSize(maxMessageSize),
+		),
 	}
 
 	group.Go(func() error {
 		return apipb.RegisterZapuskatorAPIHandlerFromEndpoint(ctx, mux, grpcAddr, opts)
 	})
 
+	conn, err := grpc.DialContext(ctx, grpcAddr, opts...)
+	cobra.CheckErr(err)
+
+	httpMux := http.NewServeMux()
+	httpMux.Handle("/v1/events", gateway.NewSSEHandler(apipb.NewZapuskatorAPIClient(conn)))
+	httpMux.Handle("/", mux)
+
+	group.Go(func() error {
+		<-ctx.Done()
+		return conn.Close()
+	})
+
 	group.Go(func() error {
 		addr := fmt.Sprintf(":%d", httpPort)
 		log.Printf("http server listening at %v", addr)
+		return http.ListenAndServe(addr, httpMux)
+	})
+}
+
+func initGrpcAdminServer(_ context.Context, group *errgroup.Group, addr string, apiServer *api.Server) {
+	lis, err := net.Listen("tcp", addr)
+	cobra.CheckErr(err)
+
+	grpcServer := grpc.NewServer()
+	srv, err := api.NewAdminServer(apiServer)
+	cobra.CheckErr(err)
+
+	apipb.RegisterZapuskatorAdminAPIServer(grpcServer, srv)
+
+	group.Go(func() error {
+		log.Printf("grpc admin server listening at %v", addr)
+		return grpcServer.Serve(lis)
+	})
+}
+
+func initRestAdminServer(ctx context.Context, group *errgroup.Group, grpcAddr string) {
+	mux := runtime.NewServeMux(
+		runtime.WithMarshalerOption(runtime.MIMEWildcard, &runtime.JSONPb{OrigName: true, EmitDefaults: true}),
+	)
+	opts := []grpc.DialOption{
+		grpc.WithInsecure(),
+	}
+
+	group.Go(func() error {
+		return apipb.RegisterZapuskatorAdminAPIHandlerFromEndpoint(ctx, mux, grpcAddr, opts)
+	})
+
+	group.Go(func() error {
+		addr := net.JoinHostPort(adminHTTPHost, strconv.Itoa(adminHTTPPort))
+		log.Printf("http admin server listening at %v", addr)
 		return http.ListenAndServe(addr, mux)
 	})
 }
 
-func initBackgroundService(ctx context.Context, group *errgroup.Group, cRegistry *registry.ContainerRegistry, cManager *docker.Manager) {
+func initBackgroundService(ctx context.Context, group *errgroup.Group, services *core.Services, cRegistry *registry.ContainerRegistry, cManager *docker.Manager) {
 	bg, err := background.NewBackground(
 		background.Config{
+			Services:                 services,
 			InactiveContainerTimeout: 120 * time.Second,
 			ContainersCheckInterval:  time.Second,
+			HungContainerTimeouts:    3,
+			UnreachableHealthchecks:  3,
 		},
 		cRegistry,
 		cManager,
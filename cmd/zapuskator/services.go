@@ -0,0 +1,232 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"time"
+
+	"github.com/docker/go-units"
+
+	"github.com/denkoren/mi-labs-test/internal/core"
+)
+
+// defaultService is used when no services configuration file is given
+var defaultService = core.Service{
+	Name:  "compute",
+	Image: "mi-labs-test:latest",
+	Port:  8080,
+
+	HealthPath:    "/health",
+	ProgressPath:  "/progress",
+	CalculatePath: "/calculate",
+
+	ParamsTemplate: core.DefaultParamsTemplate,
+}
+
+// servicesFile is the services configuration file, e.g.:
+//
+//	{
+//	  "default": "aligner",
+//	  "services": [{
+//	    "name": "aligner", "image": "aligner:1.2", "port": 8080,
+//	    "health_path": "/health", "calculate_path": "/align",
+//	    "params": {"env": {"SEED": "{{.seed}}", "GENOME": "{{.genome | default \"hg38\"}}"}},
+//	    "calculation_timeout": "10m", "max_input_size": "10m",
+//	    "resources": {"cpu_quota": 200000, "memory": "4g", "pids_limit": 512, "ulimits": [{"name": "nofile", "soft": 1024, "hard": 4096}]},
+//	    "seed_resources": {"huge-genome": {"memory": "16g"}},
+//	    "security": {"read_only_root_fs": true, "tmpfs": {"/tmp": "size=64m"}, "cap_drop": ["ALL"], "no_new_privileges": true,
+//	                 "user": "1000:1000", "seccomp_profile": "/etc/zapuskator/seccomp.json", "disable_egress": true}
+//	  }]
+//	}
+type servicesFile struct {
+	Default  string        `json:"default"`
+	Services []serviceFile `json:"services"`
+}
+
+type serviceFile struct {
+	Name  string `json:"name"`
+	Image string `json:"image"`
+	Port  int    `json:"port"`
+
+	HealthPath    string `json:"health_path"`
+	ProgressPath  string `json:"progress_path"`
+	CalculatePath string `json:"calculate_path"`
+
+	Params *struct {
+		Env    map[string]string `json:"env"`
+		Args   []string          `json:"args"`
+		Labels map[string]string `json:"labels"`
+	} `json:"params"`
+
+	Resources     resourcesFile            `json:"resources"`
+	SeedResources map[string]resourcesFile `json:"seed_resources"`
+
+	Security struct {
+		ReadOnlyRootFS  bool              `json:"read_only_root_fs"`
+		Tmpfs           map[string]string `json:"tmpfs"`
+		CapDrop         []string          `json:"cap_drop"`
+		CapAdd          []string          `json:"cap_add"`
+		NoNewPrivileges bool              `json:"no_new_privileges"`
+		User            string            `json:"user"`
+		SeccompProfile  string            `json:"seccomp_profile"`
+		DisableEgress   bool              `json:"disable_egress"`
+	} `json:"security"`
+
+	MaxInputSize byteSize `json:"max_input_size"`
+
+	ContainerWaitTimeout     duration `json:"container_wait_timeout"`
+	CalculationTimeout       duration `json:"calculation_timeout"`
+	InactiveContainerTimeout duration `json:"inactive_container_timeout"`
+}
+
+type resourcesFile struct {
+	CPUShares int64 `json:"cpu_shares"`
+	CPUQuota  int64 `json:"cpu_quota"`
+	CPUPeriod int64 `json:"cpu_period"`
+
+	Memory            byteSize `json:"memory"`
+	MemoryReservation byteSize `json:"memory_reservation"`
+
+	PidsLimit int64 `json:"pids_limit"`
+	Ulimits   []struct {
+		Name string `json:"name"`
+		Soft int64  `json:"soft"`
+		Hard int64  `json:"hard"`
+	} `json:"ulimits"`
+
+	Cores int64 `json:"cores"`
+}
+
+func (f resourcesFile) resources() core.Resources {
+	result := core.Resources{
+		CPUShares: f.CPUShares,
+		CPUQuota:  f.CPUQuota,
+		CPUPeriod: f.CPUPeriod,
+
+		Memory:            int64(f.Memory),
+		MemoryReservation: int64(f.MemoryReservation),
+
+		PidsLimit: f.PidsLimit,
+		Cores:     f.Cores,
+	}
+
+	for _, ulimit := range f.Ulimits {
+		result.Ulimits = append(result.Ulimits, core.Ulimit{
+			Name: ulimit.Name,
+			Soft: ulimit.Soft,
+			Hard: ulimit.Hard,
+		})
+	}
+
+	return result
+}
+
+// byteSize is the number of bytes written like "512m" or "2g" in configuration file
+type byteSize int64
+
+func (b *byteSize) UnmarshalJSON(data []byte) error {
+	var s string
+	err := json.Unmarshal(data, &s)
+	if err != nil {
+		return err
+	}
+
+	parsed, err := units.RAMInBytes(s)
+	if err != nil {
+		return err
+	}
+
+	*b = byteSize(parsed)
+	return nil
+}
+
+// duration is time.Duration written like "1m30s" in configuration file
+type duration time.Duration
+
+func (d *duration) UnmarshalJSON(data []byte) error {
+	var s string
+	err := json.Unmarshal(data, &s)
+	if err != nil {
+		return err
+	}
+
+	parsed, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+
+	*d = duration(parsed)
+	return nil
+}
+
+func loadServices(path string) (*core.Services, error) {
+	if path == "" {
+		return core.NewServices(defaultService.Name, defaultService)
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read services configuration: %w", err)
+	}
+
+	var file servicesFile
+	err = json.Unmarshal(data, &file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse services configuration '%s': %w", path, err)
+	}
+
+	services := make([]core.Service, 0, len(file.Services))
+	for _, f := range file.Services {
+		service := core.Service{
+			Name:  f.Name,
+			Image: f.Image,
+			Port:  f.Port,
+
+			HealthPath:    withDefault(f.HealthPath, defaultService.HealthPath),
+			ProgressPath:  f.ProgressPath,
+			CalculatePath: withDefault(f.CalculatePath, defaultService.CalculatePath),
+
+			ParamsTemplate: core.DefaultParamsTemplate,
+
+			Resources: f.Resources.resources(),
+			Security:  core.Security(f.Security),
+
+			MaxInputSize: int64(f.MaxInputSize),
+
+			ContainerWaitTimeout:     time.Duration(f.ContainerWaitTimeout),
+			CalculationTimeout:       time.Duration(f.CalculationTimeout),
+			InactiveContainerTimeout: time.Duration(f.InactiveContainerTimeout),
+		}
+		if f.Params != nil {
+			service.ParamsTemplate = core.ParamsTemplate{
+				Env:    f.Params.Env,
+				Args:   f.Params.Args,
+				Labels: f.Params.Labels,
+			}
+		}
+
+		if len(f.SeedResources) != 0 {
+			service.SeedResources = make(map[string]core.Resources, len(f.SeedResources))
+			for seed, resources := range f.SeedResources {
+				service.SeedResources[seed] = resources.resources()
+			}
+		}
+
+		services = append(services, service)
+	}
+
+	defaultName := file.Default
+	if defaultName == "" && len(services) == 1 {
+		defaultName = services[0].Name
+	}
+
+	return core.NewServices(defaultName, services...)
+}
+
+func withDefault(value string, def string) string {
+	if value == "" {
+		return def
+	}
+	return value
+}
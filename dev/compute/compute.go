@@ -2,6 +2,7 @@ package main
 
 import (
 	"fmt"
+	"io/ioutil"
 	"log"
 	"net"
 	"net/http"
@@ -17,15 +18,19 @@ const (
 
 var (
 	isHealthy bool
+	startedAt time.Time
 )
 
 func main() {
+	startedAt = time.Now()
 	time.AfterFunc(healthyLag, func() { isHealthy = true })
 
 	mux := http.NewServeMux()
 
 	mux.HandleFunc("/health", handleHealthcheck)
+	mux.HandleFunc("/progress", handleProgress)
 	mux.HandleFunc("/calculate/", handleCalculate)
+	mux.HandleFunc("/calculate", handleCalculate)
 
 	// Imitate long container bootup: don't listen port for several seconds.
 	time.Sleep(bootLag)
@@ -51,6 +56,17 @@ func handleHealthcheck(w http.ResponseWriter, r *http.Request) {
 	return
 }
 
+func handleProgress(w http.ResponseWriter, r *http.Request) {
+	progress := 100
+	if !isHealthy {
+		progress = int(100 * time.Since(startedAt) / healthyLag)
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_, _ = fmt.Fprintf(w, "%d", progress)
+	return
+}
+
 func handleCalculate(w http.ResponseWriter, r *http.Request) {
 	if !isHealthy {
 		w.WriteHeader(http.StatusInternalServerError)
@@ -59,6 +75,19 @@ func handleCalculate(w http.ResponseWriter, r *http.Request) {
 
 	time.Sleep(responseLag)
 
+	if r.Method == http.MethodPost {
+		// Input is passed in request body
+		input, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(input)
+		return
+	}
+
 	w.WriteHeader(http.StatusOK)
 	_, _ = w.Write([]byte(r.RequestURI))
 	return
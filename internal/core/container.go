@@ -21,6 +21,16 @@ type ContainerInfo struct {
 	Stopped   time.Time
 	Updated   time.Time
 	LastUsed  time.Time
+
+	CalculationTimeouts int // Calculations timed out in a row
+	Progress            int // Initialization progress in percents, reported by container. Zero when unknown.
+	ActiveCalculations  int // Calculations, that are waited by clients right now
+
+	Pinned   bool // Pinned container is never stopped due to inactivity
+	Draining bool // Draining container accepts no new calculations and is stopped once it is idle
+	Retired  bool // Retired container is replaced by another one with the same params and is removed once stopped
+
+	Resources Resources // Limits applied to container on creation
 }
 
 func NewContainerInfo(id string, addr string, params ContainerParams) ContainerInfo {
@@ -36,10 +46,6 @@ func NewContainerInfo(id string, addr string, params ContainerParams) ContainerI
 	}
 }
 
-type ContainerParams struct {
-	Seed string
-}
-
 type ContainerStatus int
 
 const (
@@ -0,0 +1,61 @@
+package core
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+	"strconv"
+)
+
+// ParamSeed is the name of seed in named params
+const ParamSeed = "seed"
+
+// ContainerParams are the parameters container is started with.
+// Containers with equal params are interchangeable.
+type ContainerParams struct {
+	Service     string // Name of compute service. Empty for containers of older versions until they are migrated.
+	ImageDigest string // ID of service image version. Empty for containers of older versions until they are migrated.
+	Seed        string
+	Values      map[string]string // Named parameters besides seed, e.g. reference genome build or thread count
+}
+
+// Named returns all params by their names, including seed
+func (p ContainerParams) Named() map[string]string {
+	named := make(map[string]string, len(p.Values)+1)
+	for name, value := range p.Values {
+		named[name] = value
+	}
+	named[ParamSeed] = p.Seed
+
+	return named
+}
+
+// Key is the stable canonical hash of params. It identifies container in registry.
+// Params with empty values are equal to absent ones.
+func (p ContainerParams) Key() string {
+	named := p.Named()
+	names := make([]string, 0, len(named))
+	for name, value := range named {
+		if value != "" {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	h := sha256.New()
+	// Service and image are omitted when empty: keys of containers stored by older versions are still readable,
+	// but they differ from keys of the same params with service and image, see ContainerRegistry.MigrateParams.
+	if p.Service != "" {
+		_, _ = h.Write([]byte("service/" + strconv.Itoa(len(p.Service)) + ":" + p.Service))
+	}
+	if p.ImageDigest != "" {
+		_, _ = h.Write([]byte("image/" + strconv.Itoa(len(p.ImageDigest)) + ":" + p.ImageDigest))
+	}
+	for _, name := range names {
+		// Length prefixes make encoding unambiguous for any names and values
+		_, _ = h.Write([]byte(strconv.Itoa(len(name)) + ":" + name))
+		_, _ = h.Write([]byte(strconv.Itoa(len(named[name])) + ":" + named[name]))
+	}
+
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,58 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContainerParams_Key(t *testing.T) {
+	base := ContainerParams{Service: "compute", ImageDigest: "sha256:0123", Seed: "42", Values: map[string]string{"genome": "hg38"}}
+
+	tests := []struct {
+		name  string
+		other ContainerParams
+		equal bool
+	}{
+		{
+			name:  "same params",
+			other: ContainerParams{Service: "compute", ImageDigest: "sha256:0123", Seed: "42", Values: map[string]string{"genome": "hg38"}},
+			equal: true,
+		},
+		{
+			name:  "empty values are absent",
+			other: ContainerParams{Service: "compute", ImageDigest: "sha256:0123", Seed: "42", Values: map[string]string{"genome": "hg38", "threads": ""}},
+			equal: true,
+		},
+		{
+			name:  "another seed",
+			other: ContainerParams{Service: "compute", ImageDigest: "sha256:0123", Seed: "43", Values: map[string]string{"genome": "hg38"}},
+		},
+		{
+			name:  "another service",
+			other: ContainerParams{Service: "align", ImageDigest: "sha256:0123", Seed: "42", Values: map[string]string{"genome": "hg38"}},
+		},
+		{
+			name:  "another image",
+			other: ContainerParams{Service: "compute", ImageDigest: "sha256:4567", Seed: "42", Values: map[string]string{"genome": "hg38"}},
+		},
+		{
+			name:  "legacy params without service and image",
+			other: ContainerParams{Seed: "42", Values: map[string]string{"genome": "hg38"}},
+		},
+		{
+			name:  "ambiguous concatenation",
+			other: ContainerParams{Service: "compute", ImageDigest: "sha256:0123", Seed: "42", Values: map[string]string{"genom": "ehg38"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.equal {
+				assert.Equal(t, base.Key(), tt.other.Key())
+			} else {
+				assert.NotEqual(t, base.Key(), tt.other.Key())
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package core
+
+// Resources limits host resources used by container. Zero values mean no limit.
+type Resources struct {
+	CPUShares int64 // Relative CPU weight against other containers
+	CPUQuota  int64 // CPU time in microseconds, the container may use per CPU period
+	CPUPeriod int64 // CPU period in microseconds. Docker default is used when zero.
+
+	Memory            int64 // Memory limit in bytes
+	MemoryReservation int64 // Memory soft limit in bytes, is enforced on host memory contention
+
+	PidsLimit int64
+	Ulimits   []Ulimit
+
+	// Number of cores dedicated to container. Is applied only when CPU pinning is enabled.
+	Cores int64
+	// Cores assigned to container on its start, e.g. "2,3". Is empty when container shares cores with others.
+	CPUSet string
+}
+
+type Ulimit struct {
+	Name string // E.g. 'nofile' or 'nproc'
+	Soft int64
+	Hard int64
+}
+
+// Override returns the resources with non-zero limits of other resources applied.
+// Ulimits are overridden by their names.
+func (r Resources) Override(other Resources) Resources {
+	result := r
+
+	override := func(value *int64, other int64) {
+		if other != 0 {
+			*value = other
+		}
+	}
+
+	override(&result.CPUShares, other.CPUShares)
+	override(&result.CPUQuota, other.CPUQuota)
+	override(&result.CPUPeriod, other.CPUPeriod)
+	override(&result.Memory, other.Memory)
+	override(&result.MemoryReservation, other.MemoryReservation)
+	override(&result.PidsLimit, other.PidsLimit)
+	override(&result.Cores, other.Cores)
+
+	if len(other.Ulimits) != 0 {
+		result.Ulimits = make([]Ulimit, 0, len(r.Ulimits)+len(other.Ulimits))
+		for _, ulimit := range r.Ulimits {
+			if !hasUlimit(other.Ulimits, ulimit.Name) {
+				result.Ulimits = append(result.Ulimits, ulimit)
+			}
+		}
+		result.Ulimits = append(result.Ulimits, other.Ulimits...)
+	}
+
+	return result
+}
+
+func hasUlimit(ulimits []Ulimit, name string) bool {
+	for _, ulimit := range ulimits {
+		if ulimit.Name == name {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,89 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestService_ResourcesOf(t *testing.T) {
+	service := Service{
+		Name: "compute",
+		Resources: Resources{
+			CPUShares: 512,
+			CPUQuota:  100000,
+			Memory:    1 << 30,
+			PidsLimit: 256,
+			Ulimits:   []Ulimit{{Name: "nofile", Soft: 1024, Hard: 2048}, {Name: "nproc", Soft: 64, Hard: 128}},
+			Cores:     1,
+		},
+		SeedResources: map[string]Resources{
+			"huge": {
+				CPUQuota: 400000,
+				Memory:   16 << 30,
+				Ulimits:  []Ulimit{{Name: "nofile", Soft: 4096, Hard: 8192}, {Name: "stack", Soft: -1, Hard: -1}},
+				Cores:    4,
+			},
+			"reserved": {MemoryReservation: 1 << 29, CPUPeriod: 50000},
+			"empty":    {},
+		},
+	}
+
+	tests := []struct {
+		name string
+		seed string
+		want Resources
+	}{
+		{
+			name: "seed without overrides",
+			seed: "42",
+			want: service.Resources,
+		},
+		{
+			name: "seed limits override service ones",
+			seed: "huge",
+			want: Resources{
+				CPUShares: 512,
+				CPUQuota:  400000,
+				Memory:    16 << 30,
+				PidsLimit: 256,
+				// Service ulimits overridden by name are replaced, the rest are kept
+				Ulimits: []Ulimit{{Name: "nproc", Soft: 64, Hard: 128}, {Name: "nofile", Soft: 4096, Hard: 8192}, {Name: "stack", Soft: -1, Hard: -1}},
+				Cores:   4,
+			},
+		},
+		{
+			name: "seed adds limits absent in service",
+			seed: "reserved",
+			want: Resources{
+				CPUShares:         512,
+				CPUQuota:          100000,
+				CPUPeriod:         50000,
+				Memory:            1 << 30,
+				MemoryReservation: 1 << 29,
+				PidsLimit:         256,
+				Ulimits:           []Ulimit{{Name: "nofile", Soft: 1024, Hard: 2048}, {Name: "nproc", Soft: 64, Hard: 128}},
+				Cores:             1,
+			},
+		},
+		{
+			name: "zero seed limits keep service ones",
+			seed: "empty",
+			want: service.Resources,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, service.ResourcesOf(tt.seed))
+		})
+	}
+}
+
+func TestResources_OverrideKeepsOriginal(t *testing.T) {
+	resources := Resources{Memory: 1 << 30, Ulimits: []Ulimit{{Name: "nofile", Soft: 1024, Hard: 2048}}}
+
+	_ = resources.Override(Resources{Memory: 2 << 30, Ulimits: []Ulimit{{Name: "nofile", Soft: 1, Hard: 1}}})
+
+	assert.Equal(t, Resources{Memory: 1 << 30, Ulimits: []Ulimit{{Name: "nofile", Soft: 1024, Hard: 2048}}}, resources)
+}
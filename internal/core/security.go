@@ -0,0 +1,28 @@
+package core
+
+// Security hardens containers of service. Zero value keeps Docker defaults.
+type Security struct {
+	ReadOnlyRootFS bool
+	Tmpfs          map[string]string // Scratch mounts of writable directories by paths with mount options, e.g. "/tmp": "size=64m"
+
+	CapDrop         []string // Kernel capabilities removed from container, e.g. "ALL"
+	CapAdd          []string
+	NoNewPrivileges bool
+
+	User           string // Non-root user of container processes, e.g. "1000:1000"
+	SeccompProfile string // Path to JSON seccomp profile. Docker default profile is used when empty.
+
+	DisableEgress bool // Container is attached to internal network without access to outside world
+}
+
+// Enabled reports if any hardening is requested
+func (s Security) Enabled() bool {
+	return s.ReadOnlyRootFS ||
+		len(s.Tmpfs) != 0 ||
+		len(s.CapDrop) != 0 ||
+		len(s.CapAdd) != 0 ||
+		s.NoNewPrivileges ||
+		s.User != "" ||
+		s.SeccompProfile != "" ||
+		s.DisableEgress
+}
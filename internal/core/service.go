@@ -0,0 +1,115 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var ErrUnknownService = errors.New("unknown service")
+
+// Service is the compute service, which containers are run by zapuskator
+type Service struct {
+	Name  string
+	Image string
+	Port  int
+
+	HealthPath    string
+	ProgressPath  string // Path of endpoint reporting initialization progress in percents. Empty disables polling.
+	CalculatePath string // Input is passed in path after it or in request body
+
+	ParamsTemplate ParamsTemplate
+
+	Resources     Resources
+	SeedResources map[string]Resources // Overrides of service resources for particular seeds
+
+	Security Security
+
+	// Maximum size of calculation input in bytes. Zero means the common limit is used.
+	MaxInputSize int64
+
+	// Service specific timeouts. Zero means the common one is used.
+	ContainerWaitTimeout     time.Duration
+	CalculationTimeout       time.Duration
+	InactiveContainerTimeout time.Duration
+}
+
+// ResourcesOf returns resources of service container for the seed
+func (s Service) ResourcesOf(seed string) Resources {
+	if resources, ok := s.SeedResources[seed]; ok {
+		return s.Resources.Override(resources)
+	}
+
+	return s.Resources
+}
+
+// ParamsTemplate describes how container params are passed to container of the service.
+// Values are Go templates executed with params map, e.g. "{{.seed}}" or `{{.threads | default "4"}}`.
+// Absent params are rendered as empty strings.
+type ParamsTemplate struct {
+	Env    map[string]string // Environment variables by names
+	Args   []string          // Container command
+	Labels map[string]string
+}
+
+// DefaultParamsTemplate passes seed in SEED environment variable
+var DefaultParamsTemplate = ParamsTemplate{
+	Env: map[string]string{
+		"SEED": "{{.seed}}",
+	},
+}
+
+// Services is the routing table of compute services
+type Services struct {
+	byName      map[string]Service
+	defaultName string
+}
+
+// NewServices makes routing table. The default service handles requests without service name.
+func NewServices(defaultName string, services ...Service) (*Services, error) {
+	result := &Services{
+		byName:      make(map[string]Service, len(services)),
+		defaultName: defaultName,
+	}
+
+	for _, service := range services {
+		if service.Name == "" || service.Image == "" || service.Port == 0 {
+			return nil, fmt.Errorf("service '%s': name, image and port are required", service.Name)
+		}
+		if _, ok := result.byName[service.Name]; ok {
+			return nil, fmt.Errorf("service '%s' is defined twice", service.Name)
+		}
+
+		result.byName[service.Name] = service
+	}
+
+	if _, ok := result.byName[defaultName]; !ok {
+		return nil, fmt.Errorf("default service '%s': %w", defaultName, ErrUnknownService)
+	}
+
+	return result, nil
+}
+
+// Get returns service by its name. Empty name means the default service.
+func (s *Services) Get(name string) (Service, error) {
+	if name == "" {
+		name = s.defaultName
+	}
+
+	service, ok := s.byName[name]
+	if !ok {
+		return Service{}, fmt.Errorf("service '%s': %w", name, ErrUnknownService)
+	}
+
+	return service, nil
+}
+
+// All returns all services
+func (s *Services) All() []Service {
+	result := make([]Service, 0, len(s.byName))
+	for _, service := range s.byName {
+		result = append(result, service)
+	}
+
+	return result
+}
@@ -0,0 +1,312 @@
+package docker
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+)
+
+// Number of cores requested by container is kept in its label to allocate them again on container start
+const coresLabel = "zapuskator.cores"
+
+// cpuAllocator assigns disjoint sets of cores to containers, so calculations don't contend for CPU.
+// Containers without enough free cores are not pinned: they run on any host cores.
+type cpuAllocator struct {
+	cores  []int          // All allocatable cores, sorted
+	owners map[int]string // Container IDs by assigned cores
+
+	lock sync.Mutex
+}
+
+func newCPUAllocator(cpuset string) (*cpuAllocator, error) {
+	cores, err := parseCPUSet(cpuset)
+	if err != nil {
+		return nil, err
+	}
+
+	return &cpuAllocator{
+		cores:  cores,
+		owners: make(map[int]string, len(cores)),
+	}, nil
+}
+
+// allocate assigns <n> free cores to the container instead of its current ones.
+// Returns false, when there are not enough free cores.
+func (a *cpuAllocator) allocate(id string, n int) ([]int, bool) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	a.release(id)
+
+	var result []int
+	for _, core := range a.cores {
+		if len(result) == n {
+			break
+		}
+		if _, ok := a.owners[core]; !ok {
+			result = append(result, core)
+		}
+	}
+
+	if len(result) < n {
+		return nil, false
+	}
+
+	for _, core := range result {
+		a.owners[core] = id
+	}
+
+	return result, true
+}
+
+// assign marks cores as used by the container. Is used to restore allocations of running containers.
+// Returns false and assigns nothing, when some of cores are not allocatable or are assigned to another container.
+func (a *cpuAllocator) assign(id string, cores []int) bool {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	allocatable := make(map[int]bool, len(a.cores))
+	for _, core := range a.cores {
+		allocatable[core] = true
+	}
+
+	for _, core := range cores {
+		owner, assigned := a.owners[core]
+		if !allocatable[core] || assigned && owner != id {
+			return false
+		}
+	}
+
+	for _, core := range cores {
+		a.owners[core] = id
+	}
+	return true
+}
+
+// rename moves cores to the container, created with cores allocated in advance
+func (a *cpuAllocator) rename(oldID string, newID string) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	for core, owner := range a.owners {
+		if owner == oldID {
+			a.owners[core] = newID
+		}
+	}
+}
+
+// free releases cores of the container
+func (a *cpuAllocator) free(id string) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	a.release(id)
+}
+
+// release is NOT thread safe
+func (a *cpuAllocator) release(id string) {
+	for core, owner := range a.owners {
+		if owner == id {
+			delete(a.owners, core)
+		}
+	}
+}
+
+// of returns cores assigned to the container
+func (a *cpuAllocator) of(id string) []int {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	var result []int
+	for core, owner := range a.owners {
+		if owner == id {
+			result = append(result, core)
+		}
+	}
+
+	sort.Ints(result)
+	return result
+}
+
+// cpusetOf allocates cores to the container and returns its cpuset.
+// Cpuset is empty, when there are not enough free cores: container is not pinned then.
+func (a *cpuAllocator) cpusetOf(id string, cores int) string {
+	allocated, ok := a.allocate(id, cores)
+	if ok {
+		return formatCPUSet(allocated)
+	}
+
+	log.Printf("[Docker] no '%d' free cores for container '%s', container is not pinned", cores, id)
+	return ""
+}
+
+// CPUSet returns cores assigned to the container by allocator. Is empty, when container is not pinned.
+func (m *Manager) CPUSet(id string) string {
+	if m.cpus == nil {
+		return ""
+	}
+
+	return formatCPUSet(m.cpus.of(id))
+}
+
+// ReleaseCPUs frees cores of the container stopped by itself
+func (m *Manager) ReleaseCPUs(id string) {
+	if m.cpus != nil {
+		m.cpus.free(id)
+	}
+}
+
+// RestoreCPUAllocation finds cores used by running containers, e.g. after zapuskator restart.
+func (m *Manager) RestoreCPUAllocation(ctx context.Context) error {
+	if m.cpus == nil {
+		return nil
+	}
+
+	containers, err := m.docker.ContainerList(ctx, types.ContainerListOptions{
+		Filters: filters.NewArgs(filters.Arg("label", coresLabel)),
+	})
+	if err != nil {
+		return newError("list", "", err)
+	}
+
+	for _, c := range containers {
+		info, err := m.docker.ContainerInspect(ctx, c.ID)
+		if err != nil {
+			return newError("inspect", c.ID, err)
+		}
+
+		cores, err := parseCPUSet(info.HostConfig.CpusetCpus)
+		if err != nil {
+			return fmt.Errorf("container '%s': %w", c.ID, err)
+		}
+
+		if len(cores) == 0 || strconv.Itoa(len(cores)) != info.Config.Labels[coresLabel] {
+			// Container is not pinned
+			continue
+		}
+
+		if !m.cpus.assign(c.ID, cores) {
+			// Cores are pinned on the next container start
+			log.Printf("[Docker] cores '%s' of container '%s' are not available, container keeps them until the next start",
+				info.HostConfig.CpusetCpus, c.ID)
+		}
+	}
+
+	return nil
+}
+
+// updateCPUSet allocates cores to the container before its start: cores were released on its stop
+func (m *Manager) updateCPUSet(ctx context.Context, id string) error {
+	if m.cpus == nil {
+		return nil
+	}
+
+	info, err := m.docker.ContainerInspect(ctx, id)
+	if err != nil {
+		return newError("inspect", id, err)
+	}
+
+	cores, err := strconv.Atoi(info.Config.Labels[coresLabel])
+	if err != nil || cores <= 0 {
+		// Container doesn't need dedicated cores
+		return nil
+	}
+
+	if len(m.cpus.of(id)) == cores {
+		// Cores were allocated on container creation
+		return nil
+	}
+
+	cpuset := m.cpus.cpusetOf(id, cores)
+	if cpuset == "" {
+		if info.HostConfig.CpusetCpus == "" {
+			// Container is not pinned already
+			return nil
+		}
+
+		// Docker keeps current cpuset on update with empty one, so the container is unpinned explicitly
+		cpuset, err = m.hostCPUSet(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = m.docker.ContainerUpdate(ctx, id, container.UpdateConfig{
+		Resources: container.Resources{
+			CpusetCpus: cpuset,
+		},
+	})
+	if err != nil {
+		m.cpus.free(id)
+		return newError("update", id, err)
+	}
+
+	return nil
+}
+
+// hostCPUSet returns all cores of Docker host
+func (m *Manager) hostCPUSet(ctx context.Context) (string, error) {
+	info, err := m.docker.Info(ctx)
+	if err != nil {
+		return "", newError("info", "", err)
+	}
+
+	return fmt.Sprintf("0-%d", info.NCPU-1), nil
+}
+
+// parseCPUSet parses list of cores in Docker format: "0-3,8"
+func parseCPUSet(cpuset string) ([]int, error) {
+	if cpuset == "" {
+		return nil, nil
+	}
+
+	seen := make(map[int]bool)
+	var result []int
+	for _, part := range strings.Split(cpuset, ",") {
+		bounds := strings.SplitN(strings.TrimSpace(part), "-", 2)
+
+		first, err := strconv.Atoi(bounds[0])
+		if err != nil {
+			return nil, fmt.Errorf("invalid cpuset '%s': %w", cpuset, err)
+		}
+
+		last := first
+		if len(bounds) == 2 {
+			last, err = strconv.Atoi(bounds[1])
+			if err != nil {
+				return nil, fmt.Errorf("invalid cpuset '%s': %w", cpuset, err)
+			}
+		}
+
+		if first < 0 || last < first {
+			return nil, fmt.Errorf("invalid cpuset '%s': bad range '%s'", cpuset, part)
+		}
+
+		for core := first; core <= last; core++ {
+			if !seen[core] {
+				seen[core] = true
+				result = append(result, core)
+			}
+		}
+	}
+
+	sort.Ints(result)
+	return result, nil
+}
+
+func formatCPUSet(cores []int) string {
+	parts := make([]string, 0, len(cores))
+	for _, core := range cores {
+		parts = append(parts, strconv.Itoa(core))
+	}
+
+	return strings.Join(parts, ",")
+}
@@ -0,0 +1,160 @@
+package docker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCPUSet(t *testing.T) {
+	tests := []struct {
+		cpuset  string
+		want    []int
+		wantErr bool
+	}{
+		{cpuset: "", want: nil},
+		{cpuset: "3", want: []int{3}},
+		{cpuset: "0-3", want: []int{0, 1, 2, 3}},
+		{cpuset: "8, 2-3,0", want: []int{0, 2, 3, 8}},
+		{cpuset: "1-2,2-3", want: []int{1, 2, 3}},
+		{cpuset: "5-5", want: []int{5}},
+		{cpuset: "3-1", wantErr: true},
+		{cpuset: "-1", wantErr: true},
+		{cpuset: "a", wantErr: true},
+		{cpuset: "1-b", wantErr: true},
+		{cpuset: "1,", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.cpuset, func(t *testing.T) {
+			got, err := parseCPUSet(tt.cpuset)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, formatCPUSet(tt.want), formatCPUSet(got))
+		})
+	}
+}
+
+func TestCPUAllocator(t *testing.T) {
+	type step struct {
+		op    string // allocate, assign, rename, free
+		id    string
+		newID string
+		n     int
+		cores []int
+
+		want   []int
+		wantOK bool
+	}
+
+	tests := []struct {
+		name  string
+		steps []step
+		owned map[string][]int
+	}{
+		{
+			name: "disjoint allocations",
+			steps: []step{
+				{op: "allocate", id: "a", n: 2, want: []int{0, 1}, wantOK: true},
+				{op: "allocate", id: "b", n: 2, want: []int{2, 3}, wantOK: true},
+			},
+			owned: map[string][]int{"a": {0, 1}, "b": {2, 3}},
+		},
+		{
+			name: "not enough free cores",
+			steps: []step{
+				{op: "allocate", id: "a", n: 3, want: []int{0, 1, 2}, wantOK: true},
+				{op: "allocate", id: "b", n: 2, wantOK: false},
+			},
+			owned: map[string][]int{"a": {0, 1, 2}, "b": nil},
+		},
+		{
+			name: "reallocation replaces cores",
+			steps: []step{
+				{op: "allocate", id: "a", n: 4, want: []int{0, 1, 2, 3}, wantOK: true},
+				{op: "allocate", id: "a", n: 1, want: []int{0}, wantOK: true},
+			},
+			owned: map[string][]int{"a": {0}},
+		},
+		{
+			name: "free",
+			steps: []step{
+				{op: "allocate", id: "a", n: 4, want: []int{0, 1, 2, 3}, wantOK: true},
+				{op: "free", id: "a"},
+				{op: "allocate", id: "b", n: 4, want: []int{0, 1, 2, 3}, wantOK: true},
+			},
+			owned: map[string][]int{"a": nil, "b": {0, 1, 2, 3}},
+		},
+		{
+			name: "rename",
+			steps: []step{
+				{op: "allocate", id: "creating", n: 2, want: []int{0, 1}, wantOK: true},
+				{op: "rename", id: "creating", newID: "a"},
+			},
+			owned: map[string][]int{"creating": nil, "a": {0, 1}},
+		},
+		{
+			name: "assign restored cores",
+			steps: []step{
+				{op: "assign", id: "a", cores: []int{1, 2}, wantOK: true},
+				{op: "allocate", id: "b", n: 2, want: []int{0, 3}, wantOK: true},
+			},
+			owned: map[string][]int{"a": {1, 2}, "b": {0, 3}},
+		},
+		{
+			name: "assign rejects cores of another container",
+			steps: []step{
+				{op: "assign", id: "a", cores: []int{1, 2}, wantOK: true},
+				{op: "assign", id: "b", cores: []int{2, 3}, wantOK: false},
+			},
+			owned: map[string][]int{"a": {1, 2}, "b": nil},
+		},
+		{
+			name: "assign rejects not allocatable cores",
+			steps: []step{
+				{op: "assign", id: "a", cores: []int{3, 4}, wantOK: false},
+			},
+			owned: map[string][]int{"a": nil},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, err := newCPUAllocator("0-3")
+			require.NoError(t, err)
+
+			for i, s := range tt.steps {
+				switch s.op {
+				case "allocate":
+					got, ok := a.allocate(s.id, s.n)
+					assert.Equal(t, s.wantOK, ok, "step %d", i)
+					assert.Equal(t, s.want, got, "step %d", i)
+				case "assign":
+					assert.Equal(t, s.wantOK, a.assign(s.id, s.cores), "step %d", i)
+				case "rename":
+					a.rename(s.id, s.newID)
+				case "free":
+					a.free(s.id)
+				}
+			}
+
+			for id, cores := range tt.owned {
+				assert.Equal(t, cores, a.of(id), "cores of '%s'", id)
+			}
+		})
+	}
+}
+
+func TestCPUAllocator_CPUSetOf(t *testing.T) {
+	a, err := newCPUAllocator("2-3")
+	require.NoError(t, err)
+
+	assert.Equal(t, "2,3", a.cpusetOf("a", 2))
+	assert.Equal(t, "", a.cpusetOf("b", 1), "container without free cores is not pinned")
+}
@@ -0,0 +1,84 @@
+package docker
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	dclient "github.com/docker/docker/client"
+	"github.com/docker/docker/errdefs"
+)
+
+// Docker daemon reports lack of host resources in messages of system errors only
+var resourceExhaustedMessages = []string{
+	"no space left on device",
+	"cannot allocate memory",
+	"out of memory",
+}
+
+// Error is a failure of Docker daemon to perform operation with container.
+type Error struct {
+	Op          string
+	ContainerID string
+	Err         error
+}
+
+func newError(op string, containerID string, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return &Error{
+		Op:          op,
+		ContainerID: containerID,
+		Err:         err,
+	}
+}
+
+func (e *Error) Error() string {
+	if e.ContainerID == "" {
+		return fmt.Sprintf("docker failed to %s container: %v", e.Op, e.Err)
+	}
+	return fmt.Sprintf("docker failed to %s container '%s': %v", e.Op, e.ContainerID, e.Err)
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// IsNotFound reports if the error is caused by container absence in Docker
+func IsNotFound(err error) bool {
+	var dockerErr *Error
+	if !errors.As(err, &dockerErr) {
+		return false
+	}
+
+	return dclient.IsErrNotFound(dockerErr.Err)
+}
+
+// IsUnavailable reports if Docker daemon can't serve requests right now, e.g. it is unreachable or restarting
+func IsUnavailable(err error) bool {
+	var dockerErr *Error
+	if !errors.As(err, &dockerErr) {
+		return false
+	}
+
+	return dclient.IsErrConnectionFailed(dockerErr.Err) || errdefs.IsUnavailable(dockerErr.Err)
+}
+
+// IsResourceExhausted reports if Docker host has not enough memory or disk space for container
+func IsResourceExhausted(err error) bool {
+	var dockerErr *Error
+	if !errors.As(err, &dockerErr) {
+		return false
+	}
+
+	message := strings.ToLower(dockerErr.Err.Error())
+	for _, m := range resourceExhaustedMessages {
+		if strings.Contains(message, m) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,188 @@
+package docker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"strings"
+
+	"github.com/docker/distribution/reference"
+	"github.com/docker/docker/api/types"
+	dclient "github.com/docker/docker/client"
+
+	"github.com/denkoren/mi-labs-test/internal/core"
+)
+
+var ErrImageNotFound = errors.New("image not found")
+
+// ResolveImage finds local image of the service version and returns its digest, see imageDigest.
+// Version is an image tag, digest ('sha256:...' or 'repository@sha256:...') or local image ID.
+// The service image is used when version is empty.
+// Local images only are resolved: pulling is too long for calculation requests.
+func (m *Manager) ResolveImage(ctx context.Context, service core.Service, version string) (string, error) {
+	repository := imageRepository(service.Image)
+
+	var (
+		ref  string
+		info types.ImageInspect
+		err  error
+	)
+	for _, ref = range imageRefs(service, version) {
+		info, _, err = m.docker.ImageInspectWithRaw(ctx, ref)
+		if !dclient.IsErrNotFound(err) {
+			break
+		}
+	}
+	if dclient.IsErrNotFound(err) {
+		return "", fmt.Errorf("image '%s' of service '%s': %w", ref, service.Name, ErrImageNotFound)
+	}
+	if err != nil {
+		return "", fmt.Errorf("docker failed to inspect image '%s': %w", ref, err)
+	}
+
+	if !hasRepository(info.RepoTags, repository) && !hasRepository(info.RepoDigests, repository) {
+		// Image ID of another service's image
+		return "", fmt.Errorf("image '%s' is not a version of service '%s': %w", ref, service.Name, ErrImageNotFound)
+	}
+
+	return imageDigest(info, repository), nil
+}
+
+// EnsureImage resolves the image of service version like ResolveImage does,
+// but pulls the image from registry first, if there is no such image locally and pulling is enabled.
+func (m *Manager) EnsureImage(ctx context.Context, service core.Service, version string) (string, error) {
+	digest, err := m.ResolveImage(ctx, service, version)
+	if !errors.Is(err, ErrImageNotFound) || !m.config.PullImages {
+		return digest, err
+	}
+
+	// Local image IDs can't be pulled, Docker reports them missing in registry
+	ref := imageRefs(service, version)[0]
+	log.Printf("[Docker] image '%s' of service '%s' not found locally, pulling...", ref, service.Name)
+
+	err = m.pullImage(ctx, ref)
+	if err != nil {
+		return "", fmt.Errorf("image '%s' of service '%s' is absent and failed to pull: %w", ref, service.Name, err)
+	}
+
+	log.Printf("[Docker] image '%s' of service '%s' pulled", ref, service.Name)
+	return m.ResolveImage(ctx, service, version)
+}
+
+// EnsureServiceImages makes sure images of all services are present locally and returns their IDs by service names.
+// All missing images are reported in the error.
+func (m *Manager) EnsureServiceImages(ctx context.Context) (map[string]string, error) {
+	digests := make(map[string]string)
+
+	var missing []string
+	for _, service := range m.config.Services.All() {
+		digest, err := m.EnsureImage(ctx, service, "")
+		if err != nil {
+			missing = append(missing, err.Error())
+			continue
+		}
+
+		log.Printf("[Docker] image '%s' of service '%s' resolved to '%s'", service.Image, service.Name, digest)
+		digests[service.Name] = digest
+	}
+
+	if len(missing) != 0 {
+		return nil, fmt.Errorf("images of services are not available:\n\t%s", strings.Join(missing, "\n\t"))
+	}
+
+	return digests, nil
+}
+
+// pullImage pulls image from registry and waits for the pull to finish
+func (m *Manager) pullImage(ctx context.Context, ref string) error {
+	reader, err := m.puller.ImagePull(ctx, ref, types.ImagePullOptions{})
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	// Pull progress is streamed as JSON messages. Failures are reported by messages too.
+	decoder := json.NewDecoder(reader)
+	for {
+		var message struct {
+			Error string `json:"error"`
+		}
+
+		err = decoder.Decode(&message)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if message.Error != "" {
+			return errors.New(message.Error)
+		}
+	}
+}
+
+// imageRefs makes references of service image version in order of preference.
+// Version 'sha256:...' is either digest of image in registry or local image ID: images built locally have no digests.
+func imageRefs(service core.Service, version string) []string {
+	repository := imageRepository(service.Image)
+
+	switch {
+	case version == "":
+		return []string{service.Image}
+	case strings.Contains(version, "@"):
+		return []string{version}
+	case strings.HasPrefix(version, "sha256:"):
+		if _, err := reference.ParseNormalizedNamed(repository + "@" + version); err != nil {
+			// Not a valid digest, e.g. short image ID
+			return []string{version}
+		}
+		return []string{repository + "@" + version, version}
+	}
+
+	return []string{repository + ":" + version}
+}
+
+// imageDigest identifies the image by its digest in registry, so it is the same on all hosts.
+// Images never pushed or pulled have no digests and are identified by their local IDs.
+func imageDigest(info types.ImageInspect, repository string) string {
+	for _, digest := range info.RepoDigests {
+		if hasRepository([]string{digest}, repository) {
+			return digest
+		}
+	}
+
+	return info.ID
+}
+
+// imageRepository strips tag and digest from image reference
+func imageRepository(image string) string {
+	named, err := reference.ParseNormalizedNamed(image)
+	if err != nil {
+		// Invalid references are reported by Docker
+		return image
+	}
+
+	return reference.FamiliarName(named)
+}
+
+// hasRepository checks if any of image tags or digests belongs to the repository.
+// References are compared normalized: 'ubuntu' and 'docker.io/library/ubuntu' are the same repository.
+func hasRepository(refs []string, repository string) bool {
+	named, err := reference.ParseNormalizedNamed(repository)
+	if err != nil {
+		return false
+	}
+
+	for _, ref := range refs {
+		refNamed, err := reference.ParseNormalizedNamed(ref)
+		if err == nil && refNamed.Name() == named.Name() {
+			return true
+		}
+	}
+
+	return false
+}
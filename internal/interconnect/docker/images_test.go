@@ -0,0 +1,161 @@
+package docker
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/denkoren/mi-labs-test/internal/core"
+)
+
+func TestImageRepository(t *testing.T) {
+	tests := []struct {
+		image string
+		want  string
+	}{
+		{image: "compute", want: "compute"},
+		{image: "compute:1.0", want: "compute"},
+		{image: "docker.io/library/compute:1.0", want: "compute"},
+		{image: "denkoren/compute@sha256:0123456789012345678901234567890123456789012345678901234567890123", want: "denkoren/compute"},
+		{image: "registry:5000/compute", want: "registry:5000/compute"},
+		{image: "registry:5000/compute:1.0", want: "registry:5000/compute"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.image, func(t *testing.T) {
+			assert.Equal(t, tt.want, imageRepository(tt.image))
+		})
+	}
+}
+
+const (
+	testDigest  = "sha256:0123456789012345678901234567890123456789012345678901234567890123"
+	testImageID = "sha256:4567456745674567456745674567456745674567456745674567456745674567"
+)
+
+func TestImageRefs(t *testing.T) {
+	tests := []struct {
+		name    string
+		image   string
+		version string
+		want    []string
+	}{
+		{name: "service image", image: "compute:1.0", want: []string{"compute:1.0"}},
+		{name: "tag", image: "compute:1.0", version: "2.0", want: []string{"compute:2.0"}},
+		{name: "tag in private registry", image: "registry:5000/compute:1.0", version: "2.0", want: []string{"registry:5000/compute:2.0"}},
+		{name: "digest or image ID", image: "compute:1.0", version: testDigest, want: []string{"compute@" + testDigest, testDigest}},
+		{name: "short image ID", image: "compute:1.0", version: "sha256:0123", want: []string{"sha256:0123"}},
+		{name: "reference with digest", image: "compute:1.0", version: "compute@" + testDigest, want: []string{"compute@" + testDigest}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, imageRefs(core.Service{Image: tt.image}, tt.version))
+		})
+	}
+}
+
+func TestHasRepository(t *testing.T) {
+	tests := []struct {
+		name       string
+		refs       []string
+		repository string
+		want       bool
+	}{
+		{name: "tag", refs: []string{"compute:1.0"}, repository: "compute", want: true},
+		{name: "normalized tag", refs: []string{"compute:1.0"}, repository: "docker.io/library/compute", want: true},
+		{name: "digest", refs: []string{"denkoren/compute@sha256:0123456789012345678901234567890123456789012345678901234567890123"}, repository: "denkoren/compute", want: true},
+		{name: "private registry", refs: []string{"registry:5000/compute:1.0"}, repository: "registry:5000/compute", want: true},
+		{name: "repository prefix", refs: []string{"compute-gpu:1.0"}, repository: "compute"},
+		{name: "another registry", refs: []string{"registry:5000/compute:1.0"}, repository: "compute"},
+		{name: "no refs", repository: "compute"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, hasRepository(tt.refs, tt.repository))
+		})
+	}
+}
+
+// newImagesTestManager creates manager of Docker, that has the images only
+func newImagesTestManager(t *testing.T, images ...types.ImageInspect) *Manager {
+	byRef := make(map[string]types.ImageInspect)
+	for _, image := range images {
+		byRef[image.ID] = image
+		for _, ref := range append(image.RepoTags, image.RepoDigests...) {
+			byRef[ref] = image
+		}
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path := r.URL.Path[strings.Index(r.URL.Path[1:], "/")+1:] // Without API version
+		if r.URL.Path == "/_ping" {
+			w.Header().Set("API-Version", "1.41")
+			return
+		}
+
+		image, ok := byRef[strings.TrimSuffix(strings.TrimPrefix(path, "/images/"), "/json")]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			_ = json.NewEncoder(w).Encode(types.ErrorResponse{Message: "no such image"})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(image)
+	}))
+	t.Cleanup(server.Close)
+
+	services, err := core.NewServices("compute", core.Service{Name: "compute", Image: "compute:1.0", Port: 8080})
+	require.NoError(t, err)
+
+	m, err := NewManager(ManagerConfig{Host: "tcp://" + server.Listener.Addr().String(), RequestTimeout: time.Second, Services: services})
+	require.NoError(t, err)
+
+	return m
+}
+
+func TestManager_ResolveImage(t *testing.T) {
+	m := newImagesTestManager(t,
+		types.ImageInspect{ID: "sha256:1111", RepoTags: []string{"compute:1.0"}, RepoDigests: []string{"other@" + testDigest, "compute@" + testDigest}},
+		types.ImageInspect{ID: testImageID, RepoTags: []string{"compute:dev"}},
+		types.ImageInspect{ID: "sha256:2222", RepoTags: []string{"other:1.0"}},
+	)
+	service, err := m.config.Services.Get("compute")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		version string
+		want    string
+		wantErr error
+	}{
+		{name: "service image", want: "compute@" + testDigest},
+		{name: "tag", version: "1.0", want: "compute@" + testDigest},
+		{name: "digest", version: testDigest, want: "compute@" + testDigest},
+		{name: "reference with digest", version: "compute@" + testDigest, want: "compute@" + testDigest},
+		{name: "local image", version: "dev", want: testImageID},
+		{name: "local image ID", version: testImageID, want: testImageID},
+		{name: "another service image", version: "sha256:2222", wantErr: ErrImageNotFound},
+		{name: "unknown tag", version: "2.0", wantErr: ErrImageNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := m.ResolveImage(context.Background(), service, tt.version)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"strconv"
 	"time"
 
 	"github.com/denkoren/mi-labs-test/internal/core"
@@ -15,12 +16,31 @@ import (
 type ManagerConfig struct {
 	Host           string
 	RequestTimeout time.Duration
-	ImageTag       string
+	Services       *core.Services
+
+	// Pull images absent locally from registry. Images are pulled at startup and by admin requests only.
+	PullImages  bool
+	PullTimeout time.Duration
+
+	// Cores available for pinning to containers, e.g. "2-15". Empty disables CPU pinning.
+	CPUSet string
+
+	// Network of containers without egress. Is created on startup if needed.
+	InternalNetwork string
+
+	// Network of containers. Is created on startup if missing. Default bridge network is used when empty.
+	Network string
+	// How zapuskator reaches containers. IP addresses are used when nil.
+	Addressing Addressing
 }
 
 type Manager struct {
 	config ManagerConfig
 	docker *dclient.Client
+	puller *dclient.Client // Image pulls take much longer than other requests
+	cpus   *cpuAllocator   // Is nil when CPU pinning is disabled
+
+	seccomp seccompProfiles
 }
 
 type ContainerState string
@@ -37,8 +57,17 @@ const (
 )
 
 func NewManager(config ManagerConfig) (*Manager, error) {
+	if config.Addressing == nil {
+		config.Addressing = IPAddressing{}
+	}
+
+	err := checkAddressing(config)
+	if err != nil {
+		return nil, err
+	}
+
 	docker, err := dclient.NewClientWithOpts(
-		//dclient.WithHost(config.Host),
+		withHost(config.Host),
 		dclient.WithAPIVersionNegotiation(),
 		dclient.WithTimeout(config.RequestTimeout),
 	)
@@ -47,57 +76,177 @@ func NewManager(config ManagerConfig) (*Manager, error) {
 		return nil, err
 	}
 
+	puller, err := dclient.NewClientWithOpts(
+		withHost(config.Host),
+		dclient.WithAPIVersionNegotiation(),
+		dclient.WithTimeout(config.PullTimeout),
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var cpus *cpuAllocator
+	if config.CPUSet != "" {
+		cpus, err = newCPUAllocator(config.CPUSet)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return &Manager{
 		config: config,
 		docker: docker,
+		puller: puller,
+		cpus:   cpus,
 	}, nil
 }
 
-func (m *Manager) CreateContainer(ctx context.Context, params core.ContainerParams) (string, error) {
-	log.Printf("[Docker] creating container for seed: %s", params.Seed)
+// withHost connects client to Docker daemon at the host, e.g. 'tcp://127.0.0.1:2375'. Default socket is used when empty.
+func withHost(host string) dclient.Opt {
+	if host == "" {
+		return func(*dclient.Client) error { return nil }
+	}
 
-	seedEnv := fmt.Sprintf("SEED=%s", params.Seed)
+	return dclient.WithHost(host)
+}
 
-	createResult, err := m.docker.ContainerCreate(ctx, &container.Config{
-		Image: m.config.ImageTag,
-		Tty:   false,
-		Env: []string{seedEnv},
-	}, nil, nil, nil, "")
+// CreateContainer creates container with the params and returns its ID and resource limits applied.
+func (m *Manager) CreateContainer(ctx context.Context, params core.ContainerParams) (string, core.Resources, error) {
+	log.Printf("[Docker] creating container of service '%s' for seed: %s", params.Service, params.Seed)
 
+	service, err := m.config.Services.Get(params.Service)
 	if err != nil {
-		return "", err
+		return "", core.Resources{}, err
+	}
+
+	rendered, err := renderParams(service.ParamsTemplate, params)
+	if err != nil {
+		// Not a Docker failure: the template is misconfigured
+		return "", core.Resources{}, fmt.Errorf("failed to render params of service '%s': %w", service.Name, err)
+	}
+
+	resources := service.ResourcesOf(params.Seed)
+
+	image := service.Image
+	if params.ImageDigest != "" {
+		image = params.ImageDigest
+	}
+
+	hostConfig := &container.HostConfig{
+		NetworkMode: container.NetworkMode(m.config.Network),
+		Resources:   hostResources(resources),
+	}
+
+	// Container ID is unknown until it is created, so the cores are allocated to temporary owner
+	allocationID := "creating:" + params.Key()
+	if m.cpus != nil && resources.Cores > 0 {
+		rendered.labels[coresLabel] = strconv.FormatInt(resources.Cores, 10)
+		hostConfig.Resources.CpusetCpus = m.cpus.cpusetOf(allocationID, int(resources.Cores))
+	}
+
+	config := &container.Config{
+		Image:  image,
+		Tty:    false,
+		Env:    rendered.env,
+		Cmd:    rendered.args,
+		Labels: rendered.labels,
+	}
+
+	err = m.applySecurity(config, hostConfig, service.Security)
+	if err != nil {
+		m.ReleaseCPUs(allocationID)
+		return "", core.Resources{}, fmt.Errorf("failed to apply security profile of service '%s': %w", service.Name, err)
+	}
+
+	createResult, err := m.docker.ContainerCreate(ctx, config, hostConfig, nil, nil, "")
+
+	if err != nil {
+		m.ReleaseCPUs(allocationID)
+		return "", core.Resources{}, newError("create", "", err)
+	}
+
+	if m.cpus != nil {
+		m.cpus.rename(allocationID, createResult.ID)
+		resources.CPUSet = m.CPUSet(createResult.ID)
 	}
 
 	log.Printf("[Docker] container with ID '%s' created for seed %s", createResult.ID, params.Seed)
-	return createResult.ID, nil
+	return createResult.ID, resources, nil
 }
 
 func (m *Manager) StartContainer(ctx context.Context, id string) (string, error) {
 	log.Printf("[Docker] starting container '%s'", id)
 
-	err := m.docker.ContainerStart(ctx, id, types.ContainerStartOptions{})
+	err := m.updateCPUSet(ctx, id)
 	if err != nil {
 		return "", err
 	}
 
+	err = m.docker.ContainerStart(ctx, id, types.ContainerStartOptions{})
+	if err != nil {
+		m.ReleaseCPUs(id)
+		return "", newError("start", id, err)
+	}
+
 	dInfo, err := m.docker.ContainerInspect(ctx, id)
 	if err != nil {
-		return "", err
+		return "", newError("inspect", id, err)
+	}
+
+	return m.config.Addressing.ContainerAddr(dInfo)
+}
+
+// RestartContainer restarts the container and returns its new address.
+func (m *Manager) RestartContainer(ctx context.Context, id string) (string, error) {
+	log.Printf("[Docker] restarting container '%s'", id)
+
+	err := m.docker.ContainerRestart(ctx, id, &m.config.RequestTimeout)
+	if err != nil {
+		return "", newError("restart", id, err)
 	}
 
-	return dInfo.NetworkSettings.IPAddress, nil
+	dInfo, err := m.docker.ContainerInspect(ctx, id)
+	if err != nil {
+		return "", newError("inspect", id, err)
+	}
+
+	return m.config.Addressing.ContainerAddr(dInfo)
 }
 
 func (m *Manager) ContainerState(ctx context.Context, id string) (ContainerState, error) {
 	info, err := m.docker.ContainerInspect(ctx, id)
 	if err != nil {
-		return ContainerStateUnknown, err
+		return ContainerStateUnknown, newError("inspect", id, err)
 	}
 
 	return ContainerState(info.State.Status), nil
 }
 
+// ContainerImage returns ID of the image container was created from
+func (m *Manager) ContainerImage(ctx context.Context, id string) (string, error) {
+	info, err := m.docker.ContainerInspect(ctx, id)
+	if err != nil {
+		return "", newError("inspect", id, err)
+	}
+
+	return info.Image, nil
+}
+
+func (m *Manager) RemoveContainer(ctx context.Context, id string) error {
+	log.Printf("[Docker] removing container '%s'", id)
+	err := m.docker.ContainerRemove(ctx, id, types.ContainerRemoveOptions{Force: true})
+	if err == nil || dclient.IsErrNotFound(err) {
+		m.ReleaseCPUs(id)
+	}
+	return newError("remove", id, err)
+}
+
 func (m *Manager) StopContainer(ctx context.Context, id string) error {
 	log.Printf("[Docker] stopping container '%s'", id)
-	return m.docker.ContainerStop(ctx, id, &m.config.RequestTimeout)
+	err := m.docker.ContainerStop(ctx, id, &m.config.RequestTimeout)
+	if err == nil || dclient.IsErrNotFound(err) {
+		m.ReleaseCPUs(id)
+	}
+	return newError("stop", id, err)
 }
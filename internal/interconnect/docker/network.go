@@ -0,0 +1,103 @@
+package docker
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/docker/docker/api/types"
+	dclient "github.com/docker/docker/client"
+)
+
+// Addressing decides how zapuskator reaches containers
+type Addressing interface {
+	// ContainerAddr returns host of running container, that is reachable by zapuskator
+	ContainerAddr(info types.ContainerJSON) (string, error)
+}
+
+// IPAddressing reaches containers by their IP addresses in their networks.
+// Zapuskator host should have route to the networks: it works when zapuskator runs on Docker host.
+type IPAddressing struct{}
+
+func (IPAddressing) ContainerAddr(info types.ContainerJSON) (string, error) {
+	if info.NetworkSettings.IPAddress != "" {
+		// Default bridge network
+		return info.NetworkSettings.IPAddress, nil
+	}
+
+	network := string(info.HostConfig.NetworkMode)
+	if endpoint, ok := info.NetworkSettings.Networks[network]; ok && endpoint.IPAddress != "" {
+		return endpoint.IPAddress, nil
+	}
+
+	return "", fmt.Errorf("container '%s' has no IP address in network '%s'", info.ID, network)
+}
+
+// DNSAddressing reaches containers by their names resolved by Docker DNS.
+// Works for user-defined networks only, zapuskator container must be attached to the network of containers.
+type DNSAddressing struct{}
+
+func (DNSAddressing) ContainerAddr(info types.ContainerJSON) (string, error) {
+	if !info.HostConfig.NetworkMode.IsUserDefined() {
+		return "", fmt.Errorf("container '%s' names are not resolved in network '%s'", info.ID, info.HostConfig.NetworkMode)
+	}
+
+	return strings.TrimPrefix(info.Name, "/"), nil
+}
+
+// NewAddressing returns addressing strategy by its name: 'ip' or 'dns'
+func NewAddressing(name string) (Addressing, error) {
+	switch name {
+	case "", "ip":
+		return IPAddressing{}, nil
+	case "dns":
+		return DNSAddressing{}, nil
+	}
+
+	return nil, fmt.Errorf("unknown addressing '%s'", name)
+}
+
+// checkAddressing rejects addressing, that can't reach containers in configured network
+func checkAddressing(config ManagerConfig) error {
+	if _, ok := config.Addressing.(DNSAddressing); ok && config.Network == "" {
+		return fmt.Errorf("'dns' addressing requires user-defined network: names are not resolved in default bridge network")
+	}
+
+	return nil
+}
+
+// EnsureNetwork creates network of containers, if there is no such network yet
+func (m *Manager) EnsureNetwork(ctx context.Context) error {
+	if m.config.Network == "" {
+		// Default bridge network
+		return nil
+	}
+
+	return m.ensureNetwork(ctx, m.config.Network, false)
+}
+
+func (m *Manager) ensureNetwork(ctx context.Context, name string, internal bool) error {
+	network, err := m.docker.NetworkInspect(ctx, name, types.NetworkInspectOptions{})
+	if err == nil {
+		if internal && !network.Internal {
+			return fmt.Errorf("network '%s' is not internal: containers would have access to outside world", name)
+		}
+		return nil
+	}
+	if !dclient.IsErrNotFound(err) {
+		return fmt.Errorf("docker failed to inspect network '%s': %w", name, err)
+	}
+
+	log.Printf("[Docker] creating network '%s'", name)
+	_, err = m.docker.NetworkCreate(ctx, name, types.NetworkCreate{
+		CheckDuplicate: true,
+		Driver:         "bridge",
+		Internal:       internal,
+	})
+	if err != nil {
+		return fmt.Errorf("docker failed to create network '%s': %w", name, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,31 @@
+package docker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckAddressing(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  ManagerConfig
+		wantErr bool
+	}{
+		{name: "ip in default network", config: ManagerConfig{Addressing: IPAddressing{}}},
+		{name: "ip in user-defined network", config: ManagerConfig{Addressing: IPAddressing{}, Network: "zapuskator-net"}},
+		{name: "dns in user-defined network", config: ManagerConfig{Addressing: DNSAddressing{}, Network: "zapuskator-net"}},
+		{name: "dns in default network", config: ManagerConfig{Addressing: DNSAddressing{}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkAddressing(tt.config)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,157 @@
+package docker
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"text/template"
+	"text/template/parse"
+
+	"github.com/denkoren/mi-labs-test/internal/core"
+)
+
+// Label with container params key. Helps to find container of params in Docker.
+const paramsKeyLabel = "zapuskator.params-key"
+
+var templateFuncs = template.FuncMap{
+	"default": func(def string, value string) string {
+		if value == "" {
+			return def
+		}
+		return value
+	},
+}
+
+// renderedParams are container settings made from params by template
+type renderedParams struct {
+	env    []string
+	args   []string
+	labels map[string]string
+}
+
+func renderParams(t core.ParamsTemplate, params core.ContainerParams) (renderedParams, error) {
+	named := params.Named()
+	result := renderedParams{
+		labels: map[string]string{
+			paramsKeyLabel: params.Key(),
+		},
+	}
+
+	for name, text := range t.Env {
+		value, err := renderParam(text, named)
+		if err != nil {
+			return renderedParams{}, fmt.Errorf("env '%s': %w", name, err)
+		}
+		result.env = append(result.env, name+"="+value)
+	}
+	sort.Strings(result.env)
+
+	for i, text := range t.Args {
+		value, err := renderParam(text, named)
+		if err != nil {
+			return renderedParams{}, fmt.Errorf("arg '%d': %w", i, err)
+		}
+		result.args = append(result.args, value)
+	}
+
+	for name, text := range t.Labels {
+		value, err := renderParam(text, named)
+		if err != nil {
+			return renderedParams{}, fmt.Errorf("label '%s': %w", name, err)
+		}
+		result.labels[name] = value
+	}
+
+	return result, nil
+}
+
+func renderParam(text string, named map[string]string) (string, error) {
+	tmpl, err := template.New("param").
+		Option("missingkey=zero").
+		Funcs(templateFuncs).
+		Parse(text)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	err = tmpl.Execute(&buf, named)
+	if err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
+
+// TemplateParams returns names of params used by the template: fields like '{{.genome}}'
+// and keys like '{{index . "genome-build"}}'.
+func TemplateParams(t core.ParamsTemplate) (map[string]bool, error) {
+	texts := make([]string, 0, len(t.Env)+len(t.Args)+len(t.Labels))
+	for _, text := range t.Env {
+		texts = append(texts, text)
+	}
+	texts = append(texts, t.Args...)
+	for _, text := range t.Labels {
+		texts = append(texts, text)
+	}
+
+	names := make(map[string]bool)
+	for _, text := range texts {
+		tmpl, err := template.New("param").Funcs(templateFuncs).Parse(text)
+		if err != nil {
+			return nil, err
+		}
+		collectParams(tmpl.Tree.Root, names)
+	}
+
+	return names, nil
+}
+
+func collectParams(node parse.Node, names map[string]bool) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, child := range n.Nodes {
+			collectParams(child, names)
+		}
+	case *parse.ActionNode:
+		collectParams(n.Pipe, names)
+	case *parse.PipeNode:
+		if n == nil {
+			return
+		}
+		for _, cmd := range n.Cmds {
+			collectParams(cmd, names)
+		}
+	case *parse.CommandNode:
+		if len(n.Args) == 3 {
+			ident, isIdent := n.Args[0].(*parse.IdentifierNode)
+			_, isDot := n.Args[1].(*parse.DotNode)
+			key, isString := n.Args[2].(*parse.StringNode)
+			if isIdent && ident.Ident == "index" && isDot && isString {
+				names[key.Text] = true
+			}
+		}
+		for _, arg := range n.Args {
+			collectParams(arg, names)
+		}
+	case *parse.FieldNode:
+		names[n.Ident[0]] = true
+	case *parse.ChainNode:
+		collectParams(n.Node, names)
+	case *parse.IfNode:
+		collectParams(&n.BranchNode, names)
+	case *parse.RangeNode:
+		collectParams(&n.BranchNode, names)
+	case *parse.WithNode:
+		collectParams(&n.BranchNode, names)
+	case *parse.BranchNode:
+		collectParams(n.Pipe, names)
+		collectParams(n.List, names)
+		collectParams(n.ElseList, names)
+	case *parse.TemplateNode:
+		collectParams(n.Pipe, names)
+	}
+}
@@ -0,0 +1,114 @@
+package docker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/denkoren/mi-labs-test/internal/core"
+)
+
+func TestRenderParams(t *testing.T) {
+	params := core.ContainerParams{Seed: "42", Values: map[string]string{"genome": "hg38"}}
+
+	tests := []struct {
+		name     string
+		template core.ParamsTemplate
+		want     renderedParams
+		wantErr  bool
+	}{
+		{
+			name:     "default template",
+			template: core.DefaultParamsTemplate,
+			want:     renderedParams{env: []string{"SEED=42"}},
+		},
+		{
+			name: "env, args and labels",
+			template: core.ParamsTemplate{
+				Env:    map[string]string{"SEED": "{{.seed}}", "GENOME": "{{.genome}}"},
+				Args:   []string{"--seed", "{{.seed}}", "--genome={{.genome}}"},
+				Labels: map[string]string{"genome": "{{.genome}}"},
+			},
+			want: renderedParams{
+				env:    []string{"GENOME=hg38", "SEED=42"},
+				args:   []string{"--seed", "42", "--genome=hg38"},
+				labels: map[string]string{"genome": "hg38"},
+			},
+		},
+		{
+			name:     "missing value",
+			template: core.ParamsTemplate{Env: map[string]string{"THREADS": "{{.threads}}"}},
+			want:     renderedParams{env: []string{"THREADS="}},
+		},
+		{
+			name:     "default value",
+			template: core.ParamsTemplate{Env: map[string]string{"THREADS": `{{default "4" .threads}}`, "GENOME": `{{default "hg19" .genome}}`}},
+			want:     renderedParams{env: []string{"GENOME=hg38", "THREADS=4"}},
+		},
+		{
+			name:     "invalid template",
+			template: core.ParamsTemplate{Args: []string{"{{.seed"}},
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := renderParams(tt.template, params)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			labels := map[string]string{paramsKeyLabel: params.Key()}
+			for name, value := range tt.want.labels {
+				labels[name] = value
+			}
+
+			assert.Equal(t, tt.want.env, got.env)
+			assert.Equal(t, tt.want.args, got.args)
+			assert.Equal(t, labels, got.labels)
+		})
+	}
+}
+
+func TestTemplateParams(t *testing.T) {
+	tests := []struct {
+		name     string
+		template core.ParamsTemplate
+		want     map[string]bool
+		wantErr  bool
+	}{
+		{name: "empty", template: core.ParamsTemplate{}, want: map[string]bool{}},
+		{name: "default template", template: core.DefaultParamsTemplate, want: map[string]bool{"seed": true}},
+		{
+			name: "env, args and labels",
+			template: core.ParamsTemplate{
+				Env:    map[string]string{"GENOME": "{{.genome}}"},
+				Args:   []string{"--threads", `{{default "4" .threads}}`},
+				Labels: map[string]string{"build": `{{index . "genome-build"}}`},
+			},
+			want: map[string]bool{"genome": true, "threads": true, "genome-build": true},
+		},
+		{
+			name:     "conditions",
+			template: core.ParamsTemplate{Args: []string{`{{if .gpu}}--gpu={{.gpu}}{{else}}{{.cpu}}{{end}}`}},
+			want:     map[string]bool{"gpu": true, "cpu": true},
+		},
+		{name: "invalid template", template: core.ParamsTemplate{Args: []string{"{{.seed"}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := TemplateParams(tt.template)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
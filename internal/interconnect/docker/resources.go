@@ -0,0 +1,35 @@
+package docker
+
+import (
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/go-units"
+
+	"github.com/denkoren/mi-labs-test/internal/core"
+)
+
+// hostResources makes Docker resource limits of container
+func hostResources(r core.Resources) container.Resources {
+	result := container.Resources{
+		CPUShares: r.CPUShares,
+		CPUQuota:  r.CPUQuota,
+		CPUPeriod: r.CPUPeriod,
+
+		Memory:            r.Memory,
+		MemoryReservation: r.MemoryReservation,
+	}
+
+	if r.PidsLimit != 0 {
+		pidsLimit := r.PidsLimit
+		result.PidsLimit = &pidsLimit
+	}
+
+	for _, ulimit := range r.Ulimits {
+		result.Ulimits = append(result.Ulimits, &units.Ulimit{
+			Name: ulimit.Name,
+			Soft: ulimit.Soft,
+			Hard: ulimit.Hard,
+		})
+	}
+
+	return result
+}
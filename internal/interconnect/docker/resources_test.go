@@ -0,0 +1,59 @@
+package docker
+
+import (
+	"testing"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/go-units"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/denkoren/mi-labs-test/internal/core"
+)
+
+func TestHostResources(t *testing.T) {
+	pidsLimit := int64(512)
+
+	tests := []struct {
+		name      string
+		resources core.Resources
+		want      container.Resources
+	}{
+		{
+			name: "no limits",
+		},
+		{
+			name:      "cpu",
+			resources: core.Resources{CPUShares: 512, CPUQuota: 200000, CPUPeriod: 100000},
+			want:      container.Resources{CPUShares: 512, CPUQuota: 200000, CPUPeriod: 100000},
+		},
+		{
+			name:      "memory",
+			resources: core.Resources{Memory: 4 << 30, MemoryReservation: 2 << 30},
+			want:      container.Resources{Memory: 4 << 30, MemoryReservation: 2 << 30},
+		},
+		{
+			name:      "pids limit",
+			resources: core.Resources{PidsLimit: 512},
+			want:      container.Resources{PidsLimit: &pidsLimit},
+		},
+		{
+			name:      "ulimits",
+			resources: core.Resources{Ulimits: []core.Ulimit{{Name: "nofile", Soft: 1024, Hard: 4096}, {Name: "core", Soft: 0, Hard: 0}}},
+			want: container.Resources{Ulimits: []*units.Ulimit{
+				{Name: "nofile", Soft: 1024, Hard: 4096},
+				{Name: "core", Soft: 0, Hard: 0},
+			}},
+		},
+		{
+			// Cores are pinned by CPU allocation, not by resources mapping
+			name:      "cores",
+			resources: core.Resources{Cores: 2, CPUSet: "2,3"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, hostResources(tt.resources))
+		})
+	}
+}
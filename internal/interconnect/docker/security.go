@@ -0,0 +1,241 @@
+package docker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"strings"
+	"sync"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+
+	"github.com/denkoren/mi-labs-test/internal/core"
+)
+
+// Linux capabilities known by Docker, without 'CAP_' prefix
+var knownCapabilities = map[string]bool{
+	"ALL": true,
+
+	"AUDIT_CONTROL": true, "AUDIT_READ": true, "AUDIT_WRITE": true, "BLOCK_SUSPEND": true, "BPF": true,
+	"CHECKPOINT_RESTORE": true, "CHOWN": true, "DAC_OVERRIDE": true, "DAC_READ_SEARCH": true, "FOWNER": true,
+	"FSETID": true, "IPC_LOCK": true, "IPC_OWNER": true, "KILL": true, "LEASE": true, "LINUX_IMMUTABLE": true,
+	"MAC_ADMIN": true, "MAC_OVERRIDE": true, "MKNOD": true, "NET_ADMIN": true, "NET_BIND_SERVICE": true,
+	"NET_BROADCAST": true, "NET_RAW": true, "PERFMON": true, "SETFCAP": true, "SETGID": true, "SETPCAP": true,
+	"SETUID": true, "SYSLOG": true, "SYS_ADMIN": true, "SYS_BOOT": true, "SYS_CHROOT": true, "SYS_MODULE": true,
+	"SYS_NICE": true, "SYS_PACCT": true, "SYS_PTRACE": true, "SYS_RAWIO": true, "SYS_RESOURCE": true,
+	"SYS_TIME": true, "SYS_TTY_CONFIG": true, "WAKE_ALARM": true,
+}
+
+// no-new-privileges flag appeared in Linux 3.5
+var noNewPrivilegesKernel = [2]int{3, 5}
+
+// seccompProfiles keeps content of seccomp profiles by paths: Docker API accepts profiles content only
+type seccompProfiles struct {
+	byPath map[string]string
+	lock   sync.Mutex
+}
+
+func (p *seccompProfiles) get(path string) (string, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if profile, ok := p.byPath[path]; ok {
+		return profile, nil
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read seccomp profile: %w", err)
+	}
+
+	if !json.Valid(data) {
+		return "", fmt.Errorf("seccomp profile '%s' is not a valid JSON", path)
+	}
+
+	if p.byPath == nil {
+		p.byPath = make(map[string]string)
+	}
+	p.byPath[path] = string(data)
+	return p.byPath[path], nil
+}
+
+// applySecurity hardens container configuration by service security profile
+func (m *Manager) applySecurity(config *container.Config, hostConfig *container.HostConfig, security core.Security) error {
+	config.User = security.User
+
+	hostConfig.ReadonlyRootfs = security.ReadOnlyRootFS
+	hostConfig.Tmpfs = security.Tmpfs
+	hostConfig.CapDrop = security.CapDrop
+	hostConfig.CapAdd = security.CapAdd
+
+	if security.NoNewPrivileges {
+		hostConfig.SecurityOpt = append(hostConfig.SecurityOpt, "no-new-privileges")
+	}
+
+	if security.SeccompProfile != "" {
+		profile, err := m.seccomp.get(security.SeccompProfile)
+		if err != nil {
+			return err
+		}
+		hostConfig.SecurityOpt = append(hostConfig.SecurityOpt, "seccomp="+profile)
+	}
+
+	if security.DisableEgress {
+		hostConfig.NetworkMode = container.NetworkMode(m.config.InternalNetwork)
+	}
+
+	return nil
+}
+
+// CheckSecurity makes sure Docker daemon supports security options of all services.
+// Internal network for containers without egress is created if needed. All problems are reported in the error.
+func (m *Manager) CheckSecurity(ctx context.Context) error {
+	info, err := m.docker.Info(ctx)
+	if err != nil {
+		return fmt.Errorf("docker failed to report its info: %w", err)
+	}
+
+	options, err := types.DecodeSecurityOptions(info.SecurityOptions)
+	if err != nil {
+		return fmt.Errorf("docker reported invalid security options: %w", err)
+	}
+
+	var (
+		problems      []string
+		disableEgress bool
+	)
+	for _, service := range m.config.Services.All() {
+		security := service.Security
+		if !security.Enabled() {
+			continue
+		}
+
+		if info.OSType == "linux" {
+			for _, warning := range securityWarnings(security, options) {
+				log.Printf("[Docker] service '%s': %s", service.Name, warning)
+			}
+		}
+
+		serviceProblems := securityProblems(security, info, options)
+		if security.SeccompProfile != "" && info.OSType == "linux" {
+			_, err = m.seccomp.get(security.SeccompProfile)
+			if err != nil {
+				serviceProblems = append(serviceProblems, err.Error())
+			}
+		}
+
+		for _, problem := range serviceProblems {
+			problems = append(problems, fmt.Sprintf("service '%s': %s", service.Name, problem))
+		}
+
+		disableEgress = disableEgress || security.DisableEgress
+	}
+
+	if disableEgress {
+		err = m.ensureInternalNetwork(ctx)
+		if err != nil {
+			problems = append(problems, err.Error())
+		}
+
+		if _, ok := m.config.Addressing.(DNSAddressing); ok {
+			// Zapuskator can't be checked from here: it may run outside of Docker
+			log.Printf("[Docker] containers without egress are attached to network '%s': "+
+				"zapuskator container must be attached to it too to resolve their names", m.config.InternalNetwork)
+		}
+	}
+
+	if len(problems) != 0 {
+		return fmt.Errorf("security options are not supported:\n\t%s", strings.Join(problems, "\n\t"))
+	}
+
+	return nil
+}
+
+// ensureInternalNetwork creates network for containers without egress, if there is no such network yet
+func (m *Manager) ensureInternalNetwork(ctx context.Context) error {
+	if m.config.InternalNetwork == "" {
+		return fmt.Errorf("internal network for containers without egress is not configured")
+	}
+
+	return m.ensureNetwork(ctx, m.config.InternalNetwork, true)
+}
+
+// securityProblems checks the security profile of service against Docker daemon
+func securityProblems(security core.Security, info types.Info, options []types.SecurityOpt) []string {
+	var problems []string
+	problem := func(format string, args ...interface{}) {
+		problems = append(problems, fmt.Sprintf(format, args...))
+	}
+
+	if info.OSType != "linux" {
+		problem("security options require Linux containers, Docker runs '%s' ones", info.OSType)
+		return problems
+	}
+
+	for _, capability := range append(append([]string(nil), security.CapDrop...), security.CapAdd...) {
+		if !isKnownCapability(capability) {
+			problem("unknown capability '%s'", capability)
+		}
+	}
+
+	if security.NoNewPrivileges && !kernelAtLeast(info.KernelVersion, noNewPrivilegesKernel) {
+		problem("kernel '%s' doesn't support no-new-privileges flag", info.KernelVersion)
+	}
+
+	if security.SeccompProfile != "" && !hasSecurityOption(options, "seccomp") {
+		problem("Docker is built without seccomp support")
+	}
+
+	return problems
+}
+
+// securityWarnings reports weak points of the security profile, which don't prevent its usage
+func securityWarnings(security core.Security, options []types.SecurityOpt) []string {
+	// Root of container is not root of host, when user namespaces are remapped
+	remapped := hasSecurityOption(options, "userns") || hasSecurityOption(options, "rootless")
+	switch {
+	case remapped:
+		return nil
+	case security.User == "":
+		return []string{"user is not set: container processes would run as the image user, which is root by default"}
+	case isRootUser(security.User):
+		return []string{fmt.Sprintf("user '%s' is root and Docker doesn't remap user namespaces", security.User)}
+	}
+
+	return nil
+}
+
+func isKnownCapability(capability string) bool {
+	name := strings.TrimPrefix(strings.ToUpper(capability), "CAP_")
+	return knownCapabilities[name]
+}
+
+// kernelAtLeast compares major and minor numbers of kernel version like "5.10.0-8-amd64"
+func kernelAtLeast(version string, minimal [2]int) bool {
+	var major, minor int
+	_, err := fmt.Sscanf(version, "%d.%d", &major, &minor)
+	if err != nil {
+		// Unknown kernel version is not a reason to refuse start
+		return true
+	}
+
+	return major > minimal[0] || major == minimal[0] && minor >= minimal[1]
+}
+
+func hasSecurityOption(options []types.SecurityOpt, name string) bool {
+	for _, option := range options {
+		if option.Name == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isRootUser(user string) bool {
+	name := strings.SplitN(user, ":", 2)[0]
+	return name == "root" || name == "0"
+}
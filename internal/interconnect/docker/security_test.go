@@ -0,0 +1,158 @@
+package docker
+
+import (
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/denkoren/mi-labs-test/internal/core"
+)
+
+func TestSecurityProblems(t *testing.T) {
+	linux := types.Info{OSType: "linux", KernelVersion: "5.10.0-8-amd64"}
+	seccomp := []types.SecurityOpt{{Name: "seccomp"}}
+	rootless := []types.SecurityOpt{{Name: "rootless"}}
+
+	tests := []struct {
+		name     string
+		security core.Security
+		info     types.Info
+		options  []types.SecurityOpt
+		want     []string
+	}{
+		{
+			name:     "hardened",
+			security: core.Security{User: "1000:1000", CapDrop: []string{"ALL"}, CapAdd: []string{"cap_net_bind_service"}, NoNewPrivileges: true},
+			info:     linux,
+			options:  seccomp,
+		},
+		{
+			name:     "windows",
+			security: core.Security{User: "1000"},
+			info:     types.Info{OSType: "windows"},
+			want:     []string{"security options require Linux containers, Docker runs 'windows' ones"},
+		},
+		{
+			name:     "no user",
+			security: core.Security{ReadOnlyRootFS: true},
+			info:     linux,
+		},
+		{
+			name:     "unknown capabilities",
+			security: core.Security{User: "1000", CapDrop: []string{"NET_RAW", "NET_RAWW"}, CapAdd: []string{"CAP_SYS_ADMIN", "FLY"}},
+			info:     linux,
+			want:     []string{"unknown capability 'NET_RAWW'", "unknown capability 'FLY'"},
+		},
+		{
+			name:     "old kernel",
+			security: core.Security{User: "1000", NoNewPrivileges: true},
+			info:     types.Info{OSType: "linux", KernelVersion: "3.2.0"},
+			want:     []string{"kernel '3.2.0' doesn't support no-new-privileges flag"},
+		},
+		{
+			name:     "no seccomp",
+			security: core.Security{User: "1000", SeccompProfile: "/etc/seccomp.json"},
+			info:     linux,
+			options:  rootless,
+			want:     []string{"Docker is built without seccomp support"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, securityProblems(tt.security, tt.info, tt.options))
+		})
+	}
+}
+
+func TestSecurityWarnings(t *testing.T) {
+	seccomp := []types.SecurityOpt{{Name: "seccomp"}}
+	userns := []types.SecurityOpt{{Name: "seccomp"}, {Name: "userns"}}
+	rootless := []types.SecurityOpt{{Name: "rootless"}}
+
+	tests := []struct {
+		name     string
+		security core.Security
+		options  []types.SecurityOpt
+		want     []string
+	}{
+		{
+			name:     "unprivileged user",
+			security: core.Security{User: "1000:1000"},
+			options:  seccomp,
+		},
+		{
+			name:     "no user",
+			security: core.Security{ReadOnlyRootFS: true},
+			options:  seccomp,
+			want:     []string{"user is not set: container processes would run as the image user, which is root by default"},
+		},
+		{
+			name:     "root user",
+			security: core.Security{User: "0:0"},
+			options:  seccomp,
+			want:     []string{"user '0:0' is root and Docker doesn't remap user namespaces"},
+		},
+		{
+			name:     "no user with userns remap",
+			security: core.Security{ReadOnlyRootFS: true},
+			options:  userns,
+		},
+		{
+			name:     "root user with userns remap",
+			security: core.Security{User: "root"},
+			options:  userns,
+		},
+		{
+			name:     "root user in rootless Docker",
+			security: core.Security{User: "root"},
+			options:  rootless,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, securityWarnings(tt.security, tt.options))
+		})
+	}
+}
+
+func TestKernelAtLeast(t *testing.T) {
+	tests := []struct {
+		version string
+		want    bool
+	}{
+		{version: "5.10.0-8-amd64", want: true},
+		{version: "3.5.0", want: true},
+		{version: "3.4.113", want: false},
+		{version: "2.6.32-754.el6.x86_64", want: false},
+		{version: "unknown", want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			assert.Equal(t, tt.want, kernelAtLeast(tt.version, noNewPrivilegesKernel))
+		})
+	}
+}
+
+func TestIsRootUser(t *testing.T) {
+	tests := []struct {
+		user string
+		want bool
+	}{
+		{user: "root", want: true},
+		{user: "0", want: true},
+		{user: "0:1000", want: true},
+		{user: "root:root", want: true},
+		{user: "1000:0", want: false},
+		{user: "nobody", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.user, func(t *testing.T) {
+			assert.Equal(t, tt.want, isRootUser(tt.user))
+		})
+	}
+}
@@ -0,0 +1,155 @@
+package registry
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+
+	"github.com/denkoren/mi-labs-test/internal/core"
+)
+
+var (
+	containersBucket    = []byte("containers")
+	serviceImagesBucket = []byte("service_images")
+)
+
+// BoltStore keeps registry state in embedded BoltDB database file
+type BoltStore struct {
+	db *bolt.DB
+}
+
+func NewBoltStore(path string) (*BoltStore, error) {
+	db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open registry database '%s': %w", path, err)
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(containersBucket)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.CreateBucketIfNotExists(serviceImagesBucket)
+		return err
+	})
+	if err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("failed to init registry database '%s': %w", path, err)
+	}
+
+	return &BoltStore{db: db}, nil
+}
+
+func (s *BoltStore) Save(info core.ContainerInfo) error {
+	data, err := json.Marshal(info)
+	if err != nil {
+		return fmt.Errorf("failed to encode container '%s': %w", info.ID, err)
+	}
+
+	err = s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(containersBucket).Put([]byte(storeKey(info)), data)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save container '%s': %w", info.ID, err)
+	}
+
+	return nil
+}
+
+func (s *BoltStore) Delete(info core.ContainerInfo) error {
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(containersBucket).Delete([]byte(storeKey(info)))
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete container '%s': %w", info.ID, err)
+	}
+
+	return nil
+}
+
+func (s *BoltStore) Load() ([]core.ContainerInfo, error) {
+	var result []core.ContainerInfo
+
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(containersBucket)
+
+		// Records are collected first: bucket can't be modified while iterating it
+		outdated := make(map[string]core.ContainerInfo)
+		err := bucket.ForEach(func(k, v []byte) error {
+			var info core.ContainerInfo
+			err := json.Unmarshal(v, &info)
+			if err != nil {
+				return fmt.Errorf("failed to decode container '%s': %w", k, err)
+			}
+
+			if string(k) != storeKey(info) {
+				// Record was stored by older version of service, e.g. by seed
+				outdated[string(k)] = info
+			}
+
+			result = append(result, info)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		for k, info := range outdated {
+			err = bucket.Delete([]byte(k))
+			if err != nil {
+				return err
+			}
+
+			data, err := json.Marshal(info)
+			if err != nil {
+				return err
+			}
+
+			err = bucket.Put([]byte(storeKey(info)), data)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load containers: %w", err)
+	}
+
+	return result, nil
+}
+
+func (s *BoltStore) SaveServiceImage(service string, digest string) error {
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(serviceImagesBucket).Put([]byte(service), []byte(digest))
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save image of service '%s': %w", service, err)
+	}
+
+	return nil
+}
+
+func (s *BoltStore) LoadServiceImages() (map[string]string, error) {
+	result := make(map[string]string)
+
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(serviceImagesBucket).ForEach(func(k, v []byte) error {
+			result[string(k)] = string(v)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load service images: %w", err)
+	}
+
+	return result, nil
+}
+
+func (s *BoltStore) Close() error {
+	return s.db.Close()
+}
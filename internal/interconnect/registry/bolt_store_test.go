@@ -0,0 +1,259 @@
+package registry
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	bolt "go.etcd.io/bbolt"
+
+	"github.com/denkoren/mi-labs-test/internal/core"
+)
+
+func openTestStore(t *testing.T, path string) *BoltStore {
+	store, err := NewBoltStore(path)
+	require.NoError(t, err)
+
+	return store
+}
+
+func testContainerInfo(id string, seed string) core.ContainerInfo {
+	now := time.Date(2021, 9, 1, 12, 0, 0, 0, time.UTC)
+
+	return core.ContainerInfo{
+		ID:   id,
+		Addr: "172.17.0.2:80",
+		Params: core.ContainerParams{
+			Service:     "compute",
+			ImageDigest: "sha256:0123",
+			Seed:        seed,
+			Values:      map[string]string{"genome": "hg38"},
+		},
+		Status:    core.ContainerStatusReady,
+		Created:   now,
+		Started:   now.Add(time.Second),
+		Updated:   now.Add(time.Second),
+		LastUsed:  now.Add(time.Minute),
+		Pinned:    true,
+		Resources: core.Resources{CPUSet: "2-3"},
+	}
+}
+
+func TestBoltStore(t *testing.T) {
+	tests := []struct {
+		name   string
+		save   []core.ContainerInfo
+		delete []core.ContainerInfo
+		want   []core.ContainerInfo
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name: "round trip",
+			save: []core.ContainerInfo{testContainerInfo("a", "1"), testContainerInfo("b", "2")},
+			want: []core.ContainerInfo{testContainerInfo("a", "1"), testContainerInfo("b", "2")},
+		},
+		{
+			name: "overwrite by params",
+			save: []core.ContainerInfo{testContainerInfo("a", "1"), testContainerInfo("b", "1")},
+			want: []core.ContainerInfo{testContainerInfo("b", "1")},
+		},
+		{
+			name:   "delete",
+			save:   []core.ContainerInfo{testContainerInfo("a", "1"), testContainerInfo("b", "2")},
+			delete: []core.ContainerInfo{testContainerInfo("a", "1")},
+			want:   []core.ContainerInfo{testContainerInfo("b", "2")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "registry.db")
+
+			store := openTestStore(t, path)
+			for _, info := range tt.save {
+				require.NoError(t, store.Save(info))
+			}
+			for _, info := range tt.delete {
+				require.NoError(t, store.Delete(info))
+			}
+			require.NoError(t, store.Close())
+
+			// State survives reopening
+			store = openTestStore(t, path)
+			defer store.Close()
+
+			loaded, err := store.Load()
+			require.NoError(t, err)
+			assert.ElementsMatch(t, tt.want, loaded)
+		})
+	}
+}
+
+func TestBoltStore_LoadOutdatedKeys(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "registry.db")
+	info := testContainerInfo("a", "1")
+
+	store := openTestStore(t, path)
+	err := store.db.Update(func(tx *bolt.Tx) error {
+		data, err := json.Marshal(info)
+		if err != nil {
+			return err
+		}
+		return tx.Bucket(containersBucket).Put([]byte(info.Params.Seed), data)
+	})
+	require.NoError(t, err)
+	defer store.Close()
+
+	loaded, err := store.Load()
+	require.NoError(t, err)
+	assert.Equal(t, []core.ContainerInfo{info}, loaded)
+
+	// Record is moved to params key
+	var keys []string
+	err = store.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(containersBucket).ForEach(func(k, _ []byte) error {
+			keys = append(keys, string(k))
+			return nil
+		})
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{info.Params.Key()}, keys)
+}
+
+func TestContainerRegistry_Load(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "registry.db")
+
+	busy := testContainerInfo("a", "1")
+	busy.ActiveCalculations = 2
+	busy.CalculationTimeouts = 1
+	uncreated := testContainerInfo("", "2")
+
+	store := openTestStore(t, path)
+	require.NoError(t, store.Save(busy))
+	require.NoError(t, store.Save(uncreated))
+
+	r, err := NewContainerRegistry(store)
+	require.NoError(t, err)
+
+	c, err := r.PeekByID("a")
+	require.NoError(t, err)
+	info := c.Snapshot()
+	assert.Zero(t, info.ActiveCalculations, "calculations are lost with previous instance")
+	assert.Zero(t, info.CalculationTimeouts)
+
+	_, err = r.GetByParams(uncreated.Params)
+	assert.ErrorIs(t, err, ErrContainerNotExists)
+
+	loaded, err := store.Load()
+	require.NoError(t, err)
+	assert.Len(t, loaded, 1, "interrupted creation is removed from store")
+	require.NoError(t, store.Close())
+}
+
+func TestContainerRegistry_MigrateParams(t *testing.T) {
+	legacy := testContainerInfo("legacy", "1")
+	legacy.Params.Service = ""
+	legacy.Params.ImageDigest = ""
+	current := testContainerInfo("current", "2")
+
+	store := openTestStore(t, filepath.Join(t.TempDir(), "registry.db"))
+	require.NoError(t, store.Save(legacy))
+	require.NoError(t, store.Save(current))
+
+	r, err := NewContainerRegistry(store)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"legacy"}, idsOf(r.LegacyContainers()))
+
+	tests := []struct {
+		name    string
+		id      string
+		params  core.ContainerParams
+		wantErr bool
+	}{
+		{name: "conflict", id: "legacy", params: current.Params, wantErr: true},
+		{name: "legacy", id: "legacy", params: testContainerInfo("", "1").Params},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := r.PeekByID(tt.id)
+			require.NoError(t, err)
+
+			err = r.MigrateParams(c, tt.params)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			migrated, err := r.GetByParams(tt.params)
+			require.NoError(t, err)
+			assert.Equal(t, tt.id, migrated.Snapshot().ID)
+		})
+	}
+
+	assert.Empty(t, r.LegacyContainers())
+
+	loaded, err := store.Load()
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"legacy", "current"}, []string{loaded[0].ID, loaded[1].ID})
+	for _, info := range loaded {
+		assert.Equal(t, "compute", info.Params.Service, "container '%s'", info.ID)
+	}
+	require.NoError(t, store.Close())
+}
+
+func TestContainerRegistry_LoadRetired(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "registry.db")
+
+	retired := testContainerInfo("retired", "1")
+	retired.Retired = true
+	replacement := testContainerInfo("replacement", "1")
+
+	store := openTestStore(t, path)
+	require.NoError(t, store.Save(retired))
+	require.NoError(t, store.Save(replacement))
+
+	r, err := NewContainerRegistry(store)
+	require.NoError(t, err)
+
+	c, err := r.PeekByID("retired")
+	require.NoError(t, err)
+	assert.True(t, c.Snapshot().Retired)
+
+	c, err = r.GetByParams(replacement.Params)
+	require.NoError(t, err)
+	assert.Equal(t, "replacement", c.Snapshot().ID)
+
+	require.NoError(t, store.Delete(retired))
+	loaded, err := store.Load()
+	require.NoError(t, err)
+	require.Len(t, loaded, 1, "retired container is stored separately")
+	assert.Equal(t, "replacement", loaded[0].ID)
+	require.NoError(t, store.Close())
+}
+
+func TestBoltStore_ServiceImages(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "registry.db")
+
+	store := openTestStore(t, path)
+	images, err := store.LoadServiceImages()
+	require.NoError(t, err)
+	assert.Empty(t, images)
+
+	require.NoError(t, store.SaveServiceImage("compute", "sha256:0123"))
+	require.NoError(t, store.SaveServiceImage("annotator", "sha256:4567"))
+	require.NoError(t, store.SaveServiceImage("compute", "sha256:89ab"))
+	require.NoError(t, store.Close())
+
+	store = openTestStore(t, path)
+	images, err = store.LoadServiceImages()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"compute": "sha256:89ab", "annotator": "sha256:4567"}, images)
+	require.NoError(t, store.Close())
+}
@@ -1,7 +1,9 @@
 package registry
 
 import (
+	"errors"
 	"fmt"
+	"log"
 	"sync"
 	"time"
 
@@ -11,8 +13,12 @@ import (
 type ContainerInfo struct {
 	sync.Mutex
 	registry         *ContainerRegistry
-	subscribers      map[int]subscriber
+	subscribers      map[int]*eventQueue
 	nextSubscriberID int
+	seq              uint64            // Sequence number of the last status transition
+	lifecycle        *lifecycleHistory // Last status transitions, both done and failed
+	actor            Actor             // Actor of transition in progress
+	lastUsedSaved    time.Time         // LastUsed value persisted to store
 
 	core.ContainerInfo
 }
@@ -20,8 +26,9 @@ type ContainerInfo struct {
 func NewContainerInfo(r *ContainerRegistry, coreInfo core.ContainerInfo) *ContainerInfo {
 	return &ContainerInfo{
 		registry:         r,
-		subscribers:      make(map[int]subscriber, defaultStatusSubscriptionCapacity),
+		subscribers:      make(map[int]*eventQueue),
 		nextSubscriberID: 0,
+		lifecycle:        &lifecycleHistory{},
 
 		ContainerInfo: coreInfo,
 	}
@@ -30,15 +37,150 @@ func NewContainerInfo(r *ContainerRegistry, coreInfo core.ContainerInfo) *Contai
 func (c *ContainerInfo) UpdateLastUsed() {
 	c.Lock()
 	c.LastUsed = time.Now()
+	c.saveLastUsed()
 	c.Unlock()
 }
 
-func (c *ContainerInfo) Save() error {
-	// Perform DB update actions here
+// LastUsed is persisted not more often than once per this interval: it changes on every request.
+const lastUsedSaveInterval = time.Minute
+
+// saveLastUsed is called under container lock
+func (c *ContainerInfo) saveLastUsed() {
+	if c.LastUsed.Sub(c.lastUsedSaved) < lastUsedSaveInterval {
+		return
+	}
+
+	err := c.Save()
+	if err != nil {
+		log.Printf("[Registry] failed to persist container '%s' last usage time: %v", c.ID, err)
+		return
+	}
+	c.lastUsedSaved = c.LastUsed
+}
+
+// CalculationTimedOut counts calculation timeouts happened in a row.
+func (c *ContainerInfo) CalculationTimedOut() {
+	c.Lock()
+	c.CalculationTimeouts++
+	c.Unlock()
+}
+
+// CalculationResponded resets calculation timeouts counter: the container is not hung.
+func (c *ContainerInfo) CalculationResponded() {
+	c.Lock()
+	c.CalculationTimeouts = 0
+	c.Unlock()
+}
+
+// CalculationStarted counts calculations, that are waited by clients right now.
+// Draining container accepts no new calculations.
+func (c *ContainerInfo) CalculationStarted() error {
+	c.Lock()
+	defer c.Unlock()
+
+	if c.Draining {
+		return ErrContainerDraining
+	}
+
+	c.ActiveCalculations++
+	c.LastUsed = time.Now()
+	c.saveLastUsed()
 	return nil
 }
 
-var ErrTransitionNotAllowed = fmt.Errorf("transition not allowed")
+// CalculationFinished is the pair for CalculationStarted.
+func (c *ContainerInfo) CalculationFinished() {
+	c.Lock()
+	c.ActiveCalculations--
+	c.LastUsed = time.Now()
+	c.saveLastUsed()
+	c.Unlock()
+}
+
+// Modify changes container info without status change. Hooks are run under container lock.
+func (c *ContainerInfo) Modify(hooks ...TransitionHook) error {
+	c.Lock()
+	defer c.Unlock()
+
+	err := c.runHooks(c.Status, hooks...)
+	if err != nil {
+		return err
+	}
+
+	c.Updated = time.Now()
+
+	// Change is already applied in memory, see changeStatus
+	err = c.Save()
+	if err != nil {
+		log.Printf("[Registry] failed to persist container '%s' changes: %v", c.ID, err)
+	}
+	return nil
+}
+
+// SetProgress updates container initialization progress.
+func (c *ContainerInfo) SetProgress(percents int) {
+	c.Lock()
+	c.Progress = percents
+	c.Unlock()
+}
+
+// Snapshot returns consistent copy of container info.
+func (c *ContainerInfo) Snapshot() core.ContainerInfo {
+	c.Lock()
+	defer c.Unlock()
+
+	return c.ContainerInfo
+}
+
+// StatusActor returns actor of the transition to the current status. Is called under container lock.
+func (c *ContainerInfo) StatusActor() Actor {
+	for i := len(c.lifecycle.transitions) - 1; i >= 0; i-- {
+		if t := c.lifecycle.transitions[i]; t.Err == nil {
+			return t.Actor
+		}
+	}
+
+	return ActorUnknown
+}
+
+// Save persists container info in registry store. Is called under container lock.
+func (c *ContainerInfo) Save() error {
+	err := c.registry.store.Save(c.ContainerInfo)
+	if err == nil {
+		c.lastUsedSaved = c.LastUsed
+	}
+	return err
+}
+
+var (
+	ErrTransitionNotAllowed = fmt.Errorf("transition not allowed")
+	ErrContainerDraining    = fmt.Errorf("container is draining")
+)
+
+// TransitionError is a failure of container status change
+type TransitionError struct {
+	ContainerID string
+	From        core.ContainerStatus
+	To          core.ContainerStatus
+	Err         error
+}
+
+func (e *TransitionError) Error() string {
+	return fmt.Sprintf("transition from '%s' to '%s' failed: %v", e.From.String(), e.To.String(), e.Err)
+}
+
+func (e *TransitionError) Unwrap() error {
+	return e.Err
+}
+
+func (c *ContainerInfo) transitionError(newStatus core.ContainerStatus, err error) error {
+	return &TransitionError{
+		ContainerID: c.ID,
+		From:        c.Status,
+		To:          newStatus,
+		Err:         err,
+	}
+}
 
 type TransitionHook func(c *ContainerInfo, newStatus core.ContainerStatus) error
 
@@ -76,6 +218,7 @@ var allowedTransitions = map[core.ContainerStatus][]core.ContainerStatus{
 	},
 
 	core.ContainerStatusUnreachable: {
+		core.ContainerStatusStarting, // Restart of hung or unreachable container
 		core.ContainerStatusRunning,
 		core.ContainerStatusReady,
 		core.ContainerStatusPaused,
@@ -95,7 +238,7 @@ func (c *ContainerInfo) runHooks(newStatus core.ContainerStatus, hooks ...Transi
 	for _, hook := range hooks {
 		err := hook(c, newStatus)
 		if err != nil {
-			return fmt.Errorf("transition from '%s' to '%s' failed: %w", c.Status.String(), newStatus.String(), err)
+			return c.transitionError(newStatus, err)
 		}
 	}
 
@@ -107,30 +250,61 @@ func (c *ContainerInfo) transition(newStatus core.ContainerStatus, hooks ...Tran
 	defer c.Unlock()
 
 	if newStatus == c.Status {
-		// Allow transitions from any status to itself
+		// Allow transitions from any status to itself.
+		// They confirm current status periodically, so they are not recorded in history.
 		return c.runHooks(newStatus, hooks...)
 	}
 
+	c.actor = ActorUnknown
+	oldStatus := c.Status
+	err := c.changeStatus(newStatus, hooks...)
+	if errors.Is(err, ErrTransitionNotAllowed) {
+		// Callers try transitions to find out if they are still needed. Rejections are not worth the history.
+		return err
+	}
+
+	t := Transition{
+		Time:  time.Now(),
+		From:  oldStatus,
+		To:    newStatus,
+		Actor: c.actor,
+		Err:   err,
+	}
+	if err == nil {
+		t.Seq = c.seq
+		t.Time = c.Updated
+		t.container = c.ContainerInfo
+	}
+
+	c.registry.histories.record(c, t)
+	return err
+}
+
+// changeStatus is called under container lock
+func (c *ContainerInfo) changeStatus(newStatus core.ContainerStatus, hooks ...TransitionHook) error {
 	for _, allowedTarget := range allowedTransitions[c.Status] {
 		if allowedTarget == newStatus {
 			err := c.runHooks(newStatus, hooks...)
 			if err != nil {
 				return err
 			}
+			oldStatus := c.Status
 			c.Status = newStatus
 			c.Updated = time.Now()
 
+			// Registry in memory is the source of truth: the transition has already happened in Docker
+			// and subscribers must know about it. Store gets the actual state with the next save.
 			err = c.Save()
 			if err != nil {
-				return err
+				log.Printf("[Registry] failed to persist container '%s' transition to '%s': %v", c.ID, newStatus.String(), err)
 			}
 
-			c.notifySubscribers(newStatus)
+			c.notifySubscribers(oldStatus)
 			return nil
 		}
 	}
 
-	return fmt.Errorf("transition from '%s' to '%s' failed: %w", c.Status.String(), newStatus.String(), ErrTransitionNotAllowed)
+	return c.transitionError(newStatus, ErrTransitionNotAllowed)
 }
 
 func (c *ContainerInfo) ToCreated(hooks ...TransitionHook) error {
@@ -140,7 +314,10 @@ func (c *ContainerInfo) ToCreated(hooks ...TransitionHook) error {
 func (c *ContainerInfo) ToStarting(hooks ...TransitionHook) error {
 	hooks = append(
 		hooks,
-		simpleHook(func() { c.Scheduled = time.Now() }),
+		simpleHook(func() {
+			c.Scheduled = time.Now()
+			c.Progress = 0
+		}),
 	)
 
 	return c.transition(core.ContainerStatusStarting, hooks...)
@@ -154,9 +331,12 @@ func (c *ContainerInfo) ToReady(hooks ...TransitionHook) error {
 	hooks = append(
 		hooks,
 		simpleHook(func() {
-			if c.Status == core.ContainerStatusStarting {
+			if c.Started.Before(c.Scheduled) {
+				// First readiness since the container start was scheduled
 				c.Started = time.Now()
+				c.registry.startups.record(c.Params, c.Started.Sub(c.Scheduled))
 			}
+			c.Progress = 100
 		}),
 	)
 	return c.transition(core.ContainerStatusReady, hooks...)
@@ -173,7 +353,10 @@ func (c *ContainerInfo) ToPaused(hooks ...TransitionHook) error {
 func (c *ContainerInfo) ToStopped(hooks ...TransitionHook) error {
 	hooks = append(
 		hooks,
-		simpleHook(func() { c.Stopped = time.Now() }),
+		simpleHook(func() {
+			c.Stopped = time.Now()
+			c.Draining = false // Container is drained
+		}),
 	)
 	return c.transition(core.ContainerStatusStopped, hooks...)
 }
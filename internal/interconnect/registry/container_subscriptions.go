@@ -1,20 +1,34 @@
 package registry
 
 import (
+	"errors"
+	"fmt"
 	"log"
-	"time"
 
 	"github.com/denkoren/mi-labs-test/internal/core"
 )
 
-const defaultNotificationTimeout = 500 * time.Nanosecond
-const defaultStatusSubscriptionCapacity = 2
+var ErrSequenceUnavailable = errors.New("container transitions sequence is not available")
 
+// Subscription receives every status transition of container in the order they happen.
+// C is closed, when subscriber falls behind too much. Err reports it.
 type Subscription struct {
-	C <-chan core.ContainerStatus
+	C <-chan Event
+
+	// Container status, info and sequence number of its last transition at the moment of subscription.
+	// Use them instead of reading container fields to not miss transitions in between.
+	Status core.ContainerStatus
+	Seq    uint64
+	Info   core.ContainerInfo
 
 	id        int
 	container *ContainerInfo
+	queue     *eventQueue
+}
+
+// Err returns ErrSequenceUnavailable, when subscription was dropped due to slow reading.
+func (s Subscription) Err() error {
+	return s.queue.Err()
 }
 
 func (s Subscription) Unsubscribe() {
@@ -26,12 +40,12 @@ func (s Subscription) Unsubscribe() {
 		s.id,
 	)
 
-	sub, ok := s.container.subscribers[s.id]
+	queue, ok := s.container.subscribers[s.id]
 	if !ok {
 		return
 	}
 
-	close(sub)
+	queue.stop()
 	delete(s.container.subscribers, s.id)
 
 	log.Printf("[Registry] container status change events subscription removed. Container: '%s', Subscripton: '%d'. Subscriptions count: '%d'",
@@ -39,17 +53,9 @@ func (s Subscription) Unsubscribe() {
 		s.id,
 		len(s.container.subscribers),
 	)
-
-	go func() {
-		// Read rest of events from subscription to prevent memory leaks.
-		// Nobody should already listen this channel anyway
-		for range s.C {
-		}
-	}()
 }
 
-type subscriber chan<- core.ContainerStatus
-
+// Subscribe starts receiving container status transitions happening after the subscription.
 func (c *ContainerInfo) Subscribe() Subscription {
 	c.Lock()
 	defer c.Unlock()
@@ -57,16 +63,36 @@ func (c *ContainerInfo) Subscribe() Subscription {
 	return c.subscribe()
 }
 
-func (c *ContainerInfo) subscribe() Subscription {
-	subCh := make(chan core.ContainerStatus, defaultStatusSubscriptionCapacity)
+// SubscribeFrom resumes receiving container status transitions after the one with <seq> sequence number.
+// Transitions already happened are replayed first. Only last transitions are kept by container,
+// ErrSequenceUnavailable is returned when some of requested transitions are lost.
+func (c *ContainerInfo) SubscribeFrom(seq uint64) (Subscription, error) {
+	c.Lock()
+	defer c.Unlock()
+
+	replay, ok := c.registry.histories.since(c, seq)
+	if !ok {
+		return Subscription{}, fmt.Errorf("can't resume container '%s' subscription from '%d', last sequence number is '%d': %w",
+			c.ID, seq, c.seq, ErrSequenceUnavailable)
+	}
+
+	return c.subscribe(replay...), nil
+}
+
+func (c *ContainerInfo) subscribe(replay ...Event) Subscription {
+	queue := newEventQueue(defaultEventQueueLimit, replay...)
 	sub := Subscription{
-		C: subCh,
+		C:      queue.out,
+		Status: c.Status,
+		Seq:    c.seq,
+		Info:   c.ContainerInfo,
 
 		id:        c.nextSubscriberID,
 		container: c,
+		queue:     queue,
 	}
 
-	c.subscribers[sub.id] = subCh
+	c.subscribers[sub.id] = queue
 	c.nextSubscriberID++
 
 	log.Printf("[Registry] new container status change events subscription. Container: '%s', Subscription: '%d'. Subscriptions count: '%d'",
@@ -78,14 +104,24 @@ func (c *ContainerInfo) subscribe() Subscription {
 	return sub
 }
 
-func (c *ContainerInfo) notifySubscribers(status core.ContainerStatus) {
+// notifySubscribers is called under container lock
+func (c *ContainerInfo) notifySubscribers(oldStatus core.ContainerStatus) {
+	c.seq++
+	e := Event{
+		Seq:       c.seq,
+		OldStatus: oldStatus,
+		Status:    c.Status,
+		Time:      c.Updated,
+		Container: c.ContainerInfo,
+	}
+
 	log.Printf("[Registry] notifying '%d' container subscribers about status change...", len(c.subscribers))
-	for _, subCh := range c.subscribers {
-		select {
-		case subCh <- status:
-		default:
-			// Don't block on notifications. It is better to lose one, than to catch deadlock
-			log.Printf("[Registry] status change notification skipped!")
+	for id, queue := range c.subscribers {
+		if !queue.push(e) {
+			log.Printf("[Registry] container '%s' subscription '%d' dropped: %v", c.ID, id, queue.Err())
+			delete(c.subscribers, id)
 		}
 	}
+
+	c.registry.notifyWatchers(e)
 }
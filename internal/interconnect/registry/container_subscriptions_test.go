@@ -0,0 +1,124 @@
+package registry
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/denkoren/mi-labs-test/internal/core"
+)
+
+// toggleReady makes <n> transitions between Running and Ready statuses of created container
+func toggleReady(t *testing.T, c *ContainerInfo, n int) {
+	for i := 0; i < n; i++ {
+		var err error
+		if i%2 == 0 {
+			err = c.ToRunning()
+		} else {
+			err = c.ToReady()
+		}
+		require.NoError(t, err)
+	}
+}
+
+func TestContainerInfo_Subscribe(t *testing.T) {
+	c := newTestContainer(t)
+	require.NoError(t, c.ToCreated())
+
+	subscription := c.Subscribe()
+	defer subscription.Unsubscribe()
+	assert.Equal(t, uint64(1), subscription.Seq)
+
+	toggleReady(t, c, 4)
+
+	events := receiveEvents(t, subscription.C, 4)
+	assert.Equal(t, []uint64{2, 3, 4, 5}, seqs(events))
+	assert.NoError(t, subscription.Err())
+}
+
+func TestContainerInfo_SubscribeFrom(t *testing.T) {
+	tests := []struct {
+		name    string
+		from    uint64
+		want    []uint64
+		wantErr error
+	}{
+		{name: "replay all", from: 1, want: []uint64{2, 3, 4, 5}},
+		{name: "replay tail", from: 4, want: []uint64{5}},
+		{name: "up to date", from: 5, want: []uint64{}},
+		{name: "from future", from: 6, wantErr: ErrSequenceUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestContainer(t)
+			require.NoError(t, c.ToCreated())
+			toggleReady(t, c, 4)
+
+			subscription, err := c.SubscribeFrom(tt.from)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			defer subscription.Unsubscribe()
+
+			// Transitions after subscription follow the replayed ones
+			toggleReady(t, c, 1)
+			want := append(tt.want, 6)
+
+			assert.Equal(t, want, seqs(receiveEvents(t, subscription.C, len(want))))
+		})
+	}
+}
+
+func TestContainerInfo_SubscribeFrom_Lost(t *testing.T) {
+	c := newTestContainer(t)
+	require.NoError(t, c.ToCreated())
+	toggleReady(t, c, defaultLifecycleHistorySize+1)
+
+	_, err := c.SubscribeFrom(1)
+	assert.ErrorIs(t, err, ErrSequenceUnavailable)
+}
+
+func TestContainerInfo_Subscribe_Overflow(t *testing.T) {
+	c := newTestContainer(t)
+	require.NoError(t, c.ToCreated())
+
+	slow := c.Subscribe()
+	defer slow.Unsubscribe()
+
+	// Reader may take one event out of the queue before blocking on channel send
+	toggleReady(t, c, defaultEventQueueLimit+2)
+
+	for range slow.C {
+	}
+	assert.ErrorIs(t, slow.Err(), ErrSequenceUnavailable)
+
+	c.Lock()
+	assert.Empty(t, c.subscribers, "overflowed subscriber must be dropped")
+	c.Unlock()
+}
+
+type failingStore struct{ NopStore }
+
+func (failingStore) Save(core.ContainerInfo) error { return errors.New("disk is full") }
+
+func TestContainerInfo_Subscribe_SaveFailure(t *testing.T) {
+	r, err := NewContainerRegistry(failingStore{})
+	require.NoError(t, err)
+	c, err := r.ExistingOrNewByParams(core.ContainerParams{Seed: "seed"})
+	require.NoError(t, err)
+
+	subscription := c.Subscribe()
+	defer subscription.Unsubscribe()
+
+	// Persistence is best-effort: transition happens and is notified
+	require.NoError(t, c.ToCreated())
+
+	events := receiveEvents(t, subscription.C, 1)
+	assert.Equal(t, core.ContainerStatusCreated, events[0].Status)
+	assert.Equal(t, core.ContainerStatusCreated, c.Snapshot().Status)
+}
@@ -0,0 +1,125 @@
+package registry
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/denkoren/mi-labs-test/internal/core"
+)
+
+// Event is a status transition of container
+type Event struct {
+	Seq       uint64 // Sequence number of transition within the container, starts from 1
+	OldStatus core.ContainerStatus
+	Status    core.ContainerStatus
+	Time      time.Time
+
+	Container core.ContainerInfo // Container info right after transition
+}
+
+// Number of events buffered for slow reader. The reader falling behind further is dropped.
+const defaultEventQueueLimit = 1024
+
+// eventQueue delivers events to its channel in the order they were pushed.
+// push never blocks: events are buffered until the reader takes them, so the transition path
+// can't be locked by slow reader. The queue is stopped with ErrSequenceUnavailable, when the reader
+// falls behind by more than <limit> events. Anyway the reader must stop the queue, when it is not
+// interested in events anymore.
+type eventQueue struct {
+	out    chan Event
+	wake   chan struct{}
+	done   chan struct{}
+	events []Event
+	limit  int
+	err    error // Reason of queue stop by the queue itself
+
+	stopOnce sync.Once
+	lock     sync.Mutex
+}
+
+func newEventQueue(limit int, events ...Event) *eventQueue {
+	q := &eventQueue{
+		out:    make(chan Event),
+		wake:   make(chan struct{}, 1),
+		done:   make(chan struct{}),
+		events: events,
+		limit:  limit,
+	}
+
+	go q.run()
+	return q
+}
+
+// push returns false, when the queue overflowed and was stopped
+func (q *eventQueue) push(e Event) bool {
+	q.lock.Lock()
+	if q.err != nil {
+		q.lock.Unlock()
+		return false
+	}
+
+	if len(q.events) >= q.limit {
+		q.err = fmt.Errorf("reader fell behind by '%d' events: %w", len(q.events), ErrSequenceUnavailable)
+		q.events = nil
+		q.lock.Unlock()
+
+		q.stop()
+		return false
+	}
+
+	q.events = append(q.events, e)
+	q.lock.Unlock()
+
+	select {
+	case q.wake <- struct{}{}:
+	default:
+		// Reader is already woken up
+	}
+
+	return true
+}
+
+// Err returns the reason of queue stop on overflow. Is nil when queue was stopped by reader.
+func (q *eventQueue) Err() error {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	return q.err
+}
+
+// stop releases queue resources. Queue channel is closed, the rest of events are dropped.
+func (q *eventQueue) stop() {
+	q.stopOnce.Do(func() {
+		close(q.done)
+	})
+}
+
+func (q *eventQueue) run() {
+	defer close(q.out)
+
+	for {
+		q.lock.Lock()
+		if len(q.events) == 0 {
+			q.lock.Unlock()
+
+			select {
+			case <-q.wake:
+				continue
+			case <-q.done:
+				return
+			}
+		}
+
+		e := q.events[0]
+		q.events[0] = Event{}
+		q.events = q.events[1:]
+		q.lock.Unlock()
+
+		select {
+		case q.out <- e:
+		case <-q.done:
+			return
+		}
+	}
+}
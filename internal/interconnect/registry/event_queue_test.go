@@ -0,0 +1,103 @@
+package registry
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func receiveEvents(t *testing.T, c <-chan Event, n int) []Event {
+	result := make([]Event, 0, n)
+	for len(result) < n {
+		select {
+		case e, ok := <-c:
+			require.True(t, ok, "queue closed after '%d' events", len(result))
+			result = append(result, e)
+		case <-time.After(time.Second):
+			require.FailNow(t, "events were not delivered in time", "received '%d' of '%d'", len(result), n)
+		}
+	}
+
+	return result
+}
+
+func seqs(events []Event) []uint64 {
+	result := make([]uint64, 0, len(events))
+	for _, e := range events {
+		result = append(result, e.Seq)
+	}
+
+	return result
+}
+
+func TestEventQueue(t *testing.T) {
+	tests := []struct {
+		name     string
+		limit    int
+		replay   []Event
+		push     []uint64
+		wantPush []bool
+		want     []uint64
+		wantErr  error
+	}{
+		{
+			name:     "keeps order",
+			limit:    10,
+			push:     []uint64{1, 2, 3},
+			wantPush: []bool{true, true, true},
+			want:     []uint64{1, 2, 3},
+		},
+		{
+			name:     "replays first",
+			limit:    10,
+			replay:   []Event{{Seq: 1}, {Seq: 2}},
+			push:     []uint64{3},
+			wantPush: []bool{true},
+			want:     []uint64{1, 2, 3},
+		},
+		{
+			name:     "overflow",
+			limit:    2,
+			replay:   []Event{{Seq: 1}, {Seq: 2}},
+			push:     []uint64{3, 4},
+			wantPush: []bool{false, false},
+			wantErr:  ErrSequenceUnavailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := newEventQueue(tt.limit, tt.replay...)
+			defer q.stop()
+
+			// Nobody reads the queue yet, so all events are buffered
+			for i, seq := range tt.push {
+				assert.Equal(t, tt.wantPush[i], q.push(Event{Seq: seq}))
+			}
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, q.Err(), tt.wantErr)
+				_, ok := <-q.out
+				assert.False(t, ok, "overflowed queue must be closed")
+				return
+			}
+
+			assert.Equal(t, tt.want, seqs(receiveEvents(t, q.out, len(tt.want))))
+			assert.NoError(t, q.Err())
+		})
+	}
+}
+
+func TestEventQueue_Stop(t *testing.T) {
+	q := newEventQueue(10)
+	q.push(Event{Seq: 1})
+	q.stop()
+	q.stop()
+
+	// The rest of events may be dropped, but channel must be closed
+	for range q.out {
+	}
+	assert.NoError(t, q.Err())
+}
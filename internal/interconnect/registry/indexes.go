@@ -10,12 +10,16 @@ func (s idIndex) del(id string) {
 	delete(s, id)
 }
 
-type seedIndex map[string]*ContainerInfo
+// paramsIndex keeps containers by their params keys
+type paramsIndex map[string]*ContainerInfo
 
-func (s seedIndex) set(c *ContainerInfo) {
-	s[c.Params.Seed] = c
+func (s paramsIndex) set(c *ContainerInfo) {
+	s[c.Params.Key()] = c
 }
 
-func (s seedIndex) del(c *ContainerInfo) {
-	delete(s, c.Params.Seed)
+// del removes the container only: another container could replace it already
+func (s paramsIndex) del(c *ContainerInfo) {
+	if s[c.Params.Key()] == c {
+		delete(s, c.Params.Key())
+	}
 }
@@ -0,0 +1,160 @@
+package registry
+
+import (
+	"sync"
+	"time"
+
+	"github.com/denkoren/mi-labs-test/internal/core"
+)
+
+const (
+	// Number of the latest transitions kept for each container
+	defaultLifecycleHistorySize = 100
+	// Number of container IDs, which histories are kept after containers were removed or recreated
+	defaultRetainedHistories = 1000
+)
+
+// Actor is the part of service, that changes container status
+type Actor string
+
+const (
+	ActorUnknown    Actor = "unknown"
+	ActorAPI        Actor = "api"
+	ActorBackground Actor = "background"
+	ActorAdmin      Actor = "admin"
+)
+
+// By marks the transition with its actor.
+// Should be the first transition hook to have the actor recorded even if other hooks fail.
+func By(actor Actor) TransitionHook {
+	return func(c *ContainerInfo, _ core.ContainerStatus) error {
+		c.actor = actor
+		return nil
+	}
+}
+
+// Transition is a record of container status change attempt
+type Transition struct {
+	Seq   uint64 // Sequence number of transition done. Is zero for failed ones.
+	Time  time.Time
+	From  core.ContainerStatus
+	To    core.ContainerStatus
+	Actor Actor
+	Err   error // Status was not changed when set
+
+	container core.ContainerInfo // Container info right after transition, to replay it to subscribers
+}
+
+func (t Transition) event() Event {
+	return Event{
+		Seq:       t.Seq,
+		OldStatus: t.From,
+		Status:    t.To,
+		Time:      t.Time,
+		Container: t.container,
+	}
+}
+
+type lifecycleHistory struct {
+	transitions []Transition
+}
+
+// lifecycleHistories keeps containers' transitions by container IDs.
+// All histories are guarded by the single lock to be read outside container lock.
+type lifecycleHistories struct {
+	byID map[string]*lifecycleHistory
+	ids  []string // In order of appearance, for eviction
+
+	lock sync.Mutex
+}
+
+func newLifecycleHistories() *lifecycleHistories {
+	return &lifecycleHistories{
+		byID: make(map[string]*lifecycleHistory, defaultRetainedHistories),
+	}
+}
+
+// record is called under container lock
+func (h *lifecycleHistories) record(c *ContainerInfo, t Transition) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	c.lifecycle.transitions = append(c.lifecycle.transitions, t)
+	if len(c.lifecycle.transitions) > defaultLifecycleHistorySize {
+		c.lifecycle.transitions[0] = Transition{}
+		c.lifecycle.transitions = c.lifecycle.transitions[1:]
+	}
+
+	if c.ID == "" {
+		// Container was not created yet. Its history is available by container itself only.
+		return
+	}
+
+	if _, ok := h.byID[c.ID]; ok {
+		return
+	}
+
+	h.byID[c.ID] = c.lifecycle
+	h.ids = append(h.ids, c.ID)
+	if len(h.ids) > defaultRetainedHistories {
+		delete(h.byID, h.ids[0])
+		h.ids = h.ids[1:]
+	}
+}
+
+func (h *lifecycleHistories) get(id string, lifecycle *lifecycleHistory) ([]Transition, bool) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	if lifecycle == nil {
+		var ok bool
+		lifecycle, ok = h.byID[id]
+		if !ok {
+			return nil, false
+		}
+	}
+
+	result := make([]Transition, len(lifecycle.transitions))
+	copy(result, lifecycle.transitions)
+	return result, true
+}
+
+// since returns transitions of container done after the one with <seq> sequence number.
+// Returns false, when some of them are not kept anymore. Is called under container lock.
+func (h *lifecycleHistories) since(c *ContainerInfo, seq uint64) ([]Event, bool) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	if seq > c.seq {
+		return nil, false
+	}
+
+	var result []Event
+	for _, t := range c.lifecycle.transitions {
+		if t.Err == nil && t.Seq > seq {
+			result = append(result, t.event())
+		}
+	}
+
+	if seq < c.seq && (len(result) == 0 || result[0].Seq != seq+1) {
+		return nil, false
+	}
+
+	return result, true
+}
+
+// History returns the latest status transitions of container, oldest first.
+// History is available for some time after container removal or recreation with new ID.
+func (r *ContainerRegistry) History(id string) ([]Transition, error) {
+	var lifecycle *lifecycleHistory
+	if c, err := r.PeekByID(id); err == nil {
+		lifecycle = c.lifecycle
+	}
+
+	transitions, ok := r.histories.get(id, lifecycle)
+	if !ok {
+		return nil, ErrContainerNotExists
+	}
+
+	return transitions, nil
+}
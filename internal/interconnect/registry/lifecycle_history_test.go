@@ -0,0 +1,75 @@
+package registry
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/denkoren/mi-labs-test/internal/core"
+)
+
+func newTestContainer(t *testing.T) *ContainerInfo {
+	r, err := NewContainerRegistry(NopStore{})
+	require.NoError(t, err)
+
+	c, err := r.ExistingOrNewByParams(core.ContainerParams{Seed: "seed"})
+	require.NoError(t, err)
+
+	return c
+}
+
+func transitionsOf(c *ContainerInfo) []Transition {
+	transitions, _ := c.registry.histories.get(c.ID, c.lifecycle)
+	return transitions
+}
+
+func TestLifecycleHistory_Record(t *testing.T) {
+	c := newTestContainer(t)
+	errHook := errors.New("hook failed")
+
+	// Rejected transitions are not recorded
+	err := c.ToReady()
+	assert.ErrorIs(t, err, ErrTransitionNotAllowed)
+	assert.Empty(t, transitionsOf(c))
+
+	err = c.ToCreated(By(ActorAPI))
+	require.NoError(t, err)
+
+	// Transitions to the same status are not recorded
+	err = c.ToCreated(By(ActorAPI))
+	require.NoError(t, err)
+
+	err = c.ToStarting(By(ActorBackground), func(*ContainerInfo, core.ContainerStatus) error { return errHook })
+	assert.ErrorIs(t, err, errHook)
+
+	transitions := transitionsOf(c)
+	require.Len(t, transitions, 2)
+
+	assert.Equal(t, uint64(1), transitions[0].Seq)
+	assert.Equal(t, core.ContainerStatusNew, transitions[0].From)
+	assert.Equal(t, core.ContainerStatusCreated, transitions[0].To)
+	assert.Equal(t, ActorAPI, transitions[0].Actor)
+	assert.NoError(t, transitions[0].Err)
+
+	assert.Equal(t, uint64(0), transitions[1].Seq)
+	assert.Equal(t, core.ContainerStatusStarting, transitions[1].To)
+	assert.Equal(t, ActorBackground, transitions[1].Actor)
+	assert.ErrorIs(t, transitions[1].Err, errHook)
+}
+
+func TestLifecycleHistory_Size(t *testing.T) {
+	c := newTestContainer(t)
+	require.NoError(t, c.ToCreated())
+	require.NoError(t, c.ToStarting())
+
+	for i := 0; i < defaultLifecycleHistorySize; i++ {
+		require.NoError(t, c.ToUnreachable())
+		require.NoError(t, c.ToStarting())
+	}
+
+	transitions := transitionsOf(c)
+	require.Len(t, transitions, defaultLifecycleHistorySize)
+	assert.Equal(t, c.seq, transitions[len(transitions)-1].Seq)
+}
@@ -3,6 +3,7 @@ package registry
 import (
 	"errors"
 	"fmt"
+	"log"
 	"sync"
 	"time"
 
@@ -14,21 +15,74 @@ const defaultContainerRegistryCapacity = 10
 var ErrContainerNotExists = errors.New("container does not exist")
 
 type ContainerRegistry struct {
-	idIndex   idIndex
-	seedIndex seedIndex
-	stopping  idIndex
-	failed    idIndex
+	idIndex     idIndex
+	paramsIndex paramsIndex
+	stopping    idIndex
+	failed      idIndex
+
+	store     Store
+	startups  *startupStats
+	histories *lifecycleHistories
+
+	watchers      map[int]*eventQueue
+	nextWatcherID int
+	watchersLock  sync.Mutex
 
 	indexesLock sync.RWMutex
 }
 
-func NewContainerRegistry() (*ContainerRegistry, error) {
-	return &ContainerRegistry{
-		idIndex:   make(idIndex, defaultContainerRegistryCapacity),
-		seedIndex: make(seedIndex, defaultContainerRegistryCapacity),
-		stopping:  make(idIndex, defaultContainerRegistryCapacity),
-		failed:    make(idIndex, defaultContainerRegistryCapacity),
-	}, nil
+// NewContainerRegistry creates registry with containers loaded from the store
+func NewContainerRegistry(store Store) (*ContainerRegistry, error) {
+	r := &ContainerRegistry{
+		idIndex:     make(idIndex, defaultContainerRegistryCapacity),
+		paramsIndex: make(paramsIndex, defaultContainerRegistryCapacity),
+		stopping:    make(idIndex, defaultContainerRegistryCapacity),
+		failed:      make(idIndex, defaultContainerRegistryCapacity),
+
+		store: store,
+
+		startups:  newStartupStats(),
+		histories: newLifecycleHistories(),
+		watchers:  make(map[int]*eventQueue),
+	}
+
+	err := r.load()
+	if err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+func (r *ContainerRegistry) load() error {
+	infos, err := r.store.Load()
+	if err != nil {
+		return err
+	}
+
+	for _, info := range infos {
+		if info.ID == "" {
+			// Container creation was interrupted. It is created again on demand.
+			err = r.store.Delete(info)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		// Calculations were lost with the previous service instance
+		info.ActiveCalculations = 0
+		info.CalculationTimeouts = 0
+
+		c := NewContainerInfo(r, info)
+		r.idIndex.set(c)
+		if !info.Retired {
+			r.paramsIndex.set(c)
+		}
+	}
+
+	log.Printf("[Registry] '%d' containers loaded from store", len(r.idIndex))
+	return nil
 }
 
 func (r *ContainerRegistry) Register(c *ContainerInfo) error {
@@ -40,7 +94,7 @@ func (r *ContainerRegistry) Register(c *ContainerInfo) error {
 	}
 
 	r.idIndex.set(c)
-	r.seedIndex.set(c)
+	r.paramsIndex.set(c)
 	return nil
 }
 
@@ -59,6 +113,14 @@ func (r *ContainerRegistry) GetByID(id string) (*ContainerInfo, error) {
 	return c, nil
 }
 
+// PeekByID is thread-safe way to get existing container by its ID without marking it as used.
+func (r *ContainerRegistry) PeekByID(id string) (*ContainerInfo, error) {
+	r.indexesLock.RLock()
+	defer r.indexesLock.RUnlock()
+
+	return r.getByID(id)
+}
+
 // getByID returns existing container by its ID
 // is NOT thread safe
 func (r *ContainerRegistry) getByID(id string) (*ContainerInfo, error) {
@@ -88,7 +150,7 @@ func (r *ContainerRegistry) GetByParams(params core.ContainerParams) (*Container
 // getByParams returns existing container by its parameters
 // is NOT thread safe
 func (r *ContainerRegistry) getByParams(params core.ContainerParams) (*ContainerInfo, error) {
-	c, exists := r.seedIndex[params.Seed]
+	c, exists := r.paramsIndex[params.Key()]
 	if !exists {
 		return nil, ErrContainerNotExists
 	}
@@ -119,7 +181,7 @@ func (r *ContainerRegistry) ExistingOrNewByParams(params core.ContainerParams) (
 		),
 	)
 
-	r.seedIndex.set(c)
+	r.paramsIndex.set(c)
 	go r.registerContainerID(c)
 
 	return c, nil
@@ -133,24 +195,94 @@ func (r *ContainerRegistry) ExistingOrNewByParams(params core.ContainerParams) (
 // to update internal ID index.
 func (r *ContainerRegistry) registerContainerID(c *ContainerInfo) {
 	subscription := c.Subscribe()
-	defer subscription.Unsubscribe()
+	defer func() {
+		subscription.Unsubscribe()
+	}()
+
+	status := subscription.Status
+	for !status.WasCreated() {
+		if status == core.ContainerStatusFailed {
+			// Container will never be created
+			return
+		}
 
-	if c.Status.WasCreated() {
-		r.indexesLock.Lock()
-		r.idIndex.set(c)
-		r.indexesLock.Unlock()
-		return
+		event, ok := <-subscription.C
+		if !ok {
+			// Subscription was dropped. Start over.
+			subscription.Unsubscribe()
+			subscription = c.Subscribe()
+			status = subscription.Status
+			continue
+		}
+		status = event.Status
 	}
 
-	for {
-		<-subscription.C
-		if c.Status.WasCreated() {
-			r.indexesLock.Lock()
-			r.idIndex.set(c)
-			r.indexesLock.Unlock()
-			return
+	r.indexesLock.Lock()
+	r.idIndex.set(c)
+	r.indexesLock.Unlock()
+}
+
+// ReplaceID updates ID index once the container was recreated in Docker with new ID.
+// Should not be called under container lock to avoid deadlocks.
+func (r *ContainerRegistry) ReplaceID(oldID string, c *ContainerInfo) {
+	r.indexesLock.Lock()
+	r.idIndex.del(oldID)
+	r.idIndex.set(c)
+	r.indexesLock.Unlock()
+
+	c.Lock()
+	defer c.Unlock()
+
+	err := c.Save()
+	if err != nil {
+		log.Printf("[Registry] failed to persist container '%s' new ID: %v", c.ID, err)
+	}
+}
+
+// LegacyContainers returns containers stored by older versions: their params have no service or image.
+func (r *ContainerRegistry) LegacyContainers() []*ContainerInfo {
+	r.indexesLock.RLock()
+	defer r.indexesLock.RUnlock()
+
+	var result []*ContainerInfo
+	for _, container := range r.idIndex {
+		params := container.Snapshot().Params
+		if params.Service == "" || params.ImageDigest == "" {
+			result = append(result, container)
 		}
 	}
+
+	return result
+}
+
+// MigrateParams changes params of the container, e.g. completes params of containers stored by older versions.
+// Container keeps its params, if there is another container with new params already.
+func (r *ContainerRegistry) MigrateParams(c *ContainerInfo, params core.ContainerParams) error {
+	r.indexesLock.Lock()
+	defer r.indexesLock.Unlock()
+
+	if other, ok := r.paramsIndex[params.Key()]; ok && other != c {
+		return fmt.Errorf("container '%s' with params of container '%s' already exists", other.ID, c.ID)
+	}
+
+	c.Lock()
+	defer c.Unlock()
+
+	// Store record is keyed by params too
+	err := r.store.Delete(c.ContainerInfo)
+	if err != nil {
+		return err
+	}
+
+	r.paramsIndex.del(c)
+	c.Params = params
+	r.paramsIndex.set(c)
+
+	err = c.Save()
+	if err != nil {
+		log.Printf("[Registry] failed to persist container '%s' migrated params: %v", c.ID, err)
+	}
+	return nil
 }
 
 func (r *ContainerRegistry) Delete(id string) error {
@@ -162,18 +294,88 @@ func (r *ContainerRegistry) Delete(id string) error {
 		return err
 	}
 
-	r.idIndex.del(id)
-	r.seedIndex.del(c)
+	return r.delete(c)
+}
+
+// Remove deletes the container from registry. Unlike Delete, it removes containers not created yet too.
+func (r *ContainerRegistry) Remove(c *ContainerInfo) error {
+	r.indexesLock.Lock()
+	defer r.indexesLock.Unlock()
+
+	return r.delete(c)
+}
+
+// delete is NOT thread safe
+func (r *ContainerRegistry) delete(c *ContainerInfo) error {
+	info := c.Snapshot()
+
+	err := r.store.Delete(info)
+	if err != nil {
+		return err
+	}
+
+	if r.idIndex[info.ID] == c {
+		r.idIndex.del(info.ID)
+	}
+	r.paramsIndex.del(c)
+	return nil
+}
+
+// Retire makes active container draining and detaches it from its params, so calculations with the same params
+// get a new container. Retired container is stopped once it is idle and then is removed.
+func (r *ContainerRegistry) Retire(c *ContainerInfo) error {
+	r.indexesLock.Lock()
+	defer r.indexesLock.Unlock()
+
+	c.Lock()
+	defer c.Unlock()
+
+	if !c.Status.IsActive() {
+		return fmt.Errorf("container '%s' is not running", c.ID)
+	}
+
+	// Store record is keyed by params, until the container is retired
+	err := r.store.Delete(c.ContainerInfo)
+	if err != nil {
+		return err
+	}
+
+	r.paramsIndex.del(c)
+	c.Draining = true
+	c.Retired = true
+
+	err = c.Save()
+	if err != nil {
+		log.Printf("[Registry] failed to persist container '%s' retirement: %v", c.ID, err)
+	}
 	return nil
 }
 
+// Containers returns all containers known by registry, including not created yet and retired ones.
+func (r *ContainerRegistry) Containers() ([]*ContainerInfo, error) {
+	r.indexesLock.RLock()
+	defer r.indexesLock.RUnlock()
+
+	result := make([]*ContainerInfo, 0, len(r.paramsIndex))
+	for _, container := range r.paramsIndex {
+		result = append(result, container)
+	}
+	for _, container := range r.idIndex {
+		if container.Snapshot().Retired {
+			result = append(result, container)
+		}
+	}
+
+	return result, nil
+}
+
 func (r *ContainerRegistry) ActiveContainers() ([]*ContainerInfo, error) {
 	r.indexesLock.RLock()
 	defer r.indexesLock.RUnlock()
 
 	result := make([]*ContainerInfo, 0, defaultContainerRegistryCapacity)
 	for _, container := range r.idIndex {
-		if container.Status.IsActive() {
+		if container.Snapshot().Status.IsActive() {
 			result = append(result, container)
 		}
 	}
@@ -181,16 +383,83 @@ func (r *ContainerRegistry) ActiveContainers() ([]*ContainerInfo, error) {
 	return result, nil
 }
 
+// OldContainers returns active containers unused since the time. Pinned and busy containers are skipped.
 func (r *ContainerRegistry) OldContainers(lastUsedBefore time.Time) ([]*ContainerInfo, error) {
 	r.indexesLock.RLock()
 	defer r.indexesLock.RUnlock()
 
 	result := make([]*ContainerInfo, 0, defaultContainerRegistryCapacity)
 	for _, container := range r.idIndex {
-		if container.Status.IsActive() && container.LastUsed.Before(lastUsedBefore) {
+		info := container.Snapshot()
+		if info.Status.IsActive() && !info.Pinned && info.ActiveCalculations == 0 && info.LastUsed.Before(lastUsedBefore) {
 			result = append(result, container)
 		}
 	}
 
 	return result, nil
 }
+
+// DrainedContainers returns draining active containers with no calculations in progress.
+func (r *ContainerRegistry) DrainedContainers() ([]*ContainerInfo, error) {
+	r.indexesLock.RLock()
+	defer r.indexesLock.RUnlock()
+
+	result := make([]*ContainerInfo, 0, defaultContainerRegistryCapacity)
+	for _, container := range r.idIndex {
+		info := container.Snapshot()
+		if info.Status.IsActive() && info.Draining && info.ActiveCalculations == 0 {
+			result = append(result, container)
+		}
+	}
+
+	return result, nil
+}
+
+// RetiredContainers returns retired containers, that are not running already.
+func (r *ContainerRegistry) RetiredContainers() ([]*ContainerInfo, error) {
+	r.indexesLock.RLock()
+	defer r.indexesLock.RUnlock()
+
+	result := make([]*ContainerInfo, 0, defaultContainerRegistryCapacity)
+	for _, container := range r.idIndex {
+		info := container.Snapshot()
+		if info.Retired && !info.Status.IsActive() {
+			result = append(result, container)
+		}
+	}
+
+	return result, nil
+}
+
+// HungContainers returns active containers with <maxTimeouts> or more calculation timeouts in a row.
+func (r *ContainerRegistry) HungContainers(maxTimeouts int) ([]*ContainerInfo, error) {
+	r.indexesLock.RLock()
+	defer r.indexesLock.RUnlock()
+
+	result := make([]*ContainerInfo, 0, defaultContainerRegistryCapacity)
+	for _, container := range r.idIndex {
+		info := container.Snapshot()
+		if info.Status.IsActive() && info.CalculationTimeouts >= maxTimeouts {
+			result = append(result, container)
+		}
+	}
+
+	return result, nil
+}
+
+// ServiceImages returns current images of services, set by SetServiceImage.
+func (r *ContainerRegistry) ServiceImages() (map[string]string, error) {
+	return r.store.LoadServiceImages()
+}
+
+// SetServiceImage persists current image of service, so it survives service restarts.
+func (r *ContainerRegistry) SetServiceImage(service string, digest string) error {
+	return r.store.SaveServiceImage(service, digest)
+}
+
+// StartupEstimate returns expected duration of container startup: from scheduling to readiness.
+// The estimation is based on the latest startups of containers with the same params or any containers
+// if there is no history for these params yet.
+func (r *ContainerRegistry) StartupEstimate(params core.ContainerParams) (time.Duration, bool) {
+	return r.startups.estimate(params)
+}
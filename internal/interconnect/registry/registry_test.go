@@ -0,0 +1,139 @@
+package registry
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/denkoren/mi-labs-test/internal/core"
+)
+
+// newTestRegistry creates registry with containers in given statuses. Containers IDs are their seeds.
+func newTestRegistry(t *testing.T, infos ...core.ContainerInfo) *ContainerRegistry {
+	r, err := NewContainerRegistry(NopStore{})
+	require.NoError(t, err)
+
+	for _, info := range infos {
+		info.ID = info.Params.Seed
+		require.NoError(t, r.Register(NewContainerInfo(r, info)))
+	}
+
+	return r
+}
+
+func idsOf(containers []*ContainerInfo) []string {
+	result := make([]string, 0, len(containers))
+	for _, c := range containers {
+		result = append(result, c.Snapshot().ID)
+	}
+
+	return result
+}
+
+func TestContainerRegistry_HungContainers(t *testing.T) {
+	r := newTestRegistry(t,
+		core.ContainerInfo{Params: core.ContainerParams{Seed: "hung"}, Status: core.ContainerStatusReady, CalculationTimeouts: 3},
+		core.ContainerInfo{Params: core.ContainerParams{Seed: "slow"}, Status: core.ContainerStatusReady, CalculationTimeouts: 2},
+		core.ContainerInfo{Params: core.ContainerParams{Seed: "stopped"}, Status: core.ContainerStatusStopped, CalculationTimeouts: 5},
+	)
+
+	tests := []struct {
+		maxTimeouts int
+		want        []string
+	}{
+		{maxTimeouts: 1, want: []string{"hung", "slow"}},
+		{maxTimeouts: 3, want: []string{"hung"}},
+		{maxTimeouts: 4, want: []string{}},
+	}
+
+	for _, tt := range tests {
+		containers, err := r.HungContainers(tt.maxTimeouts)
+		require.NoError(t, err)
+		assert.ElementsMatch(t, tt.want, idsOf(containers), "max timeouts '%d'", tt.maxTimeouts)
+	}
+}
+
+func TestContainerRegistry_OldContainers(t *testing.T) {
+	now := time.Now()
+	r := newTestRegistry(t,
+		core.ContainerInfo{Params: core.ContainerParams{Seed: "old"}, Status: core.ContainerStatusReady, LastUsed: now.Add(-time.Hour)},
+		core.ContainerInfo{Params: core.ContainerParams{Seed: "recent"}, Status: core.ContainerStatusReady, LastUsed: now.Add(-time.Minute)},
+		core.ContainerInfo{Params: core.ContainerParams{Seed: "pinned"}, Status: core.ContainerStatusReady, LastUsed: now.Add(-time.Hour), Pinned: true},
+		core.ContainerInfo{Params: core.ContainerParams{Seed: "stopped"}, Status: core.ContainerStatusStopped, LastUsed: now.Add(-time.Hour)},
+		core.ContainerInfo{Params: core.ContainerParams{Seed: "busy"}, Status: core.ContainerStatusReady, LastUsed: now.Add(-time.Hour), ActiveCalculations: 1},
+	)
+
+	tests := []struct {
+		lastUsedBefore time.Time
+		want           []string
+	}{
+		{lastUsedBefore: now.Add(-2 * time.Hour), want: []string{}},
+		{lastUsedBefore: now.Add(-10 * time.Minute), want: []string{"old"}},
+		{lastUsedBefore: now, want: []string{"old", "recent"}},
+	}
+
+	for _, tt := range tests {
+		containers, err := r.OldContainers(tt.lastUsedBefore)
+		require.NoError(t, err)
+		assert.ElementsMatch(t, tt.want, idsOf(containers), "last used before '%s'", tt.lastUsedBefore)
+	}
+}
+
+func TestContainerRegistry_DrainedContainers(t *testing.T) {
+	r := newTestRegistry(t,
+		core.ContainerInfo{Params: core.ContainerParams{Seed: "drained"}, Status: core.ContainerStatusReady, Draining: true},
+		core.ContainerInfo{Params: core.ContainerParams{Seed: "busy"}, Status: core.ContainerStatusReady, Draining: true, ActiveCalculations: 1},
+		core.ContainerInfo{Params: core.ContainerParams{Seed: "serving"}, Status: core.ContainerStatusReady},
+		core.ContainerInfo{Params: core.ContainerParams{Seed: "stopped"}, Status: core.ContainerStatusStopped, Draining: true},
+	)
+
+	containers, err := r.DrainedContainers()
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"drained"}, idsOf(containers))
+}
+
+func TestContainerRegistry_Retire(t *testing.T) {
+	r := newTestRegistry(t,
+		core.ContainerInfo{Params: core.ContainerParams{Seed: "ready"}, Status: core.ContainerStatusReady},
+		core.ContainerInfo{Params: core.ContainerParams{Seed: "stopped"}, Status: core.ContainerStatusStopped},
+	)
+
+	stopped, err := r.PeekByID("stopped")
+	require.NoError(t, err)
+	assert.Error(t, r.Retire(stopped), "stopped container is started again on demand")
+
+	retired, err := r.PeekByID("ready")
+	require.NoError(t, err)
+	require.NoError(t, r.Retire(retired))
+	info := retired.Snapshot()
+	assert.True(t, info.Retired)
+	assert.True(t, info.Draining)
+
+	// Retired container is replaced, but is still known until it is removed
+	replacement, err := r.ExistingOrNewByParams(info.Params)
+	require.NoError(t, err)
+	assert.NotSame(t, retired, replacement)
+
+	containers, err := r.Containers()
+	require.NoError(t, err)
+	assert.Len(t, containers, 3)
+
+	retiredContainers, err := r.RetiredContainers()
+	require.NoError(t, err)
+	assert.Empty(t, retiredContainers, "retired container is running yet")
+
+	require.NoError(t, retired.ToStopped())
+	retiredContainers, err = r.RetiredContainers()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ready"}, idsOf(retiredContainers))
+
+	require.NoError(t, r.Remove(retired))
+	_, err = r.PeekByID("ready")
+	assert.ErrorIs(t, err, ErrContainerNotExists)
+
+	found, err := r.GetByParams(info.Params)
+	require.NoError(t, err)
+	assert.Same(t, replacement, found, "replacement keeps the params")
+}
@@ -0,0 +1,67 @@
+package registry
+
+import (
+	"log"
+)
+
+// Watch receives status transitions of all registry containers.
+// Transitions of each container come in order. C is closed, when watcher falls behind too much. Err reports it.
+type Watch struct {
+	C <-chan Event
+
+	id       int
+	registry *ContainerRegistry
+	queue    *eventQueue
+}
+
+// Err returns ErrSequenceUnavailable, when watch was dropped due to slow reading.
+func (w Watch) Err() error {
+	return w.queue.Err()
+}
+
+func (w Watch) Stop() {
+	w.registry.watchersLock.Lock()
+	defer w.registry.watchersLock.Unlock()
+
+	queue, ok := w.registry.watchers[w.id]
+	if !ok {
+		return
+	}
+
+	queue.stop()
+	delete(w.registry.watchers, w.id)
+
+	log.Printf("[Registry] registry watch '%d' stopped. Watchers count: '%d'", w.id, len(w.registry.watchers))
+}
+
+func (r *ContainerRegistry) Watch() Watch {
+	r.watchersLock.Lock()
+	defer r.watchersLock.Unlock()
+
+	queue := newEventQueue(defaultEventQueueLimit)
+	w := Watch{
+		C: queue.out,
+
+		id:       r.nextWatcherID,
+		registry: r,
+		queue:    queue,
+	}
+
+	r.watchers[w.id] = queue
+	r.nextWatcherID++
+
+	log.Printf("[Registry] new registry watch '%d'. Watchers count: '%d'", w.id, len(r.watchers))
+	return w
+}
+
+func (r *ContainerRegistry) notifyWatchers(e Event) {
+	r.watchersLock.Lock()
+	defer r.watchersLock.Unlock()
+
+	for id, queue := range r.watchers {
+		if !queue.push(e) {
+			log.Printf("[Registry] registry watch '%d' dropped: %v", id, queue.Err())
+			delete(r.watchers, id)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package registry
+
+import (
+	"sync"
+	"time"
+
+	"github.com/denkoren/mi-labs-test/internal/core"
+)
+
+// Number of the latest container startups used for startup duration estimation
+const defaultStartupHistorySize = 10
+
+// startupHistory is a ring buffer of the latest container startup durations
+type startupHistory struct {
+	durations []time.Duration
+	next      int
+}
+
+func (h *startupHistory) add(d time.Duration) {
+	if len(h.durations) < defaultStartupHistorySize {
+		h.durations = append(h.durations, d)
+		return
+	}
+
+	h.durations[h.next] = d
+	h.next = (h.next + 1) % defaultStartupHistorySize
+}
+
+func (h *startupHistory) mean() time.Duration {
+	var sum time.Duration
+	for _, d := range h.durations {
+		sum += d
+	}
+
+	return sum / time.Duration(len(h.durations))
+}
+
+// startupStats collects the time containers spend from start scheduling to readiness.
+// Containers with the same params behave alike, so their history is preferred for estimations.
+type startupStats struct {
+	byParams map[string]*startupHistory // by params key
+	overall  *startupHistory
+
+	lock sync.Mutex
+}
+
+func newStartupStats() *startupStats {
+	return &startupStats{
+		byParams: make(map[string]*startupHistory, defaultContainerRegistryCapacity),
+		overall:  &startupHistory{},
+	}
+}
+
+// Thread-safe
+func (s *startupStats) record(params core.ContainerParams, d time.Duration) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	h, ok := s.byParams[params.Key()]
+	if !ok {
+		h = &startupHistory{}
+		s.byParams[params.Key()] = h
+	}
+
+	h.add(d)
+	s.overall.add(d)
+}
+
+// Thread-safe
+func (s *startupStats) estimate(params core.ContainerParams) (time.Duration, bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if h, ok := s.byParams[params.Key()]; ok {
+		return h.mean(), true
+	}
+
+	if len(s.overall.durations) != 0 {
+		return s.overall.mean(), true
+	}
+
+	return 0, false
+}
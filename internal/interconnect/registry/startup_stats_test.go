@@ -0,0 +1,74 @@
+package registry
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/denkoren/mi-labs-test/internal/core"
+)
+
+func TestStartupStats(t *testing.T) {
+	hg38 := core.ContainerParams{Service: "compute", Seed: "1", Values: map[string]string{"genome": "hg38"}}
+	hg19 := core.ContainerParams{Service: "compute", Seed: "1", Values: map[string]string{"genome": "hg19"}}
+	other := core.ContainerParams{Service: "compute", Seed: "2"}
+
+	type record struct {
+		params core.ContainerParams
+		d      time.Duration
+	}
+
+	tests := []struct {
+		name    string
+		records []record
+		params  core.ContainerParams
+		want    time.Duration
+		wantOK  bool
+	}{
+		{
+			name:   "no history",
+			params: hg38,
+		},
+		{
+			name:    "params history",
+			records: []record{{hg38, 10 * time.Second}, {hg38, 20 * time.Second}, {hg19, time.Minute}},
+			params:  hg38,
+			want:    15 * time.Second,
+			wantOK:  true,
+		},
+		{
+			name:    "overall history for new params",
+			records: []record{{hg38, 10 * time.Second}, {hg19, 20 * time.Second}},
+			params:  other,
+			want:    15 * time.Second,
+			wantOK:  true,
+		},
+		{
+			name: "latest startups only",
+			records: func() []record {
+				records := []record{{hg38, time.Hour}}
+				for i := 0; i < defaultStartupHistorySize; i++ {
+					records = append(records, record{hg38, time.Second})
+				}
+				return records
+			}(),
+			params: hg38,
+			want:   time.Second,
+			wantOK: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newStartupStats()
+			for _, r := range tt.records {
+				s.record(r.params, r.d)
+			}
+
+			got, ok := s.estimate(tt.params)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
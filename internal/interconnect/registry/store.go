@@ -0,0 +1,40 @@
+package registry
+
+import (
+	"github.com/denkoren/mi-labs-test/internal/core"
+)
+
+// Store persists registry state, so it survives service restarts.
+// Containers are stored by their params keys: registry has single container per params.
+// Retired containers are stored by their IDs, see storeKey.
+type Store interface {
+	Save(info core.ContainerInfo) error
+	Delete(info core.ContainerInfo) error
+	Load() ([]core.ContainerInfo, error)
+
+	// Current images of services, changed by upgrades
+	SaveServiceImage(service string, digest string) error
+	LoadServiceImages() (map[string]string, error)
+
+	Close() error
+}
+
+// NopStore keeps nothing. Registry with this store lives in memory only.
+type NopStore struct{}
+
+func (NopStore) Save(core.ContainerInfo) error       { return nil }
+func (NopStore) Delete(core.ContainerInfo) error     { return nil }
+func (NopStore) Load() ([]core.ContainerInfo, error) { return nil, nil }
+func (NopStore) Close() error                        { return nil }
+
+func (NopStore) SaveServiceImage(string, string) error         { return nil }
+func (NopStore) LoadServiceImages() (map[string]string, error) { return nil, nil }
+
+// storeKey identifies container record in store. Retired container shares params with its replacement.
+func storeKey(info core.ContainerInfo) string {
+	if info.Retired {
+		return "retired/" + info.ID
+	}
+
+	return info.Params.Key()
+}
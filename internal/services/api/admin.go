@@ -0,0 +1,140 @@
+package api
+
+import (
+	"context"
+	"log"
+
+	"github.com/denkoren/mi-labs-test/internal/core"
+	"github.com/denkoren/mi-labs-test/internal/interconnect/docker"
+	"github.com/denkoren/mi-labs-test/internal/interconnect/registry"
+	apipb "github.com/denkoren/mi-labs-test/proto/api/v1"
+)
+
+// AdminServer implements container management API for operators.
+// It shares containers with API server and manages them the same way.
+type AdminServer struct {
+	apipb.UnimplementedZapuskatorAdminAPIServer
+
+	server *Server
+}
+
+func NewAdminServer(server *Server) (*AdminServer, error) {
+	return &AdminServer{
+		server: server,
+	}, nil
+}
+
+func (a *AdminServer) StopContainer(ctx context.Context, request *apipb.Admin_Request) (*apipb.Admin_Response, error) {
+	return a.manage(ctx, request, func(container *registry.ContainerInfo) error {
+		return a.stopContainer(ctx, container)
+	})
+}
+
+func (a *AdminServer) RestartContainer(ctx context.Context, request *apipb.Admin_Request) (*apipb.Admin_Response, error) {
+	ctx = withActor(ctx, registry.ActorAdmin)
+	return a.manage(ctx, request, func(container *registry.ContainerInfo) error {
+		err := a.stopContainer(ctx, container)
+		if err != nil {
+			return err
+		}
+
+		return a.server.startContainer(ctx, container)
+	})
+}
+
+func (a *AdminServer) RecreateContainer(ctx context.Context, request *apipb.Admin_Request) (*apipb.Admin_Response, error) {
+	ctx = withActor(ctx, registry.ActorAdmin)
+	return a.manage(ctx, request, func(container *registry.ContainerInfo) error {
+		err := container.ToStopped(
+			registry.By(registry.ActorAdmin),
+			func(c *registry.ContainerInfo, _ core.ContainerStatus) error {
+				err := a.server.docker.RemoveContainer(ctx, c.ID)
+				if docker.IsNotFound(err) {
+					return nil
+				}
+				return err
+			},
+			logAdminTransition,
+		)
+		if err != nil {
+			return err
+		}
+
+		// Container absent in Docker is created again on start
+		return a.server.startContainer(ctx, container)
+	})
+}
+
+func (a *AdminServer) DrainContainer(ctx context.Context, request *apipb.Admin_Request) (*apipb.Admin_Response, error) {
+	return a.manage(ctx, request, drainContainer)
+}
+
+func (a *AdminServer) PinContainer(ctx context.Context, request *apipb.Admin_Request) (*apipb.Admin_Response, error) {
+	return a.manage(ctx, request, func(container *registry.ContainerInfo) error {
+		return container.Modify(func(c *registry.ContainerInfo, _ core.ContainerStatus) error {
+			c.Pinned = true
+			return nil
+		})
+	})
+}
+
+func (a *AdminServer) UnpinContainer(ctx context.Context, request *apipb.Admin_Request) (*apipb.Admin_Response, error) {
+	return a.manage(ctx, request, func(container *registry.ContainerInfo) error {
+		return container.Modify(func(c *registry.ContainerInfo, _ core.ContainerStatus) error {
+			c.Pinned = false
+			return nil
+		})
+	})
+}
+
+// manage performs the action with seed's container and returns its info
+func (a *AdminServer) manage(ctx context.Context, request *apipb.Admin_Request, action func(container *registry.ContainerInfo) error) (*apipb.Admin_Response, error) {
+	_, params, err := a.server.containerParams(
+		ctx,
+		request.GetService(),
+		request.GetImageVersion(),
+		request.GetSeed(),
+		request.GetValues(),
+	)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	container, err := a.server.registry.GetByParams(params)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	err = action(container)
+	if err != nil {
+		log.Printf("[Admin] failed to manage container '%s': %v", container.ID, err)
+		return nil, statusError(newCalculationError(container, err))
+	}
+
+	return &apipb.Admin_Response{
+		Info: a.server.containerInfoToProto(container.Snapshot()),
+	}, nil
+}
+
+func (a *AdminServer) stopContainer(ctx context.Context, container *registry.ContainerInfo) error {
+	return container.ToStopped(
+		registry.By(registry.ActorAdmin),
+		func(c *registry.ContainerInfo, _ core.ContainerStatus) error {
+			return a.server.docker.StopContainer(ctx, c.ID)
+		},
+		logAdminTransition,
+	)
+}
+
+// drainContainer makes active container stop once calculations in progress are finished
+func drainContainer(container *registry.ContainerInfo) error {
+	return container.Modify(func(c *registry.ContainerInfo, _ core.ContainerStatus) error {
+		c.Draining = c.Status.IsActive()
+		return nil
+	})
+}
+
+func logAdminTransition(c *registry.ContainerInfo, newStatus core.ContainerStatus) error {
+	log.Printf("[Admin] container '%s' transitioned from '%s' to '%s'", c.ID, c.Status.String(), newStatus.String())
+	return nil
+}
@@ -0,0 +1,125 @@
+package api
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/denkoren/mi-labs-test/internal/core"
+	"github.com/denkoren/mi-labs-test/internal/interconnect/registry"
+	apipb "github.com/denkoren/mi-labs-test/proto/api/v1"
+)
+
+const testImageDigest = "sha256:0123"
+
+// newTestServer creates API server without Docker. Containers are registered with their seeds as IDs.
+func newTestServer(t *testing.T, infos ...core.ContainerInfo) *Server {
+	services, err := core.NewServices("compute", core.Service{
+		Name:          "compute",
+		Image:         "compute:1.0",
+		Port:          8080,
+		CalculatePath: "/calculate",
+		ParamsTemplate: core.ParamsTemplate{
+			Env: map[string]string{"SEED": "{{.seed}}", "GENOME": "{{.genome}}"},
+		},
+	})
+	require.NoError(t, err)
+
+	r, err := registry.NewContainerRegistry(registry.NopStore{})
+	require.NoError(t, err)
+
+	for _, info := range infos {
+		info.ID = info.Params.Seed
+		info.Params.Service = "compute"
+		info.Params.ImageDigest = testImageDigest
+		require.NoError(t, r.Register(registry.NewContainerInfo(r, info)))
+	}
+
+	s, err := NewServer(Config{Services: services, ServiceImages: map[string]string{"compute": testImageDigest}}, r, nil)
+	require.NoError(t, err)
+
+	return s
+}
+
+func TestAdminServer_Modify(t *testing.T) {
+	s := newTestServer(t,
+		core.ContainerInfo{Params: core.ContainerParams{Seed: "ready"}, Status: core.ContainerStatusReady},
+		core.ContainerInfo{Params: core.ContainerParams{Seed: "stopped"}, Status: core.ContainerStatusStopped},
+	)
+	a, err := NewAdminServer(s)
+	require.NoError(t, err)
+
+	type rpc func(context.Context, *apipb.Admin_Request) (*apipb.Admin_Response, error)
+
+	tests := []struct {
+		name         string
+		rpc          rpc
+		seed         string
+		wantCode     codes.Code
+		wantPinned   bool
+		wantDraining bool
+	}{
+		{name: "pin", rpc: a.PinContainer, seed: "ready", wantPinned: true},
+		{name: "unpin", rpc: a.UnpinContainer, seed: "ready"},
+		{name: "drain", rpc: a.DrainContainer, seed: "ready", wantDraining: true},
+		{name: "drain stopped", rpc: a.DrainContainer, seed: "stopped"},
+		{name: "unknown seed", rpc: a.PinContainer, seed: "unknown", wantCode: codes.NotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response, err := tt.rpc(context.Background(), &apipb.Admin_Request{Seed: tt.seed})
+			assert.Equal(t, tt.wantCode, status.Code(err))
+			if tt.wantCode != codes.OK {
+				return
+			}
+
+			assert.Equal(t, tt.seed, response.GetInfo().GetId())
+			assert.Equal(t, tt.wantPinned, response.GetInfo().GetPinned())
+			assert.Equal(t, tt.wantDraining, response.GetInfo().GetDraining())
+		})
+	}
+}
+
+func TestServer_RecoverContainerStoppedByOperator(t *testing.T) {
+	tests := []struct {
+		name       string
+		stop       bool
+		drain      bool
+		wantReason string
+	}{
+		{name: "stopped by admin", stop: true, wantReason: reasonContainerStopped},
+		{name: "drained by admin", drain: true, wantReason: reasonContainerDraining},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestServer(t, core.ContainerInfo{Params: core.ContainerParams{Seed: "42"}, Status: core.ContainerStatusReady})
+			container, err := s.registry.PeekByID("42")
+			require.NoError(t, err)
+
+			failedAt := time.Now()
+			if tt.stop {
+				require.NoError(t, container.ToStopped(registry.By(registry.ActorAdmin)))
+			}
+			if tt.drain {
+				require.NoError(t, drainContainer(container))
+				require.NoError(t, container.ToUnreachable(registry.By(registry.ActorBackground)))
+			}
+			status := container.Snapshot().Status
+
+			err = s.recoverContainer(context.Background(), container, failedAt)
+			require.Error(t, err)
+
+			code, reason, _ := classifyError(err)
+			assert.Equal(t, codes.Unavailable, code)
+			assert.Equal(t, tt.wantReason, reason)
+			assert.Equal(t, status, container.Snapshot().Status, "container is left as operator made it")
+		})
+	}
+}
@@ -0,0 +1,283 @@
+package api
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/denkoren/mi-labs-test/internal/core"
+	apipb "github.com/denkoren/mi-labs-test/proto/api/v1"
+)
+
+const (
+	defaultContainersPageSize = 100
+	maxContainersPageSize     = 1000
+)
+
+var protoContainerStatuses = map[core.ContainerStatus]apipb.Container_Status{
+	core.ContainerStatusNew:         apipb.Container_NEW,
+	core.ContainerStatusCreated:     apipb.Container_CREATED,
+	core.ContainerStatusStarting:    apipb.Container_STARTING,
+	core.ContainerStatusRunning:     apipb.Container_NOT_READY,
+	core.ContainerStatusReady:       apipb.Container_READY,
+	core.ContainerStatusUnreachable: apipb.Container_UNREACHABLE,
+	core.ContainerStatusPaused:      apipb.Container_PAUSED,
+	core.ContainerStatusStopped:     apipb.Container_STOPPED,
+	core.ContainerStatusFailed:      apipb.Container_FAILED,
+}
+
+func (s *Server) GetContainerInfo(_ context.Context, request *apipb.Container_Request) (*apipb.Container_Response, error) {
+	container, err := s.registry.PeekByID(request.GetId())
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	return &apipb.Container_Response{
+		Info: s.containerInfoToProto(container.Snapshot()),
+	}, nil
+}
+
+func (s *Server) GetContainerHistory(_ context.Context, request *apipb.Container_Request) (*apipb.ContainerHistory_Response, error) {
+	transitions, err := s.registry.History(request.GetId())
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	response := &apipb.ContainerHistory_Response{
+		Transitions: make([]*apipb.ContainerHistory_Transition, 0, len(transitions)),
+	}
+	for _, t := range transitions {
+		transition := &apipb.ContainerHistory_Transition{
+			Time:  timestampToProto(t.Time),
+			From:  protoContainerStatuses[t.From],
+			To:    protoContainerStatuses[t.To],
+			Actor: string(t.Actor),
+		}
+		if t.Err != nil {
+			transition.Error = t.Err.Error()
+		}
+
+		response.Transitions = append(response.Transitions, transition)
+	}
+
+	return response, nil
+}
+
+func (s *Server) ListContainers(_ context.Context, request *apipb.ListContainers_Request) (*apipb.ListContainers_Response, error) {
+	after, err := decodePageToken(request.GetPageToken())
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	pageSize := int(request.GetPageSize())
+	if pageSize <= 0 {
+		pageSize = defaultContainersPageSize
+	}
+	if pageSize > maxContainersPageSize {
+		pageSize = maxContainersPageSize
+	}
+
+	containers, err := s.registry.Containers()
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	filter := s.newContainersFilter(request)
+	infos := make([]core.ContainerInfo, 0, len(containers))
+	for _, container := range containers {
+		info := container.Snapshot()
+		if filter.match(info) && after.before(info) {
+			infos = append(infos, info)
+		}
+	}
+
+	sort.Slice(infos, func(i, j int) bool {
+		return pageCursorOf(infos[i]).before(infos[j])
+	})
+
+	response := &apipb.ListContainers_Response{}
+	if len(infos) > pageSize {
+		infos = infos[:pageSize]
+		response.NextPageToken = pageCursorOf(infos[pageSize-1]).encode()
+	}
+
+	response.Containers = make([]*apipb.Container_Info, 0, len(infos))
+	for _, info := range infos {
+		response.Containers = append(response.Containers, s.containerInfoToProto(info))
+	}
+
+	return response, nil
+}
+
+func (s *Server) containerInfoToProto(info core.ContainerInfo) *apipb.Container_Info {
+	result := &apipb.Container_Info{
+		Id:   info.ID,
+		Addr: info.Addr,
+		Params: &apipb.Container_Params{
+			Service: s.serviceName(info.Params),
+			Seed:    info.Params.Seed,
+			Values:  info.Params.Values,
+		},
+		Status:   protoContainerStatuses[info.Status],
+		Progress: int32(info.Progress),
+
+		Created:   timestampToProto(info.Created),
+		Scheduled: timestampToProto(info.Scheduled),
+		Started:   timestampToProto(info.Started),
+		Stopped:   timestampToProto(info.Stopped),
+		Updated:   timestampToProto(info.Updated),
+		LastUsed:  timestampToProto(info.LastUsed),
+
+		Pinned:             info.Pinned,
+		Draining:           info.Draining,
+		ActiveCalculations: int32(info.ActiveCalculations),
+
+		ImageDigest: info.Params.ImageDigest,
+		Resources:   resourcesToProto(info.Resources),
+	}
+
+	if readyIn, ok := s.containerReadyIn(info); ok {
+		result.ReadyIn = durationpb.New(readyIn)
+	}
+
+	return result
+}
+
+func resourcesToProto(r core.Resources) *apipb.Container_Resources {
+	result := &apipb.Container_Resources{
+		CpuShares:         r.CPUShares,
+		CpuQuota:          r.CPUQuota,
+		CpuPeriod:         r.CPUPeriod,
+		Memory:            r.Memory,
+		MemoryReservation: r.MemoryReservation,
+		PidsLimit:         r.PidsLimit,
+		Cores:             r.Cores,
+		Cpuset:            r.CPUSet,
+	}
+
+	for _, ulimit := range r.Ulimits {
+		result.Ulimits = append(result.Ulimits, &apipb.Container_Ulimit{
+			Name: ulimit.Name,
+			Soft: ulimit.Soft,
+			Hard: ulimit.Hard,
+		})
+	}
+
+	return result
+}
+
+func timestampToProto(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+type containersFilter struct {
+	statuses map[core.ContainerStatus]bool
+	service  string
+	seed     string
+	idleFor  time.Duration
+
+	serviceName func(params core.ContainerParams) string
+}
+
+func (s *Server) newContainersFilter(request *apipb.ListContainers_Request) containersFilter {
+	filter := containersFilter{
+		service: request.GetService(),
+		seed:    request.GetSeed(),
+		idleFor: request.GetIdleFor().AsDuration(),
+
+		serviceName: s.serviceName,
+	}
+
+	if len(request.GetStatuses()) != 0 {
+		filter.statuses = make(map[core.ContainerStatus]bool, len(request.GetStatuses()))
+		for _, protoStatus := range request.GetStatuses() {
+			for status, p := range protoContainerStatuses {
+				if p == protoStatus {
+					filter.statuses[status] = true
+				}
+			}
+		}
+	}
+
+	return filter
+}
+
+func (f containersFilter) match(info core.ContainerInfo) bool {
+	if f.statuses != nil && !f.statuses[info.Status] {
+		return false
+	}
+
+	if f.service != "" && f.service != f.serviceName(info.Params) {
+		return false
+	}
+
+	if f.seed != "" && f.seed != info.Params.Seed {
+		return false
+	}
+
+	if f.idleFor > 0 && time.Since(info.LastUsed) < f.idleFor {
+		return false
+	}
+
+	return true
+}
+
+// pageCursor is the position of container in the list, ordered by creation time.
+// Params key makes the order stable for containers created at the same time: there is only one container per params.
+type pageCursor struct {
+	created   int64
+	paramsKey string
+}
+
+func pageCursorOf(info core.ContainerInfo) pageCursor {
+	return pageCursor{
+		created:   info.Created.UnixNano(),
+		paramsKey: info.Params.Key(),
+	}
+}
+
+// before reports if container goes after the cursor in the list. Zero cursor is before any container.
+func (c pageCursor) before(info core.ContainerInfo) bool {
+	other := pageCursorOf(info)
+	if c.created != other.created {
+		return c.created < other.created
+	}
+	return c.paramsKey < other.paramsKey
+}
+
+func (c pageCursor) encode() string {
+	return base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf("%d/%s", c.created, c.paramsKey)))
+}
+
+func decodePageToken(token string) (pageCursor, error) {
+	if token == "" {
+		return pageCursor{}, nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return pageCursor{}, fmt.Errorf("%w: %v", ErrInvalidPageToken, err)
+	}
+
+	parts := strings.SplitN(string(data), "/", 2)
+	if len(parts) != 2 {
+		return pageCursor{}, ErrInvalidPageToken
+	}
+
+	created, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return pageCursor{}, fmt.Errorf("%w: %v", ErrInvalidPageToken, err)
+	}
+
+	return pageCursor{created: created, paramsKey: parts[1]}, nil
+}
@@ -0,0 +1,79 @@
+package api
+
+import (
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/denkoren/mi-labs-test/internal/core"
+)
+
+func TestDecodePageToken(t *testing.T) {
+	info := core.ContainerInfo{
+		Params:  core.ContainerParams{Service: "compute", Seed: "42"},
+		Created: time.Date(2021, 9, 1, 12, 0, 0, 0, time.UTC),
+	}
+	encode := func(s string) string {
+		return base64.RawURLEncoding.EncodeToString([]byte(s))
+	}
+
+	tests := []struct {
+		name    string
+		token   string
+		want    pageCursor
+		wantErr bool
+	}{
+		{name: "first page", token: ""},
+		{name: "round trip", token: pageCursorOf(info).encode(), want: pageCursorOf(info)},
+		{name: "not base64", token: "!!!", wantErr: true},
+		{name: "no params key", token: encode("1630497600000000000"), wantErr: true},
+		{name: "invalid time", token: encode("noon/abc"), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := decodePageToken(tt.token)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidPageToken)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestPageCursor_Before(t *testing.T) {
+	created := time.Date(2021, 9, 1, 12, 0, 0, 0, time.UTC)
+	info := func(seed string, created time.Time) core.ContainerInfo {
+		return core.ContainerInfo{Params: core.ContainerParams{Seed: seed}, Created: created}
+	}
+	cursor := pageCursorOf(info("42", created))
+
+	tests := []struct {
+		name   string
+		cursor pageCursor
+		info   core.ContainerInfo
+		want   bool
+	}{
+		{name: "zero cursor", cursor: pageCursor{}, info: info("42", created), want: true},
+		{name: "created later", cursor: cursor, info: info("42", created.Add(time.Second)), want: true},
+		{name: "created earlier", cursor: cursor, info: info("42", created.Add(-time.Second))},
+		{name: "same container", cursor: cursor, info: info("42", created)},
+		{
+			name:   "created at the same time",
+			cursor: cursor,
+			info:   info("43", created),
+			want:   cursor.paramsKey < info("43", created).Params.Key(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.cursor.before(tt.info))
+		})
+	}
+}
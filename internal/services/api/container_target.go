@@ -0,0 +1,146 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/denkoren/mi-labs-test/internal/core"
+	"github.com/denkoren/mi-labs-test/internal/interconnect/docker"
+	"github.com/denkoren/mi-labs-test/internal/interconnect/registry"
+)
+
+var errContainerRecovered = errors.New("container was already recovered")
+
+// containerTarget is the registry container, that performs calculations for API server.
+type containerTarget struct {
+	server    *Server
+	container *registry.ContainerInfo
+	port      int // Port of container service
+}
+
+func (t containerTarget) addr() string {
+	return net.JoinHostPort(t.container.Snapshot().Addr, strconv.Itoa(t.port))
+}
+
+func (t containerTarget) prepare(ctx context.Context) (func(), error) {
+	err := t.container.CalculationStarted()
+	if err != nil {
+		return nil, err
+	}
+
+	refreshCtx, cancel := context.WithCancel(ctx)
+	go t.server.refreshContainerLastUsed(refreshCtx, t.container)
+
+	finish := func() {
+		cancel()
+		t.container.CalculationFinished()
+	}
+
+	err = t.server.prepareContainer(ctx, t.container)
+	if err != nil {
+		finish()
+		return nil, err
+	}
+
+	return finish, nil
+}
+
+func (t containerTarget) lost(ctx context.Context) <-chan struct{} {
+	lost := make(chan struct{})
+	subscription := t.container.Subscribe()
+
+	go func() {
+		defer subscription.Unsubscribe()
+
+		if isLostStatus(subscription.Status) {
+			close(lost)
+			return
+		}
+
+		for {
+			select {
+			case event, ok := <-subscription.C:
+				if !ok {
+					// Subscription was dropped. Calculation timeout still protects from container loss.
+					log.Printf("[API] stopped watching container '%s': %v", subscription.Info.ID, subscription.Err())
+					return
+				}
+				if isLostStatus(event.Status) {
+					close(lost)
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return lost
+}
+
+func (t containerTarget) recover(ctx context.Context, failedAt time.Time) error {
+	return t.server.recoverContainer(ctx, t.container, failedAt)
+}
+
+func (t containerTarget) timedOut() {
+	t.container.CalculationTimedOut()
+}
+
+func (t containerTarget) responded() {
+	t.container.CalculationResponded()
+}
+
+func isLostStatus(status core.ContainerStatus) bool {
+	return status == core.ContainerStatusUnreachable ||
+		status == core.ContainerStatusStopped ||
+		status == core.ContainerStatusFailed
+}
+
+// recoverContainer restarts the container after its failure at <failedAt> time.
+// The container is recreated if it was removed from Docker.
+// Concurrent recoveries of the same container restart it only once.
+// Containers stopped or drained by operator are not recovered: it would undo the operator's action.
+func (s *Server) recoverContainer(ctx context.Context, container *registry.ContainerInfo, failedAt time.Time) error {
+	log.Printf("[API] recovering container '%s'", container.ID)
+
+	err := container.ToStopped(
+		registry.By(actorOf(ctx)),
+		func(c *registry.ContainerInfo, _ core.ContainerStatus) error {
+			if c.Scheduled.After(failedAt) {
+				// Another thread already restarted the container after the failure
+				return errContainerRecovered
+			}
+			if c.Draining {
+				return registry.ErrContainerDraining
+			}
+			if c.Status == core.ContainerStatusStopped && c.StatusActor() == registry.ActorAdmin {
+				return ErrContainerStopped
+			}
+
+			err := s.docker.StopContainer(ctx, c.ID)
+			if docker.IsNotFound(err) {
+				// Container was removed. It will be recreated on start
+				return nil
+			}
+			return err
+		},
+		logTransition,
+	)
+
+	if err != nil &&
+		!errors.Is(err, errContainerRecovered) &&
+		!errors.Is(err, registry.ErrTransitionNotAllowed) {
+		return err
+	}
+
+	err = s.startContainer(ctx, container)
+	if err != nil {
+		return err
+	}
+
+	return s.waitForContainer(ctx, container)
+}
@@ -0,0 +1,80 @@
+package api
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/denkoren/mi-labs-test/internal/core"
+	"github.com/denkoren/mi-labs-test/internal/interconnect/registry"
+)
+
+func TestContainerTarget_HungCalculations(t *testing.T) {
+	const hungTimeouts = 3
+
+	hung := int32(1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.LoadInt32(&hung) == 1 {
+			<-r.Context().Done()
+			return
+		}
+		_, _ = w.Write([]byte("result"))
+	}))
+	defer server.Close()
+
+	host, port, err := net.SplitHostPort(server.Listener.Addr().String())
+	require.NoError(t, err)
+	portNumber, err := strconv.Atoi(port)
+	require.NoError(t, err)
+
+	s := newTestServer(t, core.ContainerInfo{Params: core.ContainerParams{Seed: "42"}, Status: core.ContainerStatusReady})
+	container, err := s.registry.PeekByID("42")
+	require.NoError(t, err)
+	require.NoError(t, container.Modify(func(c *registry.ContainerInfo, _ core.ContainerStatus) error {
+		c.Addr = host
+		return nil
+	}))
+
+	calc := calculation{
+		key:     "key",
+		target:  containerTarget{server: s, container: container, port: portNumber},
+		method:  http.MethodGet,
+		path:    "/calculate/input",
+		noCache: true,
+		timeout: 20 * time.Millisecond,
+	}
+
+	for i := 1; i <= hungTimeouts; i++ {
+		reader, errCh, err := s.requester.getRequest(context.Background(), calc)
+		require.NoError(t, err)
+		assert.ErrorIs(t, <-errCh, ErrCalculationTimeout)
+		_ = reader.Close()
+
+		assert.Equal(t, i, container.Snapshot().CalculationTimeouts)
+
+		containers, err := s.registry.HungContainers(hungTimeouts)
+		require.NoError(t, err)
+		if i < hungTimeouts {
+			assert.Empty(t, containers, "container is not hung after %d timeouts", i)
+		} else {
+			assert.Equal(t, []*registry.ContainerInfo{container}, containers)
+		}
+	}
+
+	// Response in time resets the counter
+	atomic.StoreInt32(&hung, 0)
+	reader, errCh, err := s.requester.getRequest(context.Background(), calc)
+	require.NoError(t, err)
+	require.NoError(t, <-errCh)
+	_ = reader.Close()
+
+	assert.Zero(t, container.Snapshot().CalculationTimeouts)
+}
@@ -0,0 +1,253 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/denkoren/mi-labs-test/internal/core"
+	"github.com/denkoren/mi-labs-test/internal/interconnect/docker"
+	"github.com/denkoren/mi-labs-test/internal/interconnect/registry"
+)
+
+const errorDomain = "zapuskator"
+
+// Error reasons, reported in google.rpc.ErrorInfo details of API errors
+const (
+	reasonCanceled          = "CALCULATION_CANCELED"
+	reasonNotReadyInTime    = "CONTAINER_NOT_READY_IN_TIME"
+	reasonContainerTimeout  = "CONTAINER_WAIT_TIMEOUT"
+	reasonDeadlineExceeded  = "DEADLINE_EXCEEDED"
+	reasonCalculationHung   = "CALCULATION_TIMEOUT"
+	reasonContainerRejected = "CONTAINER_REJECTED_INPUT"
+	reasonContainerFailed   = "CONTAINER_FAILED"
+	reasonContainerDown     = "CONTAINER_UNAVAILABLE"
+	reasonDockerFailed      = "DOCKER_FAILED"
+	reasonDockerExhausted   = "DOCKER_RESOURCES_EXHAUSTED"
+	reasonContainerNotFound = "CONTAINER_NOT_FOUND"
+	reasonContainerDraining = "CONTAINER_DRAINING"
+	reasonContainerStopped  = "CONTAINER_STOPPED"
+	reasonUnknownService    = "UNKNOWN_SERVICE"
+	reasonImageNotFound     = "IMAGE_NOT_FOUND"
+	reasonUpgradeInProgress = "UPGRADE_IN_PROGRESS"
+	reasonSequenceLost      = "SEQUENCE_UNAVAILABLE"
+	reasonInvalidRequest    = "INVALID_REQUEST"
+	reasonInputTooLarge     = "INPUT_TOO_LARGE"
+	reasonInternal          = "INTERNAL"
+)
+
+var (
+	ErrContainerWaitTimeout = errors.New("container was not ready in time")
+	ErrContainerLost        = errors.New("container stopped serving requests")
+	ErrContainerStopped     = errors.New("container was stopped by operator")
+	ErrCalculationTimeout   = errors.New("calculation took too long")
+	ErrUpgradeInProgress    = errors.New("service upgrade is already in progress")
+	ErrInvalidPageToken     = errors.New("invalid page token")
+	ErrInvalidArgument      = errors.New("invalid argument")
+)
+
+// UpstreamError is an unsuccessful response of container
+type UpstreamError struct {
+	StatusCode int
+	Status     string
+}
+
+func (e *UpstreamError) Error() string {
+	return fmt.Sprintf("container responded with code '%d: %s'", e.StatusCode, e.Status)
+}
+
+// InputSizeError rejects calculation input exceeding the service limit
+type InputSizeError struct {
+	Size  int64
+	Limit int64
+}
+
+func (e *InputSizeError) Error() string {
+	return fmt.Sprintf("input of '%d' bytes exceeds the limit of '%d' bytes", e.Size, e.Limit)
+}
+
+// AdmissionError rejects calculation, which container can't be ready before client's deadline.
+type AdmissionError struct {
+	ReadyIn  time.Duration // Expected time left until container readiness
+	TimeLeft time.Duration // Time left until client's deadline
+}
+
+func (e *AdmissionError) Error() string {
+	return fmt.Sprintf(
+		"container needs about %s to be ready, but request deadline expires in %s",
+		e.ReadyIn.Round(time.Second),
+		e.TimeLeft.Round(time.Millisecond),
+	)
+}
+
+// CalculationError binds the error to the container, that was used for calculation
+type CalculationError struct {
+	Seed        string
+	ContainerID string
+	Err         error
+}
+
+func newCalculationError(container *registry.ContainerInfo, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return &CalculationError{
+		Seed:        container.Params.Seed,
+		ContainerID: container.ID,
+		Err:         err,
+	}
+}
+
+func (e *CalculationError) Error() string {
+	return fmt.Sprintf("calculation for seed '%s' failed: %v", e.Seed, e.Err)
+}
+
+func (e *CalculationError) Unwrap() error {
+	return e.Err
+}
+
+// statusError converts error to gRPC status error with google.rpc.ErrorInfo details.
+func statusError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	if _, ok := status.FromError(err); ok {
+		// Already converted
+		return err
+	}
+
+	code, reason, metadata := classifyError(err)
+
+	var calcErr *CalculationError
+	if errors.As(err, &calcErr) {
+		metadata["seed"] = calcErr.Seed
+		if calcErr.ContainerID != "" {
+			metadata["container_id"] = calcErr.ContainerID
+		}
+	}
+
+	details := []proto.Message{
+		&errdetails.ErrorInfo{
+			Reason:   reason,
+			Domain:   errorDomain,
+			Metadata: metadata,
+		},
+	}
+
+	var admissionErr *AdmissionError
+	if errors.As(err, &admissionErr) {
+		details = append(details, &errdetails.RetryInfo{
+			RetryDelay: durationpb.New(admissionErr.ReadyIn),
+		})
+	}
+
+	st := status.New(code, err.Error())
+	detailed, detailsErr := st.WithDetails(details...)
+	if detailsErr != nil {
+		return st.Err()
+	}
+
+	return detailed.Err()
+}
+
+func classifyError(err error) (codes.Code, string, map[string]string) {
+	metadata := make(map[string]string)
+
+	var (
+		admissionErr  *AdmissionError
+		inputSizeErr  *InputSizeError
+		upstreamErr   *UpstreamError
+		dockerErr     *docker.Error
+		transitionErr *registry.TransitionError
+		urlErr        *url.Error
+		netErr        net.Error
+	)
+
+	switch {
+	case errors.Is(err, context.Canceled):
+		return codes.Canceled, reasonCanceled, metadata
+
+	case errors.Is(err, ErrInvalidPageToken),
+		errors.Is(err, ErrInvalidArgument):
+		return codes.InvalidArgument, reasonInvalidRequest, metadata
+
+	case errors.As(err, &inputSizeErr):
+		metadata["max_input_size"] = fmt.Sprintf("%d", inputSizeErr.Limit)
+		return codes.InvalidArgument, reasonInputTooLarge, metadata
+
+	case errors.Is(err, ErrUpgradeInProgress):
+		return codes.Aborted, reasonUpgradeInProgress, metadata
+
+	case errors.Is(err, registry.ErrSequenceUnavailable):
+		return codes.OutOfRange, reasonSequenceLost, metadata
+
+	case errors.Is(err, registry.ErrContainerNotExists):
+		return codes.NotFound, reasonContainerNotFound, metadata
+
+	case errors.Is(err, core.ErrUnknownService):
+		return codes.NotFound, reasonUnknownService, metadata
+
+	case errors.Is(err, docker.ErrImageNotFound):
+		return codes.NotFound, reasonImageNotFound, metadata
+
+	case errors.Is(err, registry.ErrContainerDraining):
+		return codes.Unavailable, reasonContainerDraining, metadata
+
+	case errors.Is(err, ErrContainerStopped):
+		return codes.Unavailable, reasonContainerStopped, metadata
+
+	case errors.As(err, &admissionErr):
+		metadata["ready_in"] = admissionErr.ReadyIn.String()
+		return codes.Unavailable, reasonNotReadyInTime, metadata
+
+	case errors.Is(err, ErrCalculationTimeout):
+		return codes.DeadlineExceeded, reasonCalculationHung, metadata
+
+	case errors.Is(err, ErrContainerWaitTimeout):
+		return codes.DeadlineExceeded, reasonContainerTimeout, metadata
+
+	case errors.Is(err, context.DeadlineExceeded):
+		// Client's own deadline
+		return codes.DeadlineExceeded, reasonDeadlineExceeded, metadata
+
+	case errors.As(err, &upstreamErr):
+		metadata["container_status"] = fmt.Sprintf("%d", upstreamErr.StatusCode)
+		if upstreamErr.StatusCode >= http.StatusBadRequest && upstreamErr.StatusCode < http.StatusInternalServerError {
+			return codes.InvalidArgument, reasonContainerRejected, metadata
+		}
+		return codes.Unavailable, reasonContainerFailed, metadata
+
+	case errors.As(err, &dockerErr):
+		metadata["docker_operation"] = dockerErr.Op
+		switch {
+		case docker.IsResourceExhausted(err):
+			return codes.ResourceExhausted, reasonDockerExhausted, metadata
+		case docker.IsUnavailable(err):
+			return codes.Unavailable, reasonDockerFailed, metadata
+		}
+		return codes.Internal, reasonDockerFailed, metadata
+
+	case errors.As(err, &transitionErr):
+		metadata["container_status"] = transitionErr.From.String()
+		return codes.Unavailable, reasonContainerDown, metadata
+
+	case errors.Is(err, ErrContainerLost),
+		errors.As(err, &urlErr),
+		errors.As(err, &netErr):
+		return codes.Unavailable, reasonContainerDown, metadata
+	}
+
+	return codes.Internal, reasonInternal, metadata
+}
@@ -0,0 +1,169 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	dclient "github.com/docker/docker/client"
+	"github.com/docker/docker/errdefs"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+
+	"github.com/denkoren/mi-labs-test/internal/core"
+	"github.com/denkoren/mi-labs-test/internal/interconnect/docker"
+	"github.com/denkoren/mi-labs-test/internal/interconnect/registry"
+)
+
+func TestClassifyError(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantCode   codes.Code
+		wantReason string
+	}{
+		{
+			name:       "canceled",
+			err:        fmt.Errorf("stopped waiting: %w", context.Canceled),
+			wantCode:   codes.Canceled,
+			wantReason: reasonCanceled,
+		},
+		{
+			name:       "client deadline",
+			err:        fmt.Errorf("stopped waiting: %w", context.DeadlineExceeded),
+			wantCode:   codes.DeadlineExceeded,
+			wantReason: reasonDeadlineExceeded,
+		},
+		{
+			name:       "container wait timeout",
+			err:        fmt.Errorf("failed to wait: %w", ErrContainerWaitTimeout),
+			wantCode:   codes.DeadlineExceeded,
+			wantReason: reasonContainerTimeout,
+		},
+		{
+			name:       "calculation timeout",
+			err:        ErrCalculationTimeout,
+			wantCode:   codes.DeadlineExceeded,
+			wantReason: reasonCalculationHung,
+		},
+		{
+			name:       "invalid page token",
+			err:        ErrInvalidPageToken,
+			wantCode:   codes.InvalidArgument,
+			wantReason: reasonInvalidRequest,
+		},
+		{
+			name:       "input too large",
+			err:        &InputSizeError{Size: 11, Limit: 10},
+			wantCode:   codes.InvalidArgument,
+			wantReason: reasonInputTooLarge,
+		},
+		{
+			name:       "upgrade in progress",
+			err:        ErrUpgradeInProgress,
+			wantCode:   codes.Aborted,
+			wantReason: reasonUpgradeInProgress,
+		},
+		{
+			name:       "sequence unavailable",
+			err:        registry.ErrSequenceUnavailable,
+			wantCode:   codes.OutOfRange,
+			wantReason: reasonSequenceLost,
+		},
+		{
+			name:       "container not found",
+			err:        registry.ErrContainerNotExists,
+			wantCode:   codes.NotFound,
+			wantReason: reasonContainerNotFound,
+		},
+		{
+			name:       "unknown service",
+			err:        core.ErrUnknownService,
+			wantCode:   codes.NotFound,
+			wantReason: reasonUnknownService,
+		},
+		{
+			name:       "draining",
+			err:        registry.ErrContainerDraining,
+			wantCode:   codes.Unavailable,
+			wantReason: reasonContainerDraining,
+		},
+		{
+			name:       "stopped by operator",
+			err:        &registry.TransitionError{From: core.ContainerStatusStopped, To: core.ContainerStatusStopped, Err: ErrContainerStopped},
+			wantCode:   codes.Unavailable,
+			wantReason: reasonContainerStopped,
+		},
+		{
+			name:       "admission",
+			err:        &AdmissionError{ReadyIn: time.Minute, TimeLeft: time.Second},
+			wantCode:   codes.Unavailable,
+			wantReason: reasonNotReadyInTime,
+		},
+		{
+			name:       "container rejected input",
+			err:        &UpstreamError{StatusCode: http.StatusBadRequest},
+			wantCode:   codes.InvalidArgument,
+			wantReason: reasonContainerRejected,
+		},
+		{
+			name:       "container failed",
+			err:        &UpstreamError{StatusCode: http.StatusInternalServerError},
+			wantCode:   codes.Unavailable,
+			wantReason: reasonContainerFailed,
+		},
+		{
+			name:       "docker unreachable",
+			err:        &docker.Error{Op: "start", Err: dclient.ErrorConnectionFailed("unix:///var/run/docker.sock")},
+			wantCode:   codes.Unavailable,
+			wantReason: reasonDockerFailed,
+		},
+		{
+			name:       "docker unavailable",
+			err:        &docker.Error{Op: "start", Err: errdefs.Unavailable(errors.New("daemon is shutting down"))},
+			wantCode:   codes.Unavailable,
+			wantReason: reasonDockerFailed,
+		},
+		{
+			name:       "docker out of disk space",
+			err:        &docker.Error{Op: "create", Err: errdefs.System(errors.New("write /var/lib/docker: no space left on device"))},
+			wantCode:   codes.ResourceExhausted,
+			wantReason: reasonDockerExhausted,
+		},
+		{
+			name:       "docker internal",
+			err:        &docker.Error{Op: "create", Err: errdefs.InvalidParameter(errors.New("invalid mount config"))},
+			wantCode:   codes.Internal,
+			wantReason: reasonDockerFailed,
+		},
+		{
+			name:       "transition",
+			err:        &registry.TransitionError{From: core.ContainerStatusStopped, Err: errors.New("failed")},
+			wantCode:   codes.Unavailable,
+			wantReason: reasonContainerDown,
+		},
+		{
+			name:       "container lost",
+			err:        &CalculationError{Seed: "seed", Err: ErrContainerLost},
+			wantCode:   codes.Unavailable,
+			wantReason: reasonContainerDown,
+		},
+		{
+			name:       "unknown",
+			err:        errors.New("something went wrong"),
+			wantCode:   codes.Internal,
+			wantReason: reasonInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, reason, _ := classifyError(tt.err)
+			assert.Equal(t, tt.wantCode, code)
+			assert.Equal(t, tt.wantReason, reason)
+		})
+	}
+}
@@ -1,12 +1,17 @@
 package api
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"log"
 	"net/http"
+	"net/url"
 	"sync"
+	"time"
 
 	"github.com/denkoren/mi-labs-test/internal/util"
 	"github.com/docker/go-units"
@@ -15,46 +20,79 @@ import (
 const defaultDedupBufferSize = 100 * units.KiB
 
 type responseDuplicator struct {
-	request    *http.Request
+	calc       calculation
 	requestCtx *util.Multicontext
+	cache      *resultCache
 
 	requesters []io.Writer
-	closers    []io.Closer
+	closers    []*io.PipeWriter
 	errors     []chan<- error
 
 	started  bool
 	finished bool
 	lock     sync.Mutex
+
+	// release removes finished request from active ones
+	release func()
 }
 
-func newMultiRequest(method string, url string, body io.Reader) (*responseDuplicator, error) {
-	reqCtx := util.NewMulticontext()
+// calculationTarget is the container, that performs calculations.
+type calculationTarget interface {
+	// addr returns current address of the container with port: host:port.
+	addr() string
+	// prepare makes the container ready and counts the calculation as active until finish is called.
+	prepare(ctx context.Context) (finish func(), err error)
+	// lost is closed when the container stops serving requests: it was stopped or became unreachable.
+	lost(ctx context.Context) <-chan struct{}
+	// recover makes the container serve requests again after its failure at <failedAt> time.
+	recover(ctx context.Context, failedAt time.Time) error
+
+	// timedOut reports calculation timeout, responded reports the container responded in time.
+	timedOut()
+	responded()
+}
 
-	r, err := http.NewRequestWithContext(
-		reqCtx.Ctx(),
-		method,
-		url,
-		body,
-	)
-	if err != nil {
-		return nil, err
-	}
+// calculation describes the request to container.
+// Concurrent calculations with the same key are deduplicated: only one request goes to container.
+type calculation struct {
+	key    string
+	target calculationTarget
+	method string
+	path   string
+	body   []byte
+	detach bool
+	// Result is neither taken from cache nor stored there
+	noCache bool
+	// onResult is called once with the result of successful request, not by every deduplicated reader
+	onResult func(data []byte)
+
+	// Number of times the request is repeated, when the container fails during calculation.
+	retries int
+	// Maximum duration of single request to the container. Zero means no limit.
+	timeout time.Duration
+}
 
+func newMultiRequest(calc calculation, cache *resultCache, linger time.Duration) *responseDuplicator {
 	return &responseDuplicator{
-		request:    r,
-		requestCtx: reqCtx,
+		calc:       calc,
+		requestCtx: util.NewLingeringMulticontext(linger),
+		cache:      cache,
 		requesters: make([]io.Writer, 0, 1),
-		closers:    make([]io.Closer, 0, 1),
+		closers:    make([]*io.PipeWriter, 0, 1),
 		errors:     make([]chan<- error, 0, 1),
-	}, nil
+	}
+}
+
+func (r *responseDuplicator) url() string {
+	return calculationURL(r.calc.target.addr(), r.calc.path)
 }
 
 func (r *responseDuplicator) registerReader(ctx context.Context) (io.ReadCloser, <-chan error, error) {
-	log.Printf("[RMUX] registering new reader for request '%s'", r.request.URL)
+	log.Printf("[RMUX] registering new reader for request '%s'", r.url())
 
 	err := r.requestCtx.AddCtx(ctx)
 	if err != nil {
-		log.Printf("[RMUX] new reader's context registration failed for '%s': %s", r.request.URL, err.Error())
+		log.Printf("[RMUX] new reader's context registration failed for '%s': %s", r.url(), err.Error())
 		return nil, nil, err
 	}
 
@@ -67,6 +105,13 @@ func (r *responseDuplicator) registerReader(ctx context.Context) (io.ReadCloser,
 	return pr, errCh, nil
 }
 
+// detach keeps the request running even when all its readers are gone.
+// The result is stored in cache anyway.
+func (r *responseDuplicator) detach() error {
+	log.Printf("[RMUX] detaching request '%s' from its readers", r.url())
+	return r.requestCtx.Detach()
+}
+
 // Thread-safe
 func (r *responseDuplicator) do() {
 	r.lock.Lock()
@@ -77,49 +122,176 @@ func (r *responseDuplicator) do() {
 	r.started = true
 	r.lock.Unlock()
 
-	var (
-		response *http.Response
-		err      error = nil
-	)
-
-	log.Printf("[RMUX] request to '%s' started", r.request.URL)
+	// New readers get the cached result or make new request, once this one is finished
+	defer r.release()
+	// Release request context resources once the response is written.
+	defer r.requestCtx.Cancel()
 
-	response, err = http.DefaultClient.Do(r.request)
+	// Container is prepared under request context, not the context of the first reader:
+	// detached and lingering requests survive their readers during container start too.
+	finish, err := r.calc.target.prepare(r.requestCtx.Ctx())
 	if err != nil {
-		r.sendError(err)
+		r.fail(err)
 		return
 	}
+	// The calculation is active until its response is written
+	defer finish()
+
+	var response *http.Response
+	for attempt := 0; ; attempt++ {
+		log.Printf("[RMUX] request to '%s' started (attempt %d)", r.url(), attempt+1)
+
+		failedAt := time.Now()
+		response, err = r.send()
+		if err == nil {
+			break
+		}
+
+		if attempt >= r.calc.retries || !r.isContainerFailure(err) {
+			r.fail(err)
+			return
+		}
+
+		log.Printf("[RMUX] container failed during request to '%s': %v. Recovering...", r.url(), err)
+		err = r.calc.target.recover(r.requestCtx.Ctx(), failedAt)
+		if err != nil {
+			r.fail(err)
+			return
+		}
+	}
+	defer response.Body.Close()
+
 	if response.StatusCode != http.StatusOK {
-		err = fmt.Errorf("container responded with code '%d: %s'", response.StatusCode, response.Status)
-		r.sendError(err)
+		r.fail(&UpstreamError{StatusCode: response.StatusCode, Status: response.Status})
 		return
 	}
 
 	r.lock.Lock()
 	defer r.lock.Unlock()
 
-	log.Printf("[RMUX] request to '%s' finished, writing response data...", r.request.URL)
+	log.Printf("[RMUX] request to '%s' finished, writing response data...", r.url())
 
+	// Readers can't join in the middle of response. They wait for the lock and take the result from cache.
 	r.finished = true
 	r.sendError(nil) // Send 'nil' error to clients so they can read response
 
-	mul := io.MultiWriter(r.requesters...)
+	result := &bytes.Buffer{}
+	mul := newFanoutWriter(append(r.requesters, result)...)
 	_, err = io.CopyBuffer(mul, response.Body, make([]byte, defaultDedupBufferSize))
 
-	log.Printf("[RMUX] response from '%s' written. Err: %v", r.request.URL, err)
+	log.Printf("[RMUX] response from '%s' written. Err: %v", r.url(), err)
 
-	log.Printf("[RMUX] closing '%d' response writers for '%s'...", len(r.closers), r.request.URL)
+	if err == nil && !r.calc.noCache {
+		r.cache.put(r.calc.key, result.Bytes())
+	}
+
+	// Readers get the copy error instead of truncated response
+	copyErr := err
+
+	log.Printf("[RMUX] closing '%d' response writers for '%s'...", len(r.closers), r.url())
 	for _, cl := range r.closers {
-		err = cl.Close()
+		err = cl.CloseWithError(copyErr)
 		if err != nil {
-			log.Printf("[RMUX] failed to close '%s' response writer: %s", r.request.URL, err.Error())
+			log.Printf("[RMUX] failed to close '%s' response writer: %s", r.url(), err.Error())
+		}
+	}
+
+	log.Printf("[RMUX] response writers for '%s' closed: %d", r.url(), len(r.closers))
+
+	if copyErr == nil && r.calc.onResult != nil {
+		r.calc.onResult(result.Bytes())
+	}
+}
+
+// send makes single request to the container.
+// The request is canceled when container stops serving requests or calculation timeout expires.
+func (r *responseDuplicator) send() (*http.Response, error) {
+	var (
+		ctx    context.Context
+		cancel context.CancelFunc
+	)
+	if r.calc.timeout > 0 {
+		ctx, cancel = context.WithTimeout(r.requestCtx.Ctx(), r.calc.timeout)
+	} else {
+		ctx, cancel = context.WithCancel(r.requestCtx.Ctx())
+	}
+
+	var body io.Reader
+	if r.calc.body != nil {
+		body = bytes.NewReader(r.calc.body)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, r.calc.method, r.url(), body)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	lost := r.calc.target.lost(ctx)
+	go func() {
+		select {
+		case <-lost:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		cancel()
+
+		select {
+		case <-lost:
+			return nil, fmt.Errorf("%w: %v", ErrContainerLost, err)
+		default:
+		}
+
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			// Calculation context has no deadline, so only calculation timeout could be exceeded.
+			r.calc.target.timedOut()
+			return nil, fmt.Errorf("%w: %v", ErrCalculationTimeout, err)
 		}
+
+		return nil, err
+	}
+
+	r.calc.target.responded()
+
+	// Keep the request context alive until the response body is read.
+	response.Body = &cancelingReadCloser{ReadCloser: response.Body, cancel: cancel}
+	return response, nil
+}
+
+// isContainerFailure reports if the request failed because of container and not because of its input.
+func (r *responseDuplicator) isContainerFailure(err error) bool {
+	if errors.Is(err, ErrContainerLost) {
+		return true
 	}
 
-	log.Printf("[RMUX] response writers for '%s' closed: %d", r.request.URL, len(r.closers))
-	return
+	if errors.Is(err, ErrCalculationTimeout) {
+		// Hung calculations are handled by background watchdog
+		return false
+	}
+
+	if r.requestCtx.Ctx().Err() != nil {
+		// Request was canceled by its clients
+		return false
+	}
+
+	var urlErr *url.Error
+	return errors.As(err, &urlErr)
 }
 
+// fail finishes the request with the error. Readers can't join failed request: they make a new one.
+func (r *responseDuplicator) fail(err error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.finished = true
+	r.sendError(err)
+}
+
+// sendError is called under request lock
 func (r *responseDuplicator) sendError(err error) {
 	for _, ch := range r.errors {
 		ch <- err
@@ -127,76 +299,181 @@ func (r *responseDuplicator) sendError(err error) {
 	}
 }
 
+// fanoutWriter duplicates writes to all its writers like io.MultiWriter does.
+// Unlike io.MultiWriter it does not stop on failed writer: the writer is just excluded from further writes.
+// This allows the rest of readers to get the response when one of them has gone.
+type fanoutWriter struct {
+	writers []io.Writer
+}
+
+func newFanoutWriter(writers ...io.Writer) *fanoutWriter {
+	return &fanoutWriter{
+		writers: append(make([]io.Writer, 0, len(writers)), writers...),
+	}
+}
+
+func (w *fanoutWriter) Write(p []byte) (int, error) {
+	active := w.writers[:0]
+	for _, writer := range w.writers {
+		_, err := writer.Write(p)
+		if err != nil {
+			log.Printf("[RMUX] response writer excluded: %s", err.Error())
+			continue
+		}
+		active = append(active, writer)
+	}
+
+	w.writers = active
+	return len(p), nil
+}
+
 type requestIndex map[string]*responseDuplicator
 
 type responseMux struct {
 	activeRequests requestIndex
+	results        *resultCache
+
+	// Time to keep request running after all its readers are gone.
+	// New reader can join the request during this time.
+	orphanLinger time.Duration
 
 	indexLock sync.Mutex
 }
 
-func newResponseMux() *responseMux {
+func newResponseMux(results *resultCache, orphanLinger time.Duration) *responseMux {
 	return &responseMux{
 		activeRequests: make(requestIndex),
+		results:        results,
+		orphanLinger:   orphanLinger,
 	}
 }
 
 // Thread-safe
-func (m *responseMux) getRequest(ctx context.Context, method, url string) (io.Reader, <-chan error, error) {
+func (m *responseMux) getRequest(ctx context.Context, calc calculation) (io.ReadCloser, <-chan error, error) {
 	m.indexLock.Lock()
 	defer m.indexLock.Unlock()
 
 	var (
 		req    *responseDuplicator
-		reader io.Reader
+		reader io.ReadCloser
 		ok     bool
 		err    error
 		errCh  <-chan error
 	)
 
-	requestIndexID := m.getRequestIndexID(method, url)
-	req, ok = m.activeRequests[requestIndexID]
+	if data, ok := m.cachedFor(calc); ok {
+		return cachedResponse(data)
+	}
+
+	req, ok = m.activeRequests[calc.key]
 	if !ok {
 		// No active request exist. Create new.
-		return m.runNewMultiRequest(ctx, method, url)
+		return m.runNewMultiRequest(ctx, calc)
 	}
 
 	req.lock.Lock()
 	defer req.lock.Unlock()
 
 	if req.finished {
-		// Request already done. We need new one.
-		return m.runNewMultiRequest(ctx, method, url)
+		// Request was done while we waited for it. Its result is cached unless it failed.
+		if data, ok := m.cachedFor(calc); ok {
+			return cachedResponse(data)
+		}
+		return m.runNewMultiRequest(ctx, calc)
 	}
 
 	reader, errCh, err = req.registerReader(ctx)
 	if err == context.Canceled {
 		// Request was canceled. We need new one.
-		return m.runNewMultiRequest(ctx, method, url)
+		return m.runNewMultiRequest(ctx, calc)
+	}
+	if err != nil {
+		return nil, nil, err
 	}
 
-	log.Printf("[RMUX] got new reader for existing request to '%s'", url)
-	return reader, errCh, err
+	if calc.detach {
+		err = req.detach()
+		if err != nil {
+			_ = reader.Close()
+			return nil, nil, err
+		}
+	}
+
+	log.Printf("[RMUX] got new reader for existing request to '%s'", calculationURL(calc.target.addr(), calc.path))
+	return reader, errCh, nil
 }
 
-func (m *responseMux) runNewMultiRequest(ctx context.Context, method, url string) (io.Reader, <-chan error, error) {
-	log.Printf("[RMUX] making new miltirequest for '%s'", url)
+// Thread-safe
+func (m *responseMux) cached(key string) ([]byte, bool) {
+	return m.results.get(key)
+}
+
+func (m *responseMux) cachedFor(calc calculation) ([]byte, bool) {
+	if calc.noCache {
+		return nil, false
+	}
 
-	req, err := newMultiRequest(method, url, nil)
+	data, ok := m.results.get(calc.key)
+	if ok {
+		log.Printf("[RMUX] got cached response for '%s'", calculationURL(calc.target.addr(), calc.path))
+	}
+	return data, ok
+}
+
+func (m *responseMux) runNewMultiRequest(ctx context.Context, calc calculation) (io.ReadCloser, <-chan error, error) {
+	log.Printf("[RMUX] making new miltirequest for '%s'", calculationURL(calc.target.addr(), calc.path))
+
+	req := newMultiRequest(calc, m.results, m.orphanLinger)
+	req.release = func() {
+		m.indexLock.Lock()
+		defer m.indexLock.Unlock()
+
+		if m.activeRequests[calc.key] == req {
+			delete(m.activeRequests, calc.key)
+		}
+	}
+	m.activeRequests[calc.key] = req
+
+	reader, errCh, err := req.registerReader(ctx)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	requestIndexID := m.getRequestIndexID(method, url)
-	m.activeRequests[requestIndexID] = req
+	if calc.detach {
+		err = req.detach()
+		if err != nil {
+			_ = reader.Close()
+			return nil, nil, err
+		}
+	}
 
-	reader, errCh, err := req.registerReader(ctx)
 	go req.do()
 
-	log.Printf("[RMUX] new miltirequest for '%s' created", url)
+	log.Printf("[RMUX] new miltirequest for '%s' created", calculationURL(calc.target.addr(), calc.path))
 	return reader, errCh, err
 }
 
-func (m *responseMux) getRequestIndexID(method, url string) string {
-	return fmt.Sprintf("%s:%s", method, url)
+// cancelingReadCloser cancels request context when response body is closed.
+type cancelingReadCloser struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (c *cancelingReadCloser) Close() error {
+	err := c.ReadCloser.Close()
+	c.cancel()
+	return err
+}
+
+// calculationURL makes URL of container endpoint. Address includes port.
+func calculationURL(addr string, path string) string {
+	return fmt.Sprintf("http://%s%s", addr, path)
+}
+
+func cachedResponse(data []byte) (io.ReadCloser, <-chan error, error) {
+	errCh := make(chan error, 1)
+	errCh <- nil
+	close(errCh)
+
+	return ioutil.NopCloser(bytes.NewReader(data)), errCh, nil
 }
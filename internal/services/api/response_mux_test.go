@@ -0,0 +1,407 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testTarget is the container at the test server address
+type testTarget struct {
+	server *httptest.Server
+}
+
+func (t testTarget) addr() string {
+	return strings.TrimPrefix(t.server.URL, "http://")
+}
+
+func (t testTarget) prepare(context.Context) (func(), error) {
+	return func() {}, nil
+}
+
+func (t testTarget) lost(context.Context) <-chan struct{} {
+	return make(chan struct{})
+}
+
+func (t testTarget) recover(context.Context, time.Time) error {
+	return nil
+}
+
+func (t testTarget) timedOut()  {}
+func (t testTarget) responded() {}
+
+func readResponse(t *testing.T, mux *responseMux, calc calculation) string {
+	reader, errCh, err := mux.getRequest(context.Background(), calc)
+	require.NoError(t, err)
+	defer reader.Close()
+
+	require.NoError(t, <-errCh)
+
+	data, err := ioutil.ReadAll(reader)
+	require.NoError(t, err)
+
+	return string(data)
+}
+
+func TestResponseMux_Cache(t *testing.T) {
+	tests := []struct {
+		name          string
+		cacheSize     int
+		noCache       bool
+		wantUpstreams int32
+	}{
+		{name: "cached", cacheSize: 10, wantUpstreams: 1},
+		{name: "cache disabled", cacheSize: 0, wantUpstreams: 3},
+		{name: "no cache calculation", cacheSize: 10, noCache: true, wantUpstreams: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var upstreams int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				n := atomic.AddInt32(&upstreams, 1)
+				_, _ = fmt.Fprintf(w, "result %d", n)
+			}))
+			defer server.Close()
+
+			mux := newResponseMux(newResultCache(tt.cacheSize, time.Minute), 0)
+			calc := calculation{
+				key:     "key",
+				target:  testTarget{server: server},
+				method:  http.MethodGet,
+				path:    "/calculate/input",
+				noCache: tt.noCache,
+			}
+
+			for i := 0; i < 3; i++ {
+				data := readResponse(t, mux, calc)
+				assert.True(t, strings.HasPrefix(data, "result "), data)
+			}
+
+			assert.Equal(t, tt.wantUpstreams, atomic.LoadInt32(&upstreams))
+
+			// Finished requests are not kept
+			assert.Eventually(t, func() bool {
+				mux.indexLock.Lock()
+				defer mux.indexLock.Unlock()
+				return len(mux.activeRequests) == 0
+			}, time.Second, 10*time.Millisecond)
+		})
+	}
+}
+
+// startingTarget is the test target, that becomes ready once <ready> is closed
+type startingTarget struct {
+	testTarget
+	ready  chan struct{}
+	active int32 // Calculations in progress
+}
+
+func (t *startingTarget) prepare(ctx context.Context) (func(), error) {
+	atomic.AddInt32(&t.active, 1)
+	finish := func() { atomic.AddInt32(&t.active, -1) }
+
+	select {
+	case <-t.ready:
+		return finish, nil
+	case <-ctx.Done():
+		finish()
+		return nil, ctx.Err()
+	}
+}
+
+func TestResponseMux_ClientGone(t *testing.T) {
+	tests := []struct {
+		name        string
+		detach      bool
+		duringStart bool
+		wantCached  bool
+	}{
+		{name: "detached, gone during container start", detach: true, duringStart: true, wantCached: true},
+		{name: "detached, gone during calculation", detach: true, wantCached: true},
+		{name: "gone during container start", duringStart: true},
+		{name: "gone during calculation"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calculated := make(chan struct{})
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				select {
+				case <-calculated:
+					_, _ = fmt.Fprint(w, "result")
+				case <-r.Context().Done():
+				}
+			}))
+			defer server.Close()
+
+			target := &startingTarget{testTarget: testTarget{server: server}, ready: make(chan struct{})}
+			if !tt.duringStart {
+				close(target.ready)
+			}
+
+			cache := newResultCache(10, time.Minute)
+			mux := newResponseMux(cache, 0)
+			calc := calculation{
+				key:    "key",
+				target: target,
+				method: http.MethodGet,
+				path:   "/calculate/input",
+				detach: tt.detach,
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			reader, _, err := mux.getRequest(ctx, calc)
+			require.NoError(t, err)
+
+			// Client leaves and the request outlives it, if it is detached
+			cancel()
+			_ = reader.Close()
+			time.Sleep(50 * time.Millisecond)
+
+			if tt.duringStart {
+				close(target.ready)
+			}
+			close(calculated)
+
+			// The calculation is active until the request is finished
+			assert.Eventually(t, func() bool {
+				mux.indexLock.Lock()
+				defer mux.indexLock.Unlock()
+				return len(mux.activeRequests) == 0
+			}, time.Second, 10*time.Millisecond)
+			assert.Zero(t, atomic.LoadInt32(&target.active))
+
+			data, ok := cache.get(calc.key)
+			assert.Equal(t, tt.wantCached, ok)
+			if tt.wantCached {
+				assert.Equal(t, "result", string(data))
+			}
+		})
+	}
+}
+
+// failingTarget is the test target, that is lost during first <lostAttempts> requests
+type failingTarget struct {
+	testTarget
+	lostAttempts int32
+	recoverErr   error
+
+	attempts   int32
+	recoveries int32
+	timeouts   int32
+}
+
+func (t *failingTarget) lost(context.Context) <-chan struct{} {
+	lost := make(chan struct{})
+	if atomic.AddInt32(&t.attempts, 1) <= t.lostAttempts {
+		close(lost)
+	}
+	return lost
+}
+
+func (t *failingTarget) recover(context.Context, time.Time) error {
+	atomic.AddInt32(&t.recoveries, 1)
+	return t.recoverErr
+}
+
+func (t *failingTarget) timedOut() {
+	atomic.AddInt32(&t.timeouts, 1)
+}
+
+func TestResponseMux_Retries(t *testing.T) {
+	errRecovery := errors.New("recovery failed")
+
+	tests := []struct {
+		name           string
+		status         int
+		hang           bool
+		timeout        time.Duration
+		retries        int
+		lostAttempts   int32
+		recoverErr     error
+		wantErr        error
+		wantStatus     int
+		wantRecoveries int32
+		wantTimeouts   int32
+	}{
+		{name: "no failures", retries: 2},
+		{name: "recovered", retries: 2, lostAttempts: 2, wantRecoveries: 2},
+		{name: "retries exhausted", retries: 1, lostAttempts: 2, wantErr: ErrContainerLost, wantRecoveries: 1},
+		{name: "recovery failed", retries: 2, lostAttempts: 1, recoverErr: errRecovery, wantErr: errRecovery, wantRecoveries: 1},
+		{name: "upstream error is not retried", retries: 2, status: http.StatusBadRequest, wantStatus: http.StatusBadRequest},
+		{name: "timeout is not retried", retries: 2, hang: true, timeout: 50 * time.Millisecond, wantErr: ErrCalculationTimeout, wantTimeouts: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if tt.hang {
+					<-r.Context().Done()
+					return
+				}
+				if tt.status != 0 {
+					w.WriteHeader(tt.status)
+				}
+				_, _ = fmt.Fprint(w, "result")
+			}))
+			defer server.Close()
+
+			target := &failingTarget{testTarget: testTarget{server: server}, lostAttempts: tt.lostAttempts, recoverErr: tt.recoverErr}
+			mux := newResponseMux(newResultCache(10, time.Minute), 0)
+			calc := calculation{
+				key:     "key",
+				target:  target,
+				method:  http.MethodGet,
+				path:    "/calculate/input",
+				retries: tt.retries,
+				timeout: tt.timeout,
+			}
+
+			reader, errCh, err := mux.getRequest(context.Background(), calc)
+			require.NoError(t, err)
+			defer reader.Close()
+
+			err = <-errCh
+			switch {
+			case tt.wantErr != nil:
+				assert.ErrorIs(t, err, tt.wantErr)
+			case tt.wantStatus != 0:
+				var upstreamErr *UpstreamError
+				require.ErrorAs(t, err, &upstreamErr)
+				assert.Equal(t, tt.wantStatus, upstreamErr.StatusCode)
+			default:
+				require.NoError(t, err)
+				data, err := ioutil.ReadAll(reader)
+				require.NoError(t, err)
+				assert.Equal(t, "result", string(data))
+			}
+
+			assert.Equal(t, tt.wantRecoveries, atomic.LoadInt32(&target.recoveries))
+			assert.Equal(t, tt.wantTimeouts, atomic.LoadInt32(&target.timeouts))
+
+			// Failed request is finished: the next reader makes a new request instead of waiting forever
+			assert.Eventually(t, func() bool {
+				mux.indexLock.Lock()
+				defer mux.indexLock.Unlock()
+				return len(mux.activeRequests) == 0
+			}, time.Second, 10*time.Millisecond)
+		})
+	}
+}
+
+func TestResponseMux_ConcurrentReadersOfFailedRequest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	target := &failingTarget{testTarget: testTarget{server: server}, lostAttempts: math.MaxInt32}
+	mux := newResponseMux(newResultCache(10, time.Minute), 0)
+	calc := calculation{key: "key", target: target, method: http.MethodGet, path: "/calculate/input"}
+
+	errs := make(chan error)
+	for i := 0; i < 20; i++ {
+		go func() {
+			reader, errCh, err := mux.getRequest(context.Background(), calc)
+			if err != nil {
+				errs <- err
+				return
+			}
+			defer reader.Close()
+
+			select {
+			case err = <-errCh:
+				errs <- err
+			case <-time.After(time.Second):
+				errs <- errors.New("reader got no error")
+			}
+		}()
+	}
+
+	for i := 0; i < 20; i++ {
+		assert.ErrorIs(t, <-errs, ErrContainerLost)
+	}
+}
+
+func TestResponseMux_OnResult(t *testing.T) {
+	tests := []struct {
+		name      string
+		status    int
+		wantCalls int32
+	}{
+		{name: "succeeded", status: http.StatusOK, wantCalls: 1},
+		{name: "failed", status: http.StatusBadRequest, wantCalls: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				_, _ = fmt.Fprint(w, "result")
+			}))
+			defer server.Close()
+
+			var calls int32
+			target := &startingTarget{testTarget: testTarget{server: server}, ready: make(chan struct{})}
+			mux := newResponseMux(newResultCache(10, time.Minute), 0)
+			calc := calculation{
+				key:    "key",
+				target: target,
+				method: http.MethodGet,
+				path:   "/calculate/input",
+				onResult: func(data []byte) {
+					atomic.AddInt32(&calls, 1)
+					assert.Equal(t, "result", string(data))
+				},
+			}
+
+			// All readers join the same request, while its container is starting
+			type reader struct {
+				data  io.ReadCloser
+				errCh <-chan error
+			}
+			readers := make([]reader, 0, 5)
+			for i := 0; i < 5; i++ {
+				data, errCh, err := mux.getRequest(context.Background(), calc)
+				require.NoError(t, err)
+				readers = append(readers, reader{data: data, errCh: errCh})
+			}
+			close(target.ready)
+
+			// Response is written to all readers at once, so they read it concurrently
+			var wg sync.WaitGroup
+			for _, r := range readers {
+				wg.Add(1)
+				go func(r reader) {
+					defer wg.Done()
+					defer r.data.Close()
+
+					if err := <-r.errCh; err == nil {
+						_, err = ioutil.ReadAll(r.data)
+						assert.NoError(t, err)
+					}
+				}(r)
+			}
+			wg.Wait()
+
+			assert.Eventually(t, func() bool {
+				mux.indexLock.Lock()
+				defer mux.indexLock.Unlock()
+				return len(mux.activeRequests) == 0
+			}, time.Second, 10*time.Millisecond)
+			assert.Equal(t, tt.wantCalls, atomic.LoadInt32(&calls))
+		})
+	}
+}
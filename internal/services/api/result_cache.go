@@ -0,0 +1,96 @@
+package api
+
+import (
+	"sync"
+	"time"
+)
+
+type cachedResult struct {
+	data    []byte
+	created time.Time
+}
+
+// resultCache keeps results of finished calculations.
+// Containers are pure functions of their params and input, so the same request always gets the same result.
+type resultCache struct {
+	size int
+	ttl  time.Duration
+
+	results map[string]cachedResult
+	lock    sync.Mutex
+}
+
+func newResultCache(size int, ttl time.Duration) *resultCache {
+	return &resultCache{
+		size:    size,
+		ttl:     ttl,
+		results: make(map[string]cachedResult, size),
+	}
+}
+
+// Thread-safe
+func (c *resultCache) get(key string) ([]byte, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	result, ok := c.results[key]
+	if !ok {
+		return nil, false
+	}
+
+	if c.isExpired(result) {
+		delete(c.results, key)
+		return nil, false
+	}
+
+	return result.data, true
+}
+
+// Thread-safe
+func (c *resultCache) put(key string, data []byte) {
+	if c.size <= 0 {
+		// Cache is disabled
+		return
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if _, ok := c.results[key]; !ok && len(c.results) >= c.size {
+		c.evict()
+	}
+
+	c.results[key] = cachedResult{
+		data:    data,
+		created: time.Now(),
+	}
+}
+
+// evict removes expired results or the oldest one when nothing is expired.
+// is NOT thread safe
+func (c *resultCache) evict() {
+	var (
+		oldestKey string
+		oldest    time.Time
+	)
+
+	for key, result := range c.results {
+		if c.isExpired(result) {
+			delete(c.results, key)
+			continue
+		}
+
+		if oldest.IsZero() || result.created.Before(oldest) {
+			oldestKey = key
+			oldest = result.created
+		}
+	}
+
+	if len(c.results) >= c.size {
+		delete(c.results, oldestKey)
+	}
+}
+
+func (c *resultCache) isExpired(result cachedResult) bool {
+	return c.ttl > 0 && time.Since(result.created) > c.ttl
+}
@@ -0,0 +1,93 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResultCache_TTL(t *testing.T) {
+	tests := []struct {
+		name string
+		ttl  time.Duration
+		age  time.Duration
+		want bool
+	}{
+		{name: "fresh", ttl: time.Minute, age: time.Second, want: true},
+		{name: "expired", ttl: time.Minute, age: 2 * time.Minute, want: false},
+		{name: "no ttl", ttl: 0, age: 24 * time.Hour, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newResultCache(10, tt.ttl)
+			c.results["key"] = cachedResult{data: []byte("data"), created: time.Now().Add(-tt.age)}
+
+			data, ok := c.get("key")
+			assert.Equal(t, tt.want, ok)
+			if tt.want {
+				assert.Equal(t, []byte("data"), data)
+			} else {
+				assert.NotContains(t, c.results, "key", "expired result is removed")
+			}
+		})
+	}
+}
+
+func TestResultCache_Eviction(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name     string
+		ttl      time.Duration
+		existing map[string]time.Duration // Results by their age
+		put      string
+		want     []string
+	}{
+		{
+			name:     "oldest is evicted",
+			existing: map[string]time.Duration{"a": 3 * time.Second, "b": 2 * time.Second, "c": time.Second},
+			put:      "d",
+			want:     []string{"b", "c", "d"},
+		},
+		{
+			name:     "expired are evicted first",
+			ttl:      time.Minute,
+			existing: map[string]time.Duration{"a": 3 * time.Second, "b": 2 * time.Minute, "c": time.Second},
+			put:      "d",
+			want:     []string{"a", "c", "d"},
+		},
+		{
+			name:     "update does not evict",
+			existing: map[string]time.Duration{"a": 3 * time.Second, "b": 2 * time.Second, "c": time.Second},
+			put:      "a",
+			want:     []string{"a", "b", "c"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newResultCache(3, tt.ttl)
+			for key, age := range tt.existing {
+				c.results[key] = cachedResult{data: []byte(key), created: now.Add(-age)}
+			}
+
+			c.put(tt.put, []byte(tt.put))
+
+			keys := make([]string, 0, len(c.results))
+			for key := range c.results {
+				keys = append(keys, key)
+			}
+			assert.ElementsMatch(t, tt.want, keys)
+		})
+	}
+}
+
+func TestResultCache_Disabled(t *testing.T) {
+	c := newResultCache(0, time.Minute)
+	c.put("key", []byte("data"))
+
+	_, ok := c.get("key")
+	assert.False(t, ok)
+}
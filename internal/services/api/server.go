@@ -2,21 +2,70 @@ package api
 
 import (
 	"context"
+	"crypto/sha256"
 	"errors"
 	"fmt"
 	"io/ioutil"
 	"log"
 	"net/http"
+	"net/url"
+	"strconv"
 	"time"
 
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
 	"github.com/denkoren/mi-labs-test/internal/core"
 	"github.com/denkoren/mi-labs-test/internal/interconnect/docker"
 	"github.com/denkoren/mi-labs-test/internal/interconnect/registry"
 	apipb "github.com/denkoren/mi-labs-test/proto/api/v1"
 )
 
+// Response metadata keys of Calculate calls, that waited for container readiness
+const (
+	metadataContainerID       = "x-container-id"
+	metadataContainerStatus   = "x-container-status"
+	metadataContainerProgress = "x-container-progress"
+	metadataContainerReadyIn  = "x-container-ready-in"
+	metadataImageDigest       = "x-image-digest"
+)
+
+// Request metadata key, that marks present input body. Empty bytes are not sent in gRPC messages,
+// so empty body of POST request is told apart from GET request by this key only. Is set by REST gateway.
+const metadataInputBody = "x-input-body"
+
 type Config struct {
+	Services *core.Services
+	// Current images of services, resolved at startup. Images of the rest services are resolved on first request.
+	ServiceImages map[string]string
+
+	// Defaults for services without own timeouts
 	ContainerWaitTimeout time.Duration
+
+	// Number of times the calculation is repeated, when its container fails during calculation.
+	CalculationRetries int
+	// Maximum duration of single calculation in container. Zero means no limit.
+	CalculationTimeout time.Duration
+	// Maximum size of calculation input in bytes for services without own limit. Zero means no limit.
+	MaxInputSize int64
+
+	// Start containers of calculations, rejected because their containers could not be ready before client's
+	// deadline. The client is able to retry the calculation later, when container is ready.
+	WarmRejectedContainers bool
+
+	// Time to keep calculation running after its last client has gone,
+	// so the client could reconnect and get the result of the same calculation.
+	OrphanedRequestLinger time.Duration
+
+	ResultCacheSize int // Maximum number of results kept in cache. Zero disables caching.
+	ResultCacheTTL  time.Duration
+
+	// Fraction of calculation results recalculated by restarted or new container to detect nondeterminism.
+	// Zero disables verification.
+	VerificationSampleRate float64
+	VerificationInterval   time.Duration
+	// Sampled result waits for container restart up to this time, then it is dropped.
+	VerificationMaxAge time.Duration
 }
 
 type Server struct {
@@ -27,84 +76,328 @@ type Server struct {
 	registry  *registry.ContainerRegistry
 	docker    *docker.Manager
 	requester *responseMux
+	images    *serviceImages
+	shadows   *shadows
+	verifier  *verifier
 }
 
 func NewServer(config Config, reg *registry.ContainerRegistry, dock *docker.Manager) (*Server, error) {
+	results := newResultCache(config.ResultCacheSize, config.ResultCacheTTL)
+
+	images := newServiceImages()
+	for service, digest := range config.ServiceImages {
+		images.set(service, digest)
+	}
+
 	return &Server{
 		config: config,
 
 		registry:  reg,
 		docker:    dock,
-		requester: newResponseMux(),
+		requester: newResponseMux(results, config.OrphanedRequestLinger),
+		images:    images,
+		shadows:   newShadows(),
+		verifier:  newVerifier(config.VerificationSampleRate, config.VerificationMaxAge),
 	}, nil
 }
 
 func (s *Server) Calculate(ctx context.Context, request *apipb.Calculate_Request) (*apipb.Calculate_Response, error) {
-	container, err := s.registry.ExistingOrNewByParams(core.ContainerParams{
-		Seed: request.GetParams().Seed,
-	})
+	if request.InputBody == nil && hasInputBody(ctx) {
+		request.InputBody = []byte{}
+	}
+
+	response, err := s.calculate(ctx, request)
 	if err != nil {
-		return nil, fmt.Errorf("failed to register new container: %v", err)
+		log.Printf("[API] calculation failed: %v", err)
+		return nil, statusError(err)
 	}
 
-	go s.refreshContainerLastUsed(ctx, container)
+	return response, nil
+}
+
+// hasInputBody checks if the request was sent with input body, even the empty one
+func hasInputBody(ctx context.Context) bool {
+	md, ok := metadata.FromIncomingContext(ctx)
+	return ok && len(md.Get(metadataInputBody)) != 0
+}
 
-	err = s.createContainer(ctx, container)
+func (s *Server) calculate(ctx context.Context, request *apipb.Calculate_Request) (*apipb.Calculate_Response, error) {
+	service, params, err := s.containerParams(
+		ctx,
+		request.GetParams().GetService(),
+		request.GetParams().GetImageVersion(),
+		request.GetParams().GetSeed(),
+		request.GetParams().GetValues(),
+	)
 	if err != nil {
 		return nil, err
 	}
 
-	err = s.startContainer(ctx, container)
+	err = s.checkInputSize(service, request)
 	if err != nil {
 		return nil, err
 	}
 
-	err = s.waitForContainer(ctx, container)
+	// Fails when there is no client. We have nothing to do with it.
+	_ = grpc.SetHeader(ctx, metadata.Pairs(metadataImageDigest, params.ImageDigest))
+
+	// Cached result needs neither container nor its admission
+	if data, ok := s.requester.cached(requestKey(params, request)); ok {
+		log.Printf("[API] got cached result for seed '%s'", params.Seed)
+		return &apipb.Calculate_Response{
+			Data:        data,
+			ImageDigest: params.ImageDigest,
+		}, nil
+	}
+
+	container, err := s.registry.ExistingOrNewByParams(params)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("failed to register new container: %v", err)
 	}
 
-	url := fmt.Sprintf("http://%s:8080/calculate/%s", container.Addr, request.Params.Input)
-	log.Printf("[API] starting request to '%s'", url)
-	reader, errCh, err := s.requester.getRequest(
-		ctx,
-		http.MethodGet,
-		url,
-	)
+	err = s.admit(ctx, container)
+	if err != nil {
+		if s.config.WarmRejectedContainers {
+			go s.warmContainer(container)
+		}
+		return nil, newCalculationError(container, err)
+	}
 
+	calc := s.newCalculation(service, container, request)
+	// Deduplicated calculation is sampled once, by the request to container
+	calc.onResult = func(data []byte) {
+		s.shadowCalculation(service, params, request, data)
+		s.verifier.sample(service, container, calc, request, data)
+	}
+
+	data, err := s.calculateIn(ctx, container, calc)
 	if err != nil {
 		return nil, err
 	}
 
-	log.Printf("[API] got reader and err chan for '%s'", url)
-	err = <-errCh
+	// FIXME: is the data huge? Prefer stream here.
+	return &apipb.Calculate_Response{
+		Data:        data,
+		ImageDigest: params.ImageDigest,
+	}, nil
+}
+
+// calculateIn performs the calculation in the container, that is started if needed.
+// The container is prepared by the calculation request itself, so it is started even when the client
+// of detached calculation leaves.
+func (s *Server) calculateIn(ctx context.Context, container *registry.ContainerInfo, calc calculation) ([]byte, error) {
+	if container.Snapshot().Status != core.ContainerStatusReady {
+		s.reportReadiness(ctx, container)
+	}
+
+	log.Printf("[API] starting request to '%s'", calc.path)
+	reader, errCh, err := s.requester.getRequest(ctx, calc)
+
 	if err != nil {
-		return nil, err
+		return nil, newCalculationError(container, err)
+	}
+	defer reader.Close()
+
+	log.Printf("[API] got reader and err chan for '%s'", calc.path)
+	select {
+	case err = <-errCh:
+		if err != nil {
+			return nil, newCalculationError(container, err)
+		}
+	case <-ctx.Done():
+		// Client has gone. Close the reader to let the response be written to the rest of clients.
+		log.Printf("[API] client stopped waiting for response from '%s': %v", calc.path, ctx.Err())
+		return nil, newCalculationError(container, ctx.Err())
 	}
 
-	log.Printf("[API] got response from '%s', reading data...", url)
+	log.Printf("[API] got response from '%s', reading data...", calc.path)
 	data, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return nil, newCalculationError(container, err)
+	}
 
-	// FIXME: is the data huge? Prefer stream here.
-	return &apipb.Calculate_Response{Data: data}, err
+	return data, nil
+}
+
+// newCalculation makes request to container from API request.
+// Input from request body is sent to container in body too, otherwise it is passed in URL path.
+func (s *Server) newCalculation(service core.Service, container *registry.ContainerInfo, request *apipb.Calculate_Request) calculation {
+	target := containerTarget{server: s, container: container, port: service.Port}
+
+	if body := request.GetInputBody(); body != nil {
+		return calculation{
+			key:     requestKey(container.Params, request),
+			target:  target,
+			method:  http.MethodPost,
+			path:    service.CalculatePath,
+			body:    body,
+			detach:  request.GetDetach(),
+			retries: s.config.CalculationRetries,
+			timeout: s.calculationTimeout(service),
+		}
+	}
+
+	input := request.GetParams().GetInput()
+	return calculation{
+		key:     requestKey(container.Params, request),
+		target:  target,
+		method:  http.MethodGet,
+		path:    service.CalculatePath + "/" + url.PathEscape(input),
+		detach:  request.GetDetach(),
+		retries: s.config.CalculationRetries,
+		timeout: s.calculationTimeout(service),
+	}
+}
+
+// requestKey identifies result of API request calculation
+func requestKey(params core.ContainerParams, request *apipb.Calculate_Request) string {
+	if body := request.GetInputBody(); body != nil {
+		return calculationKey(params, "body", body)
+	}
+
+	return calculationKey(params, "path", []byte(request.GetParams().GetInput()))
+}
+
+// calculationKey identifies calculation result regardless of container address
+func calculationKey(params core.ContainerParams, inputKind string, input []byte) string {
+	return fmt.Sprintf("%s:%s:%x", params.Key(), inputKind, sha256.Sum256(input))
+}
+
+// admit rejects calculation early, when its container can't be ready before client's deadline.
+func (s *Server) admit(ctx context.Context, container *registry.ContainerInfo) error {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return nil
+	}
+
+	readyIn, ok := s.containerReadyIn(container.Snapshot())
+	if !ok || readyIn == 0 {
+		// Nothing is known about container startup yet or container is ready
+		return nil
+	}
+
+	timeLeft := time.Until(deadline)
+	if readyIn <= timeLeft {
+		return nil
+	}
+
+	return &AdmissionError{
+		ReadyIn:  readyIn,
+		TimeLeft: timeLeft,
+	}
+}
+
+// containerReadyIn estimates time left until the container becomes ready.
+// Initialization progress reported by container is preferred over the history of previous startups.
+func (s *Server) containerReadyIn(container core.ContainerInfo) (time.Duration, bool) {
+	if container.Status == core.ContainerStatusReady {
+		return 0, true
+	}
+
+	if container.Status.IsActive() && container.Progress > 0 && container.Progress < 100 {
+		elapsed := time.Since(container.Scheduled)
+		return elapsed * time.Duration(100-container.Progress) / time.Duration(container.Progress), true
+	}
+
+	estimate, ok := s.registry.StartupEstimate(container.Params)
+	if !ok {
+		return 0, false
+	}
+
+	if container.Status.IsActive() {
+		// Container is already starting
+		estimate -= time.Since(container.Scheduled)
+		if estimate < 0 {
+			estimate = 0
+		}
+	}
+
+	return estimate, true
+}
+
+// reportReadiness sends expected container readiness time to the client in response headers.
+// Headers are sent right away, before waiting for the container, so no headers can be set after it.
+func (s *Server) reportReadiness(ctx context.Context, container *registry.ContainerInfo) {
+	info := container.Snapshot()
+
+	md := metadata.Pairs(
+		metadataContainerID, info.ID,
+		metadataContainerStatus, info.Status.String(),
+		metadataContainerProgress, strconv.Itoa(info.Progress),
+	)
+	if readyIn, ok := s.containerReadyIn(info); ok {
+		md.Set(metadataContainerReadyIn, readyIn.Round(time.Second).String())
+	}
+
+	// Fails when there is no client, e.g. for container warm up. We have nothing to do with it.
+	_ = grpc.SendHeader(ctx, md)
+}
+
+// backgroundContext limits calculation, that is performed in background without client
+func (s *Server) backgroundContext(service core.Service, params core.ContainerParams) (context.Context, context.CancelFunc) {
+	timeout := s.calculationTimeout(service)
+	if timeout == 0 {
+		return context.WithCancel(context.Background())
+	}
+
+	return context.WithTimeout(context.Background(), s.containerWaitTimeout(params)+timeout)
+}
+
+// warmContainer starts the container without any calculation, so it is ready for the next client's request.
+func (s *Server) warmContainer(container *registry.ContainerInfo) {
+	ctx, cancel := context.WithTimeout(context.Background(), s.containerWaitTimeout(container.Params))
+	defer cancel()
+
+	log.Printf("[API] warming container for seed '%s'", container.Params.Seed)
+
+	err := s.prepareContainer(ctx, container)
+	if err != nil {
+		log.Printf("[API] failed to warm container for seed '%s': %v", container.Params.Seed, err)
+		return
+	}
+
+	// Give the client a chance to come back before the container is stopped as inactive
+	container.UpdateLastUsed()
+}
+
+// prepareContainer makes the container ready for calculations: creates and starts it if needed.
+func (s *Server) prepareContainer(ctx context.Context, container *registry.ContainerInfo) error {
+	err := s.createContainer(ctx, container)
+	if err != nil {
+		return err
+	}
+
+	err = s.startContainer(ctx, container)
+	if err != nil {
+		return err
+	}
+
+	return s.waitForContainer(ctx, container)
 }
 
 func (s *Server) createContainer(ctx context.Context, container *registry.ContainerInfo) error {
+	if container.Snapshot().Status.WasCreated() {
+		// Nothing to do. Transition would be rejected anyway.
+		return nil
+	}
+
 	log.Printf("[API] creating container for seed '%s'", container.Params.Seed)
 
 	err := container.ToCreated(
+		registry.By(actorOf(ctx)),
 		func(c *registry.ContainerInfo, _ core.ContainerStatus) error {
 			if c.Status != core.ContainerStatusNew {
 				// Container was already created, nothing to do
 				return nil
 			}
 
-			id, err := s.docker.CreateContainer(ctx, container.Params)
+			id, resources, err := s.docker.CreateContainer(ctx, container.Params)
 			if err != nil {
 				return err
 			}
 
 			container.ID = id
+			container.Resources = resources
 			return nil
 		},
 		logTransition,
@@ -117,37 +410,67 @@ func (s *Server) createContainer(ctx context.Context, container *registry.Contai
 		}
 	}
 
-	_ = container.ToFailed(logTransition)
+	if err != nil {
+		_ = container.ToFailed(registry.By(actorOf(ctx)), logTransition)
+	}
 	return err
 }
 
 func (s *Server) startContainer(ctx context.Context, container *registry.ContainerInfo) error {
-	log.Printf("[API] starting container '%s'", container.ID)
+	info := container.Snapshot()
+	if info.Status.IsActive() {
+		// Container already was started
+		return nil
+	}
+
+	log.Printf("[API] starting container '%s'", info.ID)
 
 	err := container.ToStarting(
+		registry.By(actorOf(ctx)),
 		func(c *registry.ContainerInfo, _ core.ContainerStatus) error {
-			if container.Status.IsActive() {
+			if c.Status.IsActive() {
 				// container already was started
 				return nil
 			}
 
-			if !container.Status.IsStartable() {
+			if !c.Status.IsStartable() {
 				// We can't start this container
-				return fmt.Errorf("can't start container in status %s", container.Status)
+				return fmt.Errorf("can't start container in status %s", c.Status)
 			}
 
-			addr, err := s.docker.StartContainer(ctx, container.ID)
+			addr, err := s.docker.StartContainer(ctx, c.ID)
+			if docker.IsNotFound(err) {
+				// Container was removed from Docker. Create it again.
+				log.Printf("[API] container '%s' was removed, recreating...", c.ID)
+				var (
+					id        string
+					resources core.Resources
+				)
+				id, resources, err = s.docker.CreateContainer(ctx, c.Params)
+				if err != nil {
+					return err
+				}
+				c.ID = id
+				c.Resources = resources
+				addr, err = s.docker.StartContainer(ctx, c.ID)
+			}
 			if err != nil {
 				return err
 			}
-			container.Addr = addr
+			c.Addr = addr
+			c.Resources.CPUSet = s.docker.CPUSet(c.ID)
 			return nil
 		},
 		logTransition,
 	)
 
+	started := container.Snapshot()
+	if started.ID != info.ID {
+		s.registry.ReplaceID(info.ID, container)
+	}
+
 	if errors.Is(err, registry.ErrTransitionNotAllowed) {
-		if container.Status.IsActive() {
+		if started.Status.IsActive() {
 			// Another thread already started container
 			return nil
 		}
@@ -162,25 +485,32 @@ func (s *Server) waitForContainer(ctx context.Context, container *registry.Conta
 
 	log.Printf("[API] waiting for container '%s' start", container.ID)
 
-	if container.Status == core.ContainerStatusReady {
+	if subscription.Status == core.ContainerStatusReady {
 		// Container ready for work
-		// We need to check this before reading the channel to make sure we did not miss the
+		// We need to check the status got with subscription to make sure we did not miss the
 		// event while subscribing
 		return nil
 	}
 
-	waitCtx, cancel := context.WithTimeout(ctx, s.config.ContainerWaitTimeout)
+	waitCtx, cancel := context.WithTimeout(ctx, s.containerWaitTimeout(container.Params))
 	defer cancel()
 
 	for {
 		select {
-		case <-subscription.C:
-			if container.Status == core.ContainerStatusReady {
+		case event, ok := <-subscription.C:
+			if !ok {
+				return fmt.Errorf("stopped waiting for container '%s' start: %w", container.ID, subscription.Err())
+			}
+			if event.Status == core.ContainerStatusReady {
 				// Container ready for work
 				return nil
 			}
 		case <-waitCtx.Done():
-			return fmt.Errorf("failed to wait for container '%s' start: %w", container.ID, waitCtx.Err())
+			if ctx.Err() != nil {
+				// Client has gone or its own deadline exceeded
+				return fmt.Errorf("stopped waiting for container '%s' start: %w", container.ID, ctx.Err())
+			}
+			return fmt.Errorf("failed to wait for container '%s' start: %w", container.ID, ErrContainerWaitTimeout)
 		}
 	}
 }
@@ -214,3 +544,19 @@ func logTransition(c *registry.ContainerInfo, newStatus core.ContainerStatus) er
 	log.Printf("[API] container '%s' transitioned from '%s' to '%s'", c.ID, c.Status.String(), newStatus.String())
 	return nil
 }
+
+type actorKey struct{}
+
+// withActor marks container transitions performed within the context by the actor.
+func withActor(ctx context.Context, actor registry.Actor) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// actorOf returns actor of container transitions performed within the context. API is the default actor.
+func actorOf(ctx context.Context) registry.Actor {
+	if actor, ok := ctx.Value(actorKey{}).(registry.Actor); ok {
+		return actor
+	}
+
+	return registry.ActorAPI
+}
@@ -0,0 +1,231 @@
+package api
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/denkoren/mi-labs-test/internal/core"
+	apipb "github.com/denkoren/mi-labs-test/proto/api/v1"
+)
+
+func TestRequestKey(t *testing.T) {
+	params := core.ContainerParams{Service: "compute", ImageDigest: "sha256:0123", Seed: "42"}
+	byInput := func(input string) *apipb.Calculate_Request {
+		return &apipb.Calculate_Request{Params: &apipb.Container_Params{Input: input}}
+	}
+	byBody := func(body []byte) *apipb.Calculate_Request {
+		return &apipb.Calculate_Request{InputBody: body}
+	}
+
+	tests := []struct {
+		name  string
+		a     *apipb.Calculate_Request
+		b     *apipb.Calculate_Request
+		equal bool
+	}{
+		{name: "same input", a: byInput("ACGT"), b: byInput("ACGT"), equal: true},
+		{name: "same body", a: byBody([]byte("ACGT")), b: byBody([]byte("ACGT")), equal: true},
+		{name: "another input", a: byInput("ACGT"), b: byInput("ACGA")},
+		{name: "input and body", a: byInput("ACGT"), b: byBody([]byte("ACGT"))},
+		{name: "empty input and empty body", a: byInput(""), b: byBody([]byte{})},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.equal {
+				assert.Equal(t, requestKey(params, tt.a), requestKey(params, tt.b))
+			} else {
+				assert.NotEqual(t, requestKey(params, tt.a), requestKey(params, tt.b))
+			}
+		})
+	}
+}
+
+func TestHasInputBody(t *testing.T) {
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want bool
+	}{
+		{name: "no metadata", ctx: context.Background()},
+		{name: "other metadata", ctx: metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-request-id", "1"))},
+		{name: "input body", ctx: metadata.NewIncomingContext(context.Background(), metadata.Pairs(metadataInputBody, "true")), want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, hasInputBody(tt.ctx))
+		})
+	}
+}
+
+// testStartupDuration is recorded to the registry by newStartedTestServer as the only previous container startup.
+const testStartupDuration = 10 * time.Second
+
+// newStartedTestServer creates API server, which registry remembers a single container startup.
+func newStartedTestServer(t *testing.T, infos ...core.ContainerInfo) *Server {
+	infos = append(infos, core.ContainerInfo{
+		Params:    core.ContainerParams{Seed: "started"},
+		Status:    core.ContainerStatusRunning,
+		Scheduled: time.Now().Add(-testStartupDuration),
+	})
+	s := newTestServer(t, infos...)
+
+	started, err := s.registry.PeekByID("started")
+	require.NoError(t, err)
+	require.NoError(t, started.ToReady())
+
+	return s
+}
+
+func TestServer_ContainerReadyIn(t *testing.T) {
+	s := newStartedTestServer(t)
+	scheduledAgo := func(d time.Duration) time.Time { return time.Now().Add(-d) }
+
+	tests := []struct {
+		name      string
+		server    *Server
+		container core.ContainerInfo
+		want      time.Duration
+		wantOK    bool
+	}{
+		{name: "ready", server: s, container: core.ContainerInfo{Status: core.ContainerStatusReady}, wantOK: true},
+		{name: "not started", server: s, container: core.ContainerInfo{Status: core.ContainerStatusCreated}, want: testStartupDuration, wantOK: true},
+		{
+			name:      "starting",
+			server:    s,
+			container: core.ContainerInfo{Status: core.ContainerStatusStarting, Scheduled: scheduledAgo(4 * time.Second)},
+			want:      6 * time.Second,
+			wantOK:    true,
+		},
+		{
+			name:      "starting longer than usual",
+			server:    s,
+			container: core.ContainerInfo{Status: core.ContainerStatusRunning, Scheduled: scheduledAgo(time.Minute)},
+			wantOK:    true,
+		},
+		{
+			name:      "progress is preferred over history",
+			server:    s,
+			container: core.ContainerInfo{Status: core.ContainerStatusRunning, Scheduled: scheduledAgo(time.Minute), Progress: 75},
+			want:      20 * time.Second,
+			wantOK:    true,
+		},
+		{
+			name:      "progress of stopped container is ignored",
+			server:    s,
+			container: core.ContainerInfo{Status: core.ContainerStatusStopped, Scheduled: scheduledAgo(time.Minute), Progress: 75},
+			want:      testStartupDuration,
+			wantOK:    true,
+		},
+		{name: "no history", server: newTestServer(t), container: core.ContainerInfo{Status: core.ContainerStatusCreated}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.server.containerReadyIn(tt.container)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.InDelta(t, tt.want, got, float64(time.Second))
+		})
+	}
+}
+
+func TestServer_Admit(t *testing.T) {
+	s := newStartedTestServer(t,
+		core.ContainerInfo{Params: core.ContainerParams{Seed: "created"}, Status: core.ContainerStatusCreated},
+		core.ContainerInfo{Params: core.ContainerParams{Seed: "ready"}, Status: core.ContainerStatusReady},
+	)
+	withTimeout := func(timeout time.Duration) context.Context {
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		t.Cleanup(cancel)
+		return ctx
+	}
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		seed     string
+		rejected bool
+	}{
+		{name: "no deadline", ctx: context.Background(), seed: "created"},
+		{name: "enough time", ctx: withTimeout(time.Minute), seed: "created"},
+		{name: "not enough time", ctx: withTimeout(time.Second), seed: "created", rejected: true},
+		{name: "ready container", ctx: withTimeout(time.Second), seed: "ready"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			container, err := s.registry.PeekByID(tt.seed)
+			require.NoError(t, err)
+
+			err = s.admit(tt.ctx, container)
+			if !tt.rejected {
+				assert.NoError(t, err)
+				return
+			}
+
+			var admissionErr *AdmissionError
+			require.ErrorAs(t, err, &admissionErr)
+			assert.InDelta(t, testStartupDuration, admissionErr.ReadyIn, float64(time.Second))
+			assert.InDelta(t, time.Second, admissionErr.TimeLeft, float64(time.Second))
+		})
+	}
+}
+
+// headerStream records headers sent by the server method
+type headerStream struct {
+	grpc.ServerTransportStream
+
+	header metadata.MD
+	sent   []metadata.MD
+}
+
+func (s *headerStream) SetHeader(md metadata.MD) error {
+	s.header = metadata.Join(s.header, md)
+	return nil
+}
+
+func (s *headerStream) SendHeader(md metadata.MD) error {
+	s.sent = append(s.sent, metadata.Join(s.header, md))
+	return nil
+}
+
+func TestServer_ReportReadiness(t *testing.T) {
+	s := newStartedTestServer(t, core.ContainerInfo{
+		Params:    core.ContainerParams{Seed: "starting"},
+		Status:    core.ContainerStatusRunning,
+		Scheduled: time.Now().Add(-5 * time.Second),
+		Progress:  50,
+	})
+	container, err := s.registry.PeekByID("starting")
+	require.NoError(t, err)
+
+	stream := &headerStream{}
+	ctx := grpc.NewContextWithServerTransportStream(context.Background(), stream)
+	require.NoError(t, grpc.SetHeader(ctx, metadata.Pairs(metadataImageDigest, testImageDigest)))
+
+	s.reportReadiness(ctx, container)
+
+	// Headers are not buffered until the response, which comes when the container is ready
+	require.Len(t, stream.sent, 1)
+	assert.Equal(t, []string{testImageDigest}, stream.sent[0].Get(metadataImageDigest))
+	assert.Equal(t, []string{"starting"}, stream.sent[0].Get(metadataContainerID))
+	assert.Equal(t, []string{core.ContainerStatusRunning.String()}, stream.sent[0].Get(metadataContainerStatus))
+	assert.Equal(t, []string{"50"}, stream.sent[0].Get(metadataContainerProgress))
+	assert.Equal(t, []string{"5s"}, stream.sent[0].Get(metadataContainerReadyIn))
+}
+
+func TestServer_ReportReadinessWithoutClient(t *testing.T) {
+	s := newTestServer(t, core.ContainerInfo{Params: core.ContainerParams{Seed: "created"}, Status: core.ContainerStatusCreated})
+	container, err := s.registry.PeekByID("created")
+	require.NoError(t, err)
+
+	// Container warm up has no client to send headers to
+	assert.NotPanics(t, func() { s.reportReadiness(context.Background(), container) })
+}
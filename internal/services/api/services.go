@@ -0,0 +1,203 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/denkoren/mi-labs-test/internal/core"
+	"github.com/denkoren/mi-labs-test/internal/interconnect/docker"
+	apipb "github.com/denkoren/mi-labs-test/proto/api/v1"
+)
+
+// containerParams makes canonical container params: service name and image version are resolved,
+// empty values are dropped, seed is passed separately only.
+func (s *Server) containerParams(
+	ctx context.Context,
+	serviceName string,
+	imageVersion string,
+	seed string,
+	values map[string]string,
+) (core.Service, core.ContainerParams, error) {
+	service, err := s.config.Services.Get(serviceName)
+	if err != nil {
+		return core.Service{}, core.ContainerParams{}, err
+	}
+
+	digest, err := s.serviceImage(ctx, service, imageVersion)
+	if err != nil {
+		return core.Service{}, core.ContainerParams{}, err
+	}
+
+	params := core.ContainerParams{
+		Service:     service.Name,
+		ImageDigest: digest,
+		Seed:        seed,
+	}
+
+	declared, err := declaredParams(service, values)
+	if err != nil {
+		return core.Service{}, core.ContainerParams{}, err
+	}
+
+	for name, value := range values {
+		if value == "" || name == core.ParamSeed {
+			continue
+		}
+		if !declared[name] {
+			// Every distinct value makes a separate container: unused params would start them for nothing
+			return core.Service{}, core.ContainerParams{}, fmt.Errorf(
+				"%w: param '%s' is not used by service '%s'", ErrInvalidArgument, name, service.Name)
+		}
+
+		if params.Values == nil {
+			params.Values = make(map[string]string, len(values))
+		}
+		params.Values[name] = value
+	}
+
+	return service, params, nil
+}
+
+// declaredParams returns names of params used by the service params template.
+// Template is not parsed, when there are no values besides seed.
+func declaredParams(service core.Service, values map[string]string) (map[string]bool, error) {
+	for name, value := range values {
+		if value != "" && name != core.ParamSeed {
+			return docker.TemplateParams(service.ParamsTemplate)
+		}
+	}
+
+	return nil, nil
+}
+
+// serviceName returns name of container service. Containers of older versions have no service name.
+func (s *Server) serviceName(params core.ContainerParams) string {
+	service, err := s.config.Services.Get(params.Service)
+	if err != nil {
+		// Service was removed from configuration
+		return params.Service
+	}
+
+	return service.Name
+}
+
+func (s *Server) containerWaitTimeout(params core.ContainerParams) time.Duration {
+	service, err := s.config.Services.Get(params.Service)
+	if err != nil || service.ContainerWaitTimeout == 0 {
+		return s.config.ContainerWaitTimeout
+	}
+
+	return service.ContainerWaitTimeout
+}
+
+func (s *Server) calculationTimeout(service core.Service) time.Duration {
+	if service.CalculationTimeout == 0 {
+		return s.config.CalculationTimeout
+	}
+
+	return service.CalculationTimeout
+}
+
+// checkInputSize rejects calculation input exceeding the service limit
+func (s *Server) checkInputSize(service core.Service, request *apipb.Calculate_Request) error {
+	limit := service.MaxInputSize
+	if limit == 0 {
+		limit = s.config.MaxInputSize
+	}
+
+	size := len(request.GetParams().GetInput())
+	if body := request.GetInputBody(); body != nil {
+		size = len(body)
+	}
+
+	if limit != 0 && int64(size) > limit {
+		return &InputSizeError{Size: int64(size), Limit: limit}
+	}
+
+	return nil
+}
+
+// serviceImage resolves image version of the service. Current service image is used when version is empty.
+func (s *Server) serviceImage(ctx context.Context, service core.Service, version string) (string, error) {
+	if version != "" {
+		return s.docker.ResolveImage(ctx, service, version)
+	}
+
+	if digest, ok := s.images.current(service.Name); ok {
+		return digest, nil
+	}
+
+	digest, err := s.docker.ResolveImage(ctx, service, "")
+	if err != nil {
+		return "", err
+	}
+
+	return s.images.init(service.Name, digest), nil
+}
+
+// serviceImages keeps current image of each service. Calculations without image version go to it.
+// The image is resolved once and is changed by upgrades only, so retagging of the image in Docker
+// does not switch service to another version unexpectedly.
+type serviceImages struct {
+	digests   map[string]string
+	upgrading map[string]bool
+
+	lock sync.Mutex
+}
+
+func newServiceImages() *serviceImages {
+	return &serviceImages{
+		digests:   make(map[string]string),
+		upgrading: make(map[string]bool),
+	}
+}
+
+func (i *serviceImages) current(service string) (string, bool) {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	digest, ok := i.digests[service]
+	return digest, ok
+}
+
+// init sets the current image of service, if there is no one yet. Returns the current image.
+func (i *serviceImages) init(service string, digest string) string {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	if current, ok := i.digests[service]; ok {
+		return current
+	}
+
+	i.digests[service] = digest
+	return digest
+}
+
+func (i *serviceImages) set(service string, digest string) {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	i.digests[service] = digest
+}
+
+// startUpgrade prevents parallel upgrades of the same service
+func (i *serviceImages) startUpgrade(service string) error {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	if i.upgrading[service] {
+		return fmt.Errorf("service '%s': %w", service, ErrUpgradeInProgress)
+	}
+
+	i.upgrading[service] = true
+	return nil
+}
+
+func (i *serviceImages) finishUpgrade(service string) {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	delete(i.upgrading, service)
+}
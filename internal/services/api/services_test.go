@@ -0,0 +1,82 @@
+package api
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/denkoren/mi-labs-test/internal/core"
+	apipb "github.com/denkoren/mi-labs-test/proto/api/v1"
+)
+
+func TestServer_ContainerParams(t *testing.T) {
+	s := newTestServer(t)
+
+	tests := []struct {
+		name    string
+		values  map[string]string
+		want    map[string]string
+		wantErr error
+	}{
+		{name: "seed only"},
+		{name: "declared param", values: map[string]string{"genome": "hg38"}, want: map[string]string{"genome": "hg38"}},
+		{name: "empty values are dropped", values: map[string]string{"genome": "", "threads": ""}},
+		{name: "seed in values is dropped", values: map[string]string{core.ParamSeed: "43"}},
+		{name: "undeclared param", values: map[string]string{"genome": "hg38", "genom": "hg19"}, wantErr: ErrInvalidArgument},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, params, err := s.containerParams(context.Background(), "", "", "42", tt.values)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			assert.Equal(t, "compute", service.Name)
+			assert.Equal(t, core.ContainerParams{Service: "compute", ImageDigest: testImageDigest, Seed: "42", Values: tt.want}, params)
+		})
+	}
+}
+
+func TestServer_CheckInputSize(t *testing.T) {
+	s := newTestServer(t)
+	s.config.MaxInputSize = 8
+
+	tests := []struct {
+		name         string
+		serviceLimit int64
+		request      *apipb.Calculate_Request
+		wantSize     int64 // Size reported in error, zero when input fits
+	}{
+		{name: "path input", request: &apipb.Calculate_Request{Params: &apipb.Container_Params{Input: "12345678"}}},
+		{name: "too long path input", request: &apipb.Calculate_Request{Params: &apipb.Container_Params{Input: "123456789"}}, wantSize: 9},
+		{name: "body", request: &apipb.Calculate_Request{InputBody: []byte("12345678")}},
+		{name: "too long body", request: &apipb.Calculate_Request{InputBody: []byte("123456789")}, wantSize: 9},
+		{
+			name:    "body is checked instead of path input",
+			request: &apipb.Calculate_Request{Params: &apipb.Container_Params{Input: "123456789"}, InputBody: []byte{}},
+		},
+		{name: "service limit is lower", serviceLimit: 4, request: &apipb.Calculate_Request{InputBody: []byte("12345")}, wantSize: 5},
+		{name: "service limit is higher", serviceLimit: 16, request: &apipb.Calculate_Request{InputBody: []byte("123456789")}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := core.Service{Name: "compute", MaxInputSize: tt.serviceLimit}
+
+			err := s.checkInputSize(service, tt.request)
+			if tt.wantSize == 0 {
+				assert.NoError(t, err)
+				return
+			}
+
+			var sizeErr *InputSizeError
+			require.ErrorAs(t, err, &sizeErr)
+			assert.Equal(t, tt.wantSize, sizeErr.Size)
+		})
+	}
+}
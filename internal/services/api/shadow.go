@@ -0,0 +1,281 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/denkoren/mi-labs-test/internal/core"
+	apipb "github.com/denkoren/mi-labs-test/proto/api/v1"
+)
+
+const (
+	// Number of the latest mismatches kept for each service
+	maxShadowMismatches = 100
+	// Candidate calculations of service running at the same time. Extra sampled calculations are skipped.
+	maxShadowCalculations = 10
+	// Only beginning of huge inputs is kept in mismatches
+	maxShadowInputSize = 64 * 1024
+)
+
+// shadowResult is the result of calculation by candidate image compared with the primary one
+type shadowResult struct {
+	time    time.Time
+	params  core.ContainerParams
+	request *apipb.Calculate_Request
+
+	primaryDigest string
+	primary       []byte
+
+	candidateDigest string
+	candidate       []byte
+	candidateErr    error
+}
+
+func (r shadowResult) matched() bool {
+	return r.candidateErr == nil && bytes.Equal(r.primary, r.candidate)
+}
+
+// shadowTraffic is the candidate image of service with results of its comparison
+type shadowTraffic struct {
+	digest     string
+	sampleRate float64
+	running    int
+
+	compared   int64
+	matched    int64
+	mismatched int64
+	failed     int64
+	skipped    int64
+
+	mismatches []*apipb.ShadowReport_Mismatch // The latest last
+}
+
+type shadows struct {
+	byService map[string]*shadowTraffic
+	lock      sync.Mutex
+}
+
+func newShadows() *shadows {
+	return &shadows{
+		byService: make(map[string]*shadowTraffic),
+	}
+}
+
+func (s *shadows) set(service string, digest string, sampleRate float64) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if sampleRate == 0 {
+		delete(s.byService, service)
+		return
+	}
+
+	// Results of the previous candidate are dropped
+	s.byService[service] = &shadowTraffic{
+		digest:     digest,
+		sampleRate: sampleRate,
+	}
+}
+
+// sample decides if calculation of service should be duplicated to its candidate
+func (s *shadows) sample(service string) (*shadowTraffic, bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	shadow, ok := s.byService[service]
+	if !ok || rand.Float64() >= shadow.sampleRate {
+		return nil, false
+	}
+
+	if shadow.running >= maxShadowCalculations {
+		shadow.skipped++
+		return nil, false
+	}
+
+	shadow.running++
+	return shadow, true
+}
+
+func (s *shadows) record(shadow *shadowTraffic, result shadowResult) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	shadow.running--
+	shadow.compared++
+
+	switch {
+	case result.matched():
+		shadow.matched++
+		return
+	case result.candidateErr != nil:
+		shadow.failed++
+	default:
+		shadow.mismatched++
+	}
+
+	shadow.mismatches = append(shadow.mismatches, shadowMismatchToProto(result))
+	if len(shadow.mismatches) > maxShadowMismatches {
+		shadow.mismatches[0] = nil
+		shadow.mismatches = shadow.mismatches[1:]
+	}
+}
+
+func (s *shadows) report(service string) *apipb.ShadowReport_Response {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	shadow, ok := s.byService[service]
+	if !ok {
+		return &apipb.ShadowReport_Response{}
+	}
+
+	response := &apipb.ShadowReport_Response{
+		ImageDigest: shadow.digest,
+		SampleRate:  shadow.sampleRate,
+
+		Compared:   shadow.compared,
+		Matched:    shadow.matched,
+		Mismatched: shadow.mismatched,
+		Failed:     shadow.failed,
+		Skipped:    shadow.skipped,
+
+		Mismatches: make([]*apipb.ShadowReport_Mismatch, 0, len(shadow.mismatches)),
+	}
+	for i := len(shadow.mismatches) - 1; i >= 0; i-- {
+		response.Mismatches = append(response.Mismatches, shadow.mismatches[i])
+	}
+
+	return response
+}
+
+// shadowCalculation duplicates sampled calculation to the candidate image of service in background
+func (s *Server) shadowCalculation(service core.Service, params core.ContainerParams, request *apipb.Calculate_Request, data []byte) {
+	shadow, ok := s.shadows.sample(service.Name)
+	if !ok {
+		return
+	}
+
+	go func() {
+		result := s.runShadowCalculation(service, params, shadow.digest, request)
+		result.primary = data
+
+		if !result.matched() {
+			log.Printf("[API] candidate image '%s' of service '%s' result differs for seed '%s': %v",
+				shadow.digest, service.Name, params.Seed, result.candidateErr)
+		}
+
+		s.shadows.record(shadow, result)
+	}()
+}
+
+func (s *Server) runShadowCalculation(service core.Service, params core.ContainerParams, digest string, request *apipb.Calculate_Request) shadowResult {
+	candidateParams := params
+	candidateParams.ImageDigest = digest
+
+	result := shadowResult{
+		time:    time.Now(),
+		params:  params,
+		request: request,
+
+		primaryDigest:   params.ImageDigest,
+		candidateDigest: digest,
+	}
+
+	ctx, cancel := s.backgroundContext(service, candidateParams)
+	defer cancel()
+
+	container, err := s.registry.ExistingOrNewByParams(candidateParams)
+	if err != nil {
+		result.candidateErr = err
+		return result
+	}
+
+	result.candidate, result.candidateErr = s.calculateIn(ctx, container, s.newCalculation(service, container, request))
+	return result
+}
+
+func shadowMismatchToProto(result shadowResult) *apipb.ShadowReport_Mismatch {
+	mismatch := &apipb.ShadowReport_Mismatch{
+		Time: timestampToProto(result.time),
+		Params: &apipb.Container_Params{
+			Service: result.params.Service,
+			Seed:    result.params.Seed,
+			Values:  result.params.Values,
+			Input:   result.request.GetParams().GetInput(),
+		},
+		InputBody: result.request.GetInputBody(),
+
+		PrimaryImageDigest:  result.primaryDigest,
+		PrimaryResultSha256: resultHash(result.primary),
+		PrimaryResultSize:   int64(len(result.primary)),
+
+		CandidateImageDigest: result.candidateDigest,
+	}
+
+	if len(mismatch.InputBody) > maxShadowInputSize {
+		mismatch.InputBody = mismatch.InputBody[:maxShadowInputSize]
+		mismatch.InputTruncated = true
+	}
+	if len(mismatch.Params.Input) > maxShadowInputSize {
+		mismatch.Params.Input = mismatch.Params.Input[:maxShadowInputSize]
+		mismatch.InputTruncated = true
+	}
+
+	if result.candidateErr != nil {
+		mismatch.CandidateError = result.candidateErr.Error()
+	} else {
+		mismatch.CandidateResultSha256 = resultHash(result.candidate)
+		mismatch.CandidateResultSize = int64(len(result.candidate))
+	}
+
+	return mismatch
+}
+
+func resultHash(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func (a *AdminServer) SetShadowTraffic(ctx context.Context, request *apipb.Shadow_Request) (*apipb.Shadow_Response, error) {
+	rate := request.GetSampleRate()
+	if rate < 0 || rate > 1 {
+		return nil, statusError(fmt.Errorf("%w: sample rate must be from 0 to 1, got %v", ErrInvalidArgument, rate))
+	}
+
+	service, err := a.server.config.Services.Get(request.GetService())
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	var digest string
+	if rate > 0 {
+		digest, err = a.server.docker.EnsureImage(ctx, service, request.GetImageVersion())
+		if err != nil {
+			return nil, statusError(err)
+		}
+	}
+
+	a.server.shadows.set(service.Name, digest, rate)
+	log.Printf("[Admin] shadow traffic of service '%s': image '%s', sample rate '%v'", service.Name, digest, rate)
+
+	return &apipb.Shadow_Response{
+		ImageDigest: digest,
+		SampleRate:  rate,
+	}, nil
+}
+
+func (a *AdminServer) GetShadowReport(_ context.Context, request *apipb.ShadowReport_Request) (*apipb.ShadowReport_Response, error) {
+	service, err := a.server.config.Services.Get(request.GetService())
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	return a.server.shadows.report(service.Name), nil
+}
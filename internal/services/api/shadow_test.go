@@ -0,0 +1,187 @@
+package api
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/denkoren/mi-labs-test/internal/core"
+	apipb "github.com/denkoren/mi-labs-test/proto/api/v1"
+)
+
+func TestShadowResult_Matched(t *testing.T) {
+	tests := []struct {
+		name   string
+		result shadowResult
+		want   bool
+	}{
+		{name: "same results", result: shadowResult{primary: []byte("42"), candidate: []byte("42")}, want: true},
+		{name: "empty results", result: shadowResult{primary: []byte{}}, want: true},
+		{name: "different results", result: shadowResult{primary: []byte("42"), candidate: []byte("43")}},
+		{name: "truncated result", result: shadowResult{primary: []byte("42"), candidate: []byte("4")}},
+		{
+			name:   "candidate failed",
+			result: shadowResult{primary: []byte("42"), candidate: []byte("42"), candidateErr: errors.New("failed")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.result.matched())
+		})
+	}
+}
+
+func TestShadows_Record(t *testing.T) {
+	s := newShadows()
+	s.set("compute", "sha256:2222", 1)
+
+	record := func(seed string, candidate string, candidateErr error) {
+		shadow, ok := s.sample("compute")
+		require.True(t, ok)
+
+		s.record(shadow, shadowResult{
+			time:    time.Unix(1600000000, 0),
+			params:  core.ContainerParams{Service: "compute", ImageDigest: "sha256:1111", Seed: seed},
+			request: &apipb.Calculate_Request{Params: &apipb.Container_Params{Input: "input-" + seed}},
+
+			primaryDigest: "sha256:1111",
+			primary:       []byte("result"),
+
+			candidateDigest: "sha256:2222",
+			candidate:       []byte(candidate),
+			candidateErr:    candidateErr,
+		})
+	}
+
+	record("1", "result", nil)
+	record("2", "other", nil)
+	record("3", "", errors.New("container failed"))
+
+	report := s.report("compute")
+	assert.Equal(t, "sha256:2222", report.ImageDigest)
+	assert.Equal(t, int64(3), report.Compared)
+	assert.Equal(t, int64(1), report.Matched)
+	assert.Equal(t, int64(1), report.Mismatched)
+	assert.Equal(t, int64(1), report.Failed)
+
+	// The latest mismatch first, matched results are not recorded
+	require.Len(t, report.Mismatches, 2)
+
+	failed := report.Mismatches[0]
+	assert.Equal(t, "3", failed.Params.Seed)
+	assert.Equal(t, "container failed", failed.CandidateError)
+	assert.Empty(t, failed.CandidateResultSha256)
+
+	mismatched := report.Mismatches[1]
+	assert.Equal(t, "2", mismatched.Params.Seed)
+	assert.Equal(t, "input-2", mismatched.Params.Input)
+	assert.Equal(t, "sha256:1111", mismatched.PrimaryImageDigest)
+	assert.Equal(t, resultHash([]byte("result")), mismatched.PrimaryResultSha256)
+	assert.Equal(t, int64(len("result")), mismatched.PrimaryResultSize)
+	assert.Equal(t, "sha256:2222", mismatched.CandidateImageDigest)
+	assert.Equal(t, resultHash([]byte("other")), mismatched.CandidateResultSha256)
+	assert.Equal(t, int64(len("other")), mismatched.CandidateResultSize)
+	assert.Empty(t, mismatched.CandidateError)
+
+	// Only the latest mismatches are kept
+	for i := 0; i < maxShadowMismatches; i++ {
+		record("many", "other", nil)
+	}
+
+	report = s.report("compute")
+	assert.Equal(t, int64(maxShadowMismatches+1), report.Mismatched)
+	assert.Len(t, report.Mismatches, maxShadowMismatches)
+	assert.Equal(t, "many", report.Mismatches[maxShadowMismatches-1].Params.Seed)
+
+	// Results of the previous candidate are dropped
+	s.set("compute", "sha256:3333", 1)
+	report = s.report("compute")
+	assert.Equal(t, "sha256:3333", report.ImageDigest)
+	assert.Zero(t, report.Compared)
+	assert.Empty(t, report.Mismatches)
+}
+
+func TestShadows_Sample(t *testing.T) {
+	s := newShadows()
+
+	_, ok := s.sample("compute")
+	assert.False(t, ok, "service has no candidate")
+
+	s.set("compute", "sha256:2222", 1)
+	for i := 0; i < maxShadowCalculations; i++ {
+		_, ok = s.sample("compute")
+		require.True(t, ok)
+	}
+
+	_, ok = s.sample("compute")
+	assert.False(t, ok, "too many candidate calculations are running")
+	assert.Equal(t, int64(1), s.report("compute").Skipped)
+
+	// Finished calculation frees its slot
+	shadow := s.byService["compute"]
+	s.record(shadow, shadowResult{})
+	_, ok = s.sample("compute")
+	assert.True(t, ok)
+
+	s.set("compute", "", 0)
+	_, ok = s.sample("compute")
+	assert.False(t, ok, "shadow traffic is disabled")
+}
+
+func TestShadowMismatchToProto(t *testing.T) {
+	huge := strings.Repeat("a", maxShadowInputSize+1)
+
+	tests := []struct {
+		name          string
+		request       *apipb.Calculate_Request
+		wantInput     string
+		wantBody      []byte
+		wantTruncated bool
+	}{
+		{
+			name:      "path input",
+			request:   &apipb.Calculate_Request{Params: &apipb.Container_Params{Input: "input"}},
+			wantInput: "input",
+		},
+		{
+			name:     "body",
+			request:  &apipb.Calculate_Request{InputBody: []byte("input")},
+			wantBody: []byte("input"),
+		},
+		{
+			name:          "huge path input",
+			request:       &apipb.Calculate_Request{Params: &apipb.Container_Params{Input: huge}},
+			wantInput:     huge[:maxShadowInputSize],
+			wantTruncated: true,
+		},
+		{
+			name:          "huge body",
+			request:       &apipb.Calculate_Request{InputBody: []byte(huge)},
+			wantBody:      []byte(huge[:maxShadowInputSize]),
+			wantTruncated: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mismatch := shadowMismatchToProto(shadowResult{
+				params:    core.ContainerParams{Service: "compute", Seed: "42", Values: map[string]string{"genome": "hg38"}},
+				request:   tt.request,
+				primary:   []byte("result"),
+				candidate: []byte("other"),
+			})
+
+			assert.Equal(t, "compute", mismatch.Params.Service)
+			assert.Equal(t, "42", mismatch.Params.Seed)
+			assert.Equal(t, map[string]string{"genome": "hg38"}, mismatch.Params.Values)
+			assert.Equal(t, tt.wantInput, mismatch.Params.Input)
+			assert.Equal(t, tt.wantBody, mismatch.InputBody)
+			assert.Equal(t, tt.wantTruncated, mismatch.InputTruncated)
+		})
+	}
+}
@@ -0,0 +1,212 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/denkoren/mi-labs-test/internal/core"
+	"github.com/denkoren/mi-labs-test/internal/interconnect/docker"
+	"github.com/denkoren/mi-labs-test/internal/interconnect/registry"
+	apipb "github.com/denkoren/mi-labs-test/proto/api/v1"
+)
+
+func (a *AdminServer) UpgradeService(ctx context.Context, request *apipb.Upgrade_Request) (*apipb.Upgrade_Response, error) {
+	response, err := a.upgradeService(withActor(ctx, registry.ActorAdmin), request)
+	if err != nil {
+		log.Printf("[Admin] failed to upgrade service '%s': %v", request.GetService(), err)
+		return nil, statusError(err)
+	}
+
+	return response, nil
+}
+
+func (a *AdminServer) upgradeService(ctx context.Context, request *apipb.Upgrade_Request) (*apipb.Upgrade_Response, error) {
+	s := a.server
+
+	service, err := s.config.Services.Get(request.GetService())
+	if err != nil {
+		return nil, err
+	}
+
+	oldDigest, err := s.serviceImage(ctx, service, "")
+	if err != nil {
+		return nil, err
+	}
+
+	newDigest, err := s.docker.EnsureImage(ctx, service, request.GetImageVersion())
+	if err != nil {
+		return nil, err
+	}
+
+	response := &apipb.Upgrade_Response{
+		OldImageDigest: oldDigest,
+		NewImageDigest: newDigest,
+	}
+	if newDigest == oldDigest {
+		return response, nil
+	}
+
+	err = s.images.startUpgrade(service.Name)
+	if err != nil {
+		return nil, err
+	}
+	defer s.images.finishUpgrade(service.Name)
+
+	log.Printf("[Admin] upgrading service '%s' from '%s' to '%s'", service.Name, oldDigest, newDigest)
+
+	oldContainers, err := a.upgradedContainers(service, newDigest)
+	if err != nil {
+		return nil, err
+	}
+
+	newContainers, created, err := a.startNewVersion(ctx, oldContainers, newDigest)
+	if err != nil {
+		a.removeContainers(created)
+		return nil, fmt.Errorf("containers of the new version failed to start, upgrade canceled: %w", err)
+	}
+
+	// Restart must not revert the service to the configured image
+	err = s.registry.SetServiceImage(service.Name, newDigest)
+	if err != nil {
+		a.removeContainers(created)
+		return nil, fmt.Errorf("upgrade canceled: %w", err)
+	}
+
+	// New calculations go to the new version since now
+	s.images.set(service.Name, newDigest)
+
+	for _, container := range newContainers {
+		response.Started = append(response.Started, s.containerInfoToProto(container.Snapshot()))
+	}
+
+	for _, container := range oldContainers {
+		// Calculations pinned to the old version get a new container instead of the draining one
+		err = s.registry.Retire(container)
+		if err != nil {
+			log.Printf("[Admin] failed to retire container '%s': %v", container.ID, err)
+			continue
+		}
+
+		response.Retired = append(response.Retired, s.containerInfoToProto(container.Snapshot()))
+	}
+
+	log.Printf("[Admin] service '%s' upgraded to '%s'", service.Name, newDigest)
+	return response, nil
+}
+
+// upgradedContainers returns active containers of service, that run images other than the new one
+func (a *AdminServer) upgradedContainers(service core.Service, newDigest string) ([]*registry.ContainerInfo, error) {
+	containers, err := a.server.registry.Containers()
+	if err != nil {
+		return nil, err
+	}
+
+	var result []*registry.ContainerInfo
+	for _, container := range containers {
+		info := container.Snapshot()
+		if a.server.serviceName(info.Params) != service.Name ||
+			info.Params.ImageDigest == newDigest ||
+			!info.Status.IsActive() ||
+			info.Draining {
+			continue
+		}
+
+		result = append(result, container)
+	}
+
+	return result, nil
+}
+
+// startNewVersion starts containers of the new image with the same params as the old ones have.
+// Returns the containers and the ones of them created by upgrade: containers of the new version could exist already.
+func (a *AdminServer) startNewVersion(
+	ctx context.Context,
+	oldContainers []*registry.ContainerInfo,
+	newDigest string,
+) ([]*registry.ContainerInfo, []*registry.ContainerInfo, error) {
+	s := a.server
+	result := make([]*registry.ContainerInfo, 0, len(oldContainers))
+	var created []*registry.ContainerInfo
+	group, groupCtx := errgroup.WithContext(ctx)
+
+	for _, old := range oldContainers {
+		info := old.Snapshot()
+
+		params := info.Params
+		params.Service = s.serviceName(info.Params)
+		params.ImageDigest = newDigest
+
+		container, err := s.registry.ExistingOrNewByParams(params)
+		if err != nil {
+			_ = group.Wait()
+			return nil, created, err
+		}
+		result = append(result, container)
+		if container.Snapshot().Status == core.ContainerStatusNew {
+			created = append(created, container)
+		}
+
+		group.Go(func() error {
+			if info.Pinned {
+				// Pinned containers are expected to stay warm after upgrade too
+				err := container.Modify(func(c *registry.ContainerInfo, _ core.ContainerStatus) error {
+					c.Pinned = true
+					return nil
+				})
+				if err != nil {
+					return err
+				}
+			}
+
+			err := s.prepareContainer(groupCtx, container)
+			if err != nil {
+				return newCalculationError(container, err)
+			}
+
+			return nil
+		})
+	}
+
+	return result, created, group.Wait()
+}
+
+// removeContainers stops and removes containers of canceled upgrade, so they don't take resources.
+func (a *AdminServer) removeContainers(containers []*registry.ContainerInfo) {
+	// Upgrade request could be canceled already
+	ctx := context.Background()
+
+	remover := func(c *registry.ContainerInfo, _ core.ContainerStatus) error {
+		if c.ID == "" {
+			// Container was not created in Docker
+			return nil
+		}
+
+		err := a.server.docker.RemoveContainer(ctx, c.ID)
+		if docker.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	for _, container := range containers {
+		err := container.ToStopped(registry.By(registry.ActorAdmin), remover, logAdminTransition)
+		if errors.Is(err, registry.ErrTransitionNotAllowed) {
+			// Container is not running: it is new or failed to start
+			err = container.Modify(remover)
+		}
+		if err == nil {
+			err = a.server.registry.Remove(container)
+		}
+
+		id := container.Snapshot().ID
+		if err != nil {
+			log.Printf("[Admin] failed to remove container '%s' of canceled upgrade: %v", id, err)
+			continue
+		}
+		log.Printf("[Admin] container '%s' of canceled upgrade removed", id)
+	}
+}
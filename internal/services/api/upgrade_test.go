@@ -0,0 +1,344 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/denkoren/mi-labs-test/internal/core"
+	"github.com/denkoren/mi-labs-test/internal/interconnect/docker"
+	"github.com/denkoren/mi-labs-test/internal/interconnect/registry"
+	apipb "github.com/denkoren/mi-labs-test/proto/api/v1"
+)
+
+const (
+	oldImageDigest    = "sha256:1111"
+	newImageDigest    = "sha256:2222"
+	brokenImageDigest = "sha256:3333"
+)
+
+var apiVersionPrefix = regexp.MustCompile(`^/v[0-9.]+`)
+
+// fakeDocker emulates Docker API used by containers lifecycle: images inspection, containers creation,
+// start, inspection and removal.
+type fakeDocker struct {
+	images map[string]types.ImageInspect // By references and IDs
+	// Creation of more containers of the image fails
+	maxContainers map[string]int
+
+	containers map[string]string // Images by container IDs
+	created    map[string]int    // Number of containers created by image
+	nextID     int
+
+	lock sync.Mutex
+}
+
+func newFakeDocker() *fakeDocker {
+	d := &fakeDocker{
+		images:        make(map[string]types.ImageInspect),
+		maxContainers: make(map[string]int),
+		containers:    make(map[string]string),
+		created:       make(map[string]int),
+	}
+
+	for tag, id := range map[string]string{"1.0": oldImageDigest, "2.0": newImageDigest, "broken": brokenImageDigest} {
+		image := types.ImageInspect{ID: id, RepoTags: []string{"compute:" + tag}}
+		d.images["compute:"+tag] = image
+		d.images[id] = image
+	}
+
+	return d
+}
+
+// limitContainers makes creation of more containers of the image fail
+func (d *fakeDocker) limitContainers(image string, limit int) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	d.maxContainers[image] = limit
+}
+
+// containersOf returns IDs of existing containers of the image
+func (d *fakeDocker) containersOf(image string) []string {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	var result []string
+	for id, containerImage := range d.containers {
+		if containerImage == image {
+			result = append(result, id)
+		}
+	}
+
+	return result
+}
+
+func (d *fakeDocker) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	path := apiVersionPrefix.ReplaceAllString(r.URL.Path, "")
+	notFound := func() {
+		w.WriteHeader(http.StatusNotFound)
+		_ = json.NewEncoder(w).Encode(types.ErrorResponse{Message: "not found: " + path})
+	}
+
+	switch {
+	case path == "/_ping":
+		w.Header().Set("API-Version", "1.41")
+		w.WriteHeader(http.StatusOK)
+
+	case strings.HasPrefix(path, "/images/") && strings.HasSuffix(path, "/json"):
+		image, ok := d.images[strings.TrimSuffix(strings.TrimPrefix(path, "/images/"), "/json")]
+		if !ok {
+			notFound()
+			return
+		}
+		_ = json.NewEncoder(w).Encode(image)
+
+	case path == "/containers/create":
+		var config container.Config
+		_ = json.NewDecoder(r.Body).Decode(&config)
+
+		if limit, ok := d.maxContainers[config.Image]; ok && d.created[config.Image] >= limit {
+			w.WriteHeader(http.StatusInternalServerError)
+			_ = json.NewEncoder(w).Encode(types.ErrorResponse{Message: "no space left on device"})
+			return
+		}
+
+		d.nextID++
+		id := "container-" + strconv.Itoa(d.nextID)
+		d.containers[id] = config.Image
+		d.created[config.Image]++
+
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(container.ContainerCreateCreatedBody{ID: id})
+
+	case strings.HasPrefix(path, "/containers/"):
+		parts := strings.Split(strings.TrimPrefix(path, "/containers/"), "/")
+		image, ok := d.containers[parts[0]]
+		if !ok {
+			notFound()
+			return
+		}
+
+		switch {
+		case r.Method == http.MethodDelete:
+			delete(d.containers, parts[0])
+			w.WriteHeader(http.StatusNoContent)
+		case len(parts) == 2 && parts[1] == "json":
+			_ = json.NewEncoder(w).Encode(types.ContainerJSON{
+				ContainerJSONBase: &types.ContainerJSONBase{
+					ID:         parts[0],
+					Image:      image,
+					State:      &types.ContainerState{Status: string(docker.ContainerStateRunning)},
+					HostConfig: &container.HostConfig{},
+				},
+				Config:          &container.Config{Image: image},
+				NetworkSettings: &types.NetworkSettings{DefaultNetworkSettings: types.DefaultNetworkSettings{IPAddress: "127.0.0.1"}},
+			})
+		default:
+			// Start and stop
+			w.WriteHeader(http.StatusNoContent)
+		}
+
+	default:
+		notFound()
+	}
+}
+
+// newUpgradeTestServer creates API server with fake Docker and the registry stored in the database file.
+// Started containers become ready at once.
+func newUpgradeTestServer(t *testing.T, dbPath string) (*AdminServer, *fakeDocker, *registry.BoltStore) {
+	fake := newFakeDocker()
+	dockerServer := httptest.NewServer(fake)
+	t.Cleanup(dockerServer.Close)
+
+	services, err := core.NewServices("compute", core.Service{
+		Name:          "compute",
+		Image:         "compute:1.0",
+		Port:          8080,
+		CalculatePath: "/calculate",
+	})
+	require.NoError(t, err)
+
+	manager, err := docker.NewManager(docker.ManagerConfig{
+		Host:           "tcp://" + dockerServer.Listener.Addr().String(),
+		RequestTimeout: time.Second,
+		Services:       services,
+	})
+	require.NoError(t, err)
+
+	store, err := registry.NewBoltStore(dbPath)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = store.Close() })
+
+	r, err := registry.NewContainerRegistry(store)
+	require.NoError(t, err)
+
+	s, err := NewServer(Config{
+		Services:             services,
+		ServiceImages:        map[string]string{"compute": oldImageDigest},
+		ContainerWaitTimeout: 5 * time.Second,
+	}, r, manager)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	go makeStartedContainersReady(ctx, r)
+
+	a, err := NewAdminServer(s)
+	require.NoError(t, err)
+
+	return a, fake, store
+}
+
+// makeStartedContainersReady plays the role of background healthchecks
+func makeStartedContainersReady(ctx context.Context, r *registry.ContainerRegistry) {
+	ticker := time.NewTicker(5 * time.Millisecond)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			containers, _ := r.Containers()
+			for _, c := range containers {
+				if c.Snapshot().Status == core.ContainerStatusStarting {
+					_ = c.ToRunning()
+					_ = c.ToReady()
+				}
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// startOldContainers starts containers of the current service image for the seeds
+func startOldContainers(t *testing.T, s *Server, seeds ...string) []*registry.ContainerInfo {
+	result := make([]*registry.ContainerInfo, 0, len(seeds))
+	for _, seed := range seeds {
+		_, params, err := s.containerParams(context.Background(), "", "", seed, nil)
+		require.NoError(t, err)
+		require.Equal(t, oldImageDigest, params.ImageDigest)
+
+		c, err := s.registry.ExistingOrNewByParams(params)
+		require.NoError(t, err)
+		require.NoError(t, s.prepareContainer(context.Background(), c))
+
+		result = append(result, c)
+	}
+
+	return result
+}
+
+func TestAdminServer_UpgradeService(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "zapuskator.db")
+	a, fake, store := newUpgradeTestServer(t, dbPath)
+	s := a.server
+
+	old := startOldContainers(t, s, "1", "2")
+	require.NoError(t, old[0].Modify(func(c *registry.ContainerInfo, _ core.ContainerStatus) error {
+		c.Pinned = true
+		return nil
+	}))
+
+	response, err := a.UpgradeService(context.Background(), &apipb.Upgrade_Request{Service: "compute", ImageVersion: "2.0"})
+	require.NoError(t, err)
+
+	assert.Equal(t, oldImageDigest, response.OldImageDigest)
+	assert.Equal(t, newImageDigest, response.NewImageDigest)
+	assert.Len(t, response.Started, 2)
+	assert.Len(t, response.Retired, 2)
+	assert.Len(t, fake.containersOf(newImageDigest), 2)
+
+	// New calculations go to the new version
+	_, params, err := s.containerParams(context.Background(), "", "", "1", nil)
+	require.NoError(t, err)
+	assert.Equal(t, newImageDigest, params.ImageDigest)
+
+	upgraded, err := s.registry.GetByParams(params)
+	require.NoError(t, err)
+	assert.Equal(t, core.ContainerStatusReady, upgraded.Snapshot().Status)
+	assert.True(t, upgraded.Snapshot().Pinned, "pinned container is pinned after upgrade")
+
+	for _, c := range old {
+		info := c.Snapshot()
+		assert.True(t, info.Retired)
+		assert.True(t, info.Draining)
+	}
+
+	// Calculations pinned to the old version get a fresh container, the retired one accepts no calculations
+	_, params, err = s.containerParams(context.Background(), "", oldImageDigest, "1", nil)
+	require.NoError(t, err)
+	pinned, err := s.registry.ExistingOrNewByParams(params)
+	require.NoError(t, err)
+	assert.NotSame(t, old[0], pinned)
+	assert.False(t, pinned.Snapshot().Draining)
+
+	// The upgrade survives restart
+	require.NoError(t, store.Close())
+	store, err = registry.NewBoltStore(dbPath)
+	require.NoError(t, err)
+	defer store.Close()
+
+	restored, err := registry.NewContainerRegistry(store)
+	require.NoError(t, err)
+	images, err := restored.ServiceImages()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"compute": newImageDigest}, images)
+
+	retired, err := restored.PeekByID(old[1].Snapshot().ID)
+	require.NoError(t, err)
+	assert.True(t, retired.Snapshot().Retired)
+}
+
+func TestAdminServer_UpgradeServiceFailed(t *testing.T) {
+	a, fake, _ := newUpgradeTestServer(t, filepath.Join(t.TempDir(), "zapuskator.db"))
+	s := a.server
+
+	old := startOldContainers(t, s, "1", "2")
+	// Only one of new containers is created
+	fake.limitContainers(brokenImageDigest, 1)
+
+	_, err := a.UpgradeService(context.Background(), &apipb.Upgrade_Request{Service: "compute", ImageVersion: "broken"})
+	require.Error(t, err)
+
+	// Service and its containers stay on the old version
+	digest, ok := s.images.current("compute")
+	assert.True(t, ok)
+	assert.Equal(t, oldImageDigest, digest)
+
+	images, err := s.registry.ServiceImages()
+	require.NoError(t, err)
+	assert.Empty(t, images)
+
+	for _, c := range old {
+		info := c.Snapshot()
+		assert.Equal(t, core.ContainerStatusReady, info.Status)
+		assert.False(t, info.Draining)
+		assert.False(t, info.Retired)
+	}
+
+	// Partially started new version is removed both from Docker and registry
+	assert.Empty(t, fake.containersOf(brokenImageDigest))
+
+	containers, err := s.registry.Containers()
+	require.NoError(t, err)
+	for _, c := range containers {
+		assert.Equal(t, oldImageDigest, c.Snapshot().Params.ImageDigest)
+	}
+}
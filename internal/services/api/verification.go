@@ -0,0 +1,351 @@
+package api
+
+import (
+	"context"
+	"log"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/docker/go-units"
+
+	"github.com/denkoren/mi-labs-test/internal/core"
+	"github.com/denkoren/mi-labs-test/internal/interconnect/registry"
+	apipb "github.com/denkoren/mi-labs-test/proto/api/v1"
+)
+
+const (
+	// Results waiting for recalculation. Extra results are not sampled.
+	maxPendingVerifications = 100
+	// Number of the latest nondeterministic results kept
+	maxDeterminismFindings = 100
+	// Only beginning of huge results is kept in samples and findings
+	maxFindingResultSize = 64 * units.KiB
+	// Input is kept until recalculation, so results of huge inputs are not sampled
+	maxVerificationInputSize = maxShadowInputSize
+	// Recalculations running at the same time
+	maxParallelVerifications = 4
+)
+
+// verificationSample is the calculation result, that is recalculated to check the container is a pure function
+type verificationSample struct {
+	time      time.Time
+	key       string
+	service   core.Service
+	container *registry.ContainerInfo
+
+	// Input is identified by the key, which includes its hash
+	input     []byte
+	inputBody bool
+
+	containerID string
+	// Sample keeps result hash and its beginning only: results could be huge
+	resultSHA256 string
+	resultSize   int
+	resultPrefix []byte
+}
+
+type verificationCounters struct {
+	sampled          int64
+	deterministic    int64
+	nondeterministic int64
+	failed           int64
+	expired          int64
+}
+
+func (c *verificationCounters) add(other *verificationCounters) {
+	c.sampled += other.sampled
+	c.deterministic += other.deterministic
+	c.nondeterministic += other.nondeterministic
+	c.failed += other.failed
+	c.expired += other.expired
+}
+
+// verifier samples calculation results and recalculates them by restarted or new containers.
+// Containers must be pure functions of their params and input: the same request always gets the same result.
+// Otherwise cached results are wrong.
+type verifier struct {
+	sampleRate float64
+	maxAge     time.Duration
+
+	pending  map[string]verificationSample    // By calculation key
+	counters map[string]*verificationCounters // By service name
+
+	findings []*apipb.Determinism_Finding // The latest last
+
+	lock sync.Mutex
+}
+
+func newVerifier(sampleRate float64, maxAge time.Duration) *verifier {
+	return &verifier{
+		sampleRate: sampleRate,
+		maxAge:     maxAge,
+		pending:    make(map[string]verificationSample),
+		counters:   make(map[string]*verificationCounters),
+	}
+}
+
+// sample decides if the calculation result should be recalculated later
+func (v *verifier) sample(service core.Service, container *registry.ContainerInfo, calc calculation, request *apipb.Calculate_Request, result []byte) {
+	if v.sampleRate <= 0 || rand.Float64() >= v.sampleRate {
+		return
+	}
+
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	if _, ok := v.pending[calc.key]; ok || len(v.pending) >= maxPendingVerifications {
+		return
+	}
+
+	input, inputBody := []byte(request.GetParams().GetInput()), false
+	if body := request.GetInputBody(); body != nil {
+		input, inputBody = append([]byte{}, body...), true
+	}
+	if len(input) > maxVerificationInputSize {
+		return
+	}
+
+	prefix := result
+	if len(prefix) > maxFindingResultSize {
+		prefix = prefix[:maxFindingResultSize]
+	}
+
+	v.countersOf(service.Name).sampled++
+	v.pending[calc.key] = verificationSample{
+		time:      time.Now(),
+		key:       calc.key,
+		service:   service,
+		container: container,
+
+		input:     input,
+		inputBody: inputBody,
+
+		containerID:  container.Snapshot().ID,
+		resultSHA256: resultHash(result),
+		resultSize:   len(result),
+		resultPrefix: append([]byte(nil), prefix...),
+	}
+}
+
+// request restores calculation request of the sample
+func (s verificationSample) request() *apipb.Calculate_Request {
+	if s.inputBody {
+		return &apipb.Calculate_Request{InputBody: s.input}
+	}
+
+	return &apipb.Calculate_Request{Params: &apipb.Container_Params{Input: string(s.input)}}
+}
+
+// countersOf is NOT thread safe
+func (v *verifier) countersOf(service string) *verificationCounters {
+	counters, ok := v.counters[service]
+	if !ok {
+		counters = &verificationCounters{}
+		v.counters[service] = counters
+	}
+
+	return counters
+}
+
+// due returns samples, that can be recalculated right now: their containers were restarted since
+// the result was got or are not running. The same running container may keep state of the original calculation,
+// so it never recalculates the sample. Samples waiting for too long, e.g. of containers in use all the time,
+// are dropped.
+func (v *verifier) due() []verificationSample {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	var result []verificationSample
+	for key, sample := range v.pending {
+		info := sample.container.Snapshot()
+
+		age := time.Since(sample.time)
+		if info.Draining || age > v.maxAge {
+			// Container is retired or the sample was not recalculated in time
+			delete(v.pending, key)
+			v.countersOf(sample.service.Name).expired++
+			continue
+		}
+
+		restarted := !info.Status.IsActive() || info.Scheduled.After(sample.time)
+		if !restarted {
+			continue
+		}
+
+		delete(v.pending, key)
+		result = append(result, sample)
+	}
+
+	return result
+}
+
+func (v *verifier) record(sample verificationSample, containerID string, result []byte, err error) {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	counters := v.countersOf(sample.service.Name)
+	switch {
+	case err != nil:
+		counters.failed++
+		return
+	case len(result) == sample.resultSize && resultHash(result) == sample.resultSHA256:
+		counters.deterministic++
+		return
+	}
+
+	counters.nondeterministic++
+	v.findings = append(v.findings, determinismFindingToProto(sample, containerID, result))
+	if len(v.findings) > maxDeterminismFindings {
+		v.findings[0] = nil
+		v.findings = v.findings[1:]
+	}
+}
+
+func (v *verifier) report(service string) *apipb.Determinism_Response {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	total := &verificationCounters{}
+	for name, counters := range v.counters {
+		if service == "" || name == service {
+			total.add(counters)
+		}
+	}
+
+	var pending int64
+	for _, sample := range v.pending {
+		if service == "" || sample.service.Name == service {
+			pending++
+		}
+	}
+
+	response := &apipb.Determinism_Response{
+		Sampled:          total.sampled,
+		Pending:          pending,
+		Deterministic:    total.deterministic,
+		Nondeterministic: total.nondeterministic,
+		Failed:           total.failed,
+		Expired:          total.expired,
+	}
+
+	for i := len(v.findings) - 1; i >= 0; i-- {
+		if service != "" && v.findings[i].GetParams().GetService() != service {
+			continue
+		}
+		response.Findings = append(response.Findings, v.findings[i])
+	}
+
+	return response
+}
+
+// RunVerification recalculates sampled results periodically until the context is done.
+func (s *Server) RunVerification(ctx context.Context) error {
+	if s.config.VerificationSampleRate <= 0 {
+		return nil
+	}
+
+	ticker := time.NewTicker(s.config.VerificationInterval)
+	defer ticker.Stop()
+
+	// Each recalculation may wait for container start, so they run in parallel
+	running := make(chan struct{}, maxParallelVerifications)
+	wg := &sync.WaitGroup{}
+	defer wg.Wait()
+
+	for {
+		select {
+		case <-ticker.C:
+			for _, sample := range s.verifier.due() {
+				select {
+				case running <- struct{}{}:
+				case <-ctx.Done():
+					log.Printf("[API] task 'RunVerification' context done: %v", ctx.Err())
+					return nil
+				}
+
+				wg.Add(1)
+				go func(sample verificationSample) {
+					defer wg.Done()
+					defer func() { <-running }()
+
+					s.verify(sample)
+				}(sample)
+			}
+
+		case <-ctx.Done():
+			log.Printf("[API] task 'RunVerification' context done: %v", ctx.Err())
+			return nil
+		}
+	}
+}
+
+func (s *Server) verify(sample verificationSample) {
+	params := sample.container.Snapshot().Params
+
+	ctx, cancel := s.backgroundContext(sample.service, params)
+	defer cancel()
+
+	container, err := s.registry.ExistingOrNewByParams(params)
+	if err != nil {
+		s.verifier.record(sample, "", nil, err)
+		return
+	}
+
+	calc := s.newCalculation(sample.service, container, sample.request())
+	// Recalculation should reach the container, not the cache or the running calculation of a client
+	calc.key = "verification:" + calc.key
+	calc.noCache = true
+	calc.detach = false
+
+	result, err := s.calculateIn(ctx, container, calc)
+	containerID := container.Snapshot().ID
+	if err != nil {
+		log.Printf("[API] failed to verify result of seed '%s': %v", params.Seed, err)
+	} else if resultHash(result) != sample.resultSHA256 {
+		log.Printf("[API] nondeterministic result of seed '%s': containers '%s' and '%s' returned different results",
+			params.Seed, sample.containerID, containerID)
+	}
+
+	s.verifier.record(sample, containerID, result, err)
+}
+
+func determinismFindingToProto(sample verificationSample, containerID string, result []byte) *apipb.Determinism_Finding {
+	params := sample.container.Snapshot().Params
+	request := sample.request()
+
+	finding := &apipb.Determinism_Finding{
+		Params: &apipb.Container_Params{
+			Service: params.Service,
+			Seed:    params.Seed,
+			Values:  params.Values,
+			Input:   request.GetParams().GetInput(),
+		},
+		InputBody:   request.GetInputBody(),
+		ImageDigest: params.ImageDigest,
+
+		Calculated:       timestampToProto(sample.time),
+		ContainerId:      sample.containerID,
+		Result:           sample.resultPrefix,
+		ResultSha256:     sample.resultSHA256,
+		ResultSize:       int64(sample.resultSize),
+		ResultsTruncated: len(sample.resultPrefix) < sample.resultSize,
+
+		Recalculated:              timestampToProto(time.Now()),
+		RecalculationContainerId:  containerID,
+		RecalculationResult:       result,
+		RecalculationResultSha256: resultHash(result),
+		RecalculationResultSize:   int64(len(result)),
+	}
+
+	if len(finding.RecalculationResult) > maxFindingResultSize {
+		finding.RecalculationResult = finding.RecalculationResult[:maxFindingResultSize]
+		finding.ResultsTruncated = true
+	}
+
+	return finding
+}
+
+func (a *AdminServer) GetDeterminismReport(_ context.Context, request *apipb.Determinism_Request) (*apipb.Determinism_Response, error) {
+	return a.server.verifier.report(request.GetService()), nil
+}
@@ -0,0 +1,217 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/denkoren/mi-labs-test/internal/core"
+	"github.com/denkoren/mi-labs-test/internal/interconnect/registry"
+	apipb "github.com/denkoren/mi-labs-test/proto/api/v1"
+)
+
+func newTestSample(t *testing.T, info core.ContainerInfo, service string, age time.Duration, result []byte) verificationSample {
+	r, err := registry.NewContainerRegistry(registry.NopStore{})
+	require.NoError(t, err)
+
+	return verificationSample{
+		time:      time.Now().Add(-age),
+		key:       info.Params.Seed,
+		service:   core.Service{Name: service},
+		container: registry.NewContainerInfo(r, info),
+
+		resultSHA256: resultHash(result),
+		resultSize:   len(result),
+		resultPrefix: result,
+	}
+}
+
+func TestVerifier_Due(t *testing.T) {
+	maxAge := time.Hour
+
+	tests := []struct {
+		name        string
+		info        core.ContainerInfo
+		age         time.Duration
+		wantDue     bool
+		wantExpired bool
+	}{
+		{
+			name:    "stopped container",
+			info:    core.ContainerInfo{Status: core.ContainerStatusStopped},
+			age:     time.Minute,
+			wantDue: true,
+		},
+		{
+			name:    "restarted container",
+			info:    core.ContainerInfo{Status: core.ContainerStatusReady, Scheduled: time.Now()},
+			age:     time.Minute,
+			wantDue: true,
+		},
+		{
+			name: "hot container waits for restart",
+			info: core.ContainerInfo{Status: core.ContainerStatusReady},
+			age:  time.Minute,
+		},
+		{
+			name: "hot container never recalculates itself",
+			info: core.ContainerInfo{Status: core.ContainerStatusReady},
+			age:  maxAge - time.Minute,
+		},
+		{
+			name:        "hot container was not restarted in time",
+			info:        core.ContainerInfo{Status: core.ContainerStatusReady},
+			age:         maxAge + time.Minute,
+			wantExpired: true,
+		},
+		{
+			name:        "draining container",
+			info:        core.ContainerInfo{Status: core.ContainerStatusStopped, Draining: true},
+			age:         time.Minute,
+			wantExpired: true,
+		},
+		{
+			name:        "too old",
+			info:        core.ContainerInfo{Status: core.ContainerStatusStopped},
+			age:         maxAge + time.Minute,
+			wantExpired: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := newVerifier(1, maxAge)
+			tt.info.Params.Seed = "seed"
+			sample := newTestSample(t, tt.info, "compute", tt.age, []byte("result"))
+			v.pending[sample.key] = sample
+
+			due := v.due()
+			if tt.wantDue {
+				assert.Len(t, due, 1)
+			} else {
+				assert.Empty(t, due)
+			}
+
+			report := v.report("")
+			assert.Equal(t, int64(0), report.Sampled)
+			if tt.wantExpired {
+				assert.Equal(t, int64(1), report.Expired)
+			} else {
+				assert.Equal(t, int64(0), report.Expired)
+			}
+
+			wantPending := int64(0)
+			if !tt.wantDue && !tt.wantExpired {
+				wantPending = 1
+			}
+			assert.Equal(t, wantPending, report.Pending)
+		})
+	}
+}
+
+func TestVerifier_Report(t *testing.T) {
+	v := newVerifier(1, time.Hour)
+
+	huge := make([]byte, maxFindingResultSize+1)
+	records := []struct {
+		service string
+		sample  []byte
+		result  []byte
+	}{
+		{service: "compute", sample: []byte("a"), result: []byte("a")},
+		{service: "compute", sample: []byte("a"), result: []byte("b")},
+		{service: "annotator", sample: huge, result: []byte("c")},
+	}
+	for _, r := range records {
+		info := core.ContainerInfo{Params: core.ContainerParams{Service: r.service, Seed: "seed"}}
+		prefix := r.sample
+		if len(prefix) > maxFindingResultSize {
+			prefix = prefix[:maxFindingResultSize]
+		}
+
+		sample := newTestSample(t, info, r.service, 0, prefix)
+		sample.resultSHA256 = resultHash(r.sample)
+		sample.resultSize = len(r.sample)
+		v.record(sample, "recalculated", r.result, nil)
+	}
+
+	tests := []struct {
+		service              string
+		wantDeterministic    int64
+		wantNondeterministic int64
+		wantTruncated        []bool
+	}{
+		{service: "", wantDeterministic: 1, wantNondeterministic: 2, wantTruncated: []bool{true, false}},
+		{service: "compute", wantDeterministic: 1, wantNondeterministic: 1, wantTruncated: []bool{false}},
+		{service: "annotator", wantDeterministic: 0, wantNondeterministic: 1, wantTruncated: []bool{true}},
+		{service: "unknown"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.service, func(t *testing.T) {
+			report := v.report(tt.service)
+			assert.Equal(t, tt.wantDeterministic, report.Deterministic)
+			assert.Equal(t, tt.wantNondeterministic, report.Nondeterministic)
+
+			truncated := make([]bool, 0, len(report.Findings))
+			for _, finding := range report.Findings {
+				truncated = append(truncated, finding.ResultsTruncated)
+				assert.LessOrEqual(t, len(finding.Result), maxFindingResultSize)
+			}
+			assert.Equal(t, len(tt.wantTruncated), len(truncated))
+			if len(tt.wantTruncated) > 0 {
+				assert.Equal(t, tt.wantTruncated, truncated)
+			}
+		})
+	}
+}
+
+func TestVerifier_Sample(t *testing.T) {
+	r, err := registry.NewContainerRegistry(registry.NopStore{})
+	require.NoError(t, err)
+	container := registry.NewContainerInfo(r, core.ContainerInfo{ID: "sampled", Params: core.ContainerParams{Service: "compute", Seed: "42"}})
+	params := container.Snapshot().Params
+
+	byInput := &apipb.Calculate_Request{Params: &apipb.Container_Params{Seed: "42", Input: "ACGT"}}
+	byBody := &apipb.Calculate_Request{Params: &apipb.Container_Params{Seed: "42"}, InputBody: []byte("ACGT")}
+	byEmptyBody := &apipb.Calculate_Request{Params: &apipb.Container_Params{Seed: "42"}, InputBody: []byte{}}
+	byHugeBody := &apipb.Calculate_Request{Params: &apipb.Container_Params{Seed: "42"}, InputBody: make([]byte, maxVerificationInputSize+1)}
+
+	tests := []struct {
+		name        string
+		request     *apipb.Calculate_Request
+		wantSampled bool
+	}{
+		{name: "input", request: byInput, wantSampled: true},
+		{name: "body", request: byBody, wantSampled: true},
+		{name: "empty body", request: byEmptyBody, wantSampled: true},
+		{name: "huge body", request: byHugeBody},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := newVerifier(1, time.Hour)
+			calc := calculation{key: requestKey(params, tt.request)}
+
+			v.sample(core.Service{Name: "compute"}, container, calc, tt.request, []byte("result"))
+			if !tt.wantSampled {
+				assert.Empty(t, v.pending)
+				assert.Equal(t, int64(0), v.report("").Sampled)
+				return
+			}
+
+			require.Contains(t, v.pending, calc.key)
+			sample := v.pending[calc.key]
+			assert.Equal(t, int64(1), v.report("").Sampled)
+			assert.Equal(t, "sampled", sample.containerID)
+
+			// Sample keeps input only, which is enough to calculate the same result again
+			request := sample.request()
+			assert.Equal(t, calc.key, requestKey(params, request))
+			assert.Equal(t, tt.request.GetParams().GetInput(), request.GetParams().GetInput())
+			assert.Equal(t, tt.request.GetInputBody(), request.GetInputBody())
+		})
+	}
+}
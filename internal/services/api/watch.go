@@ -0,0 +1,166 @@
+package api
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/denkoren/mi-labs-test/internal/core"
+	"github.com/denkoren/mi-labs-test/internal/interconnect/registry"
+	apipb "github.com/denkoren/mi-labs-test/proto/api/v1"
+)
+
+func (s *Server) WatchContainers(request *apipb.WatchContainers_Request, stream apipb.ZapuskatorAPI_WatchContainersServer) error {
+	if request.GetContainerId() != "" {
+		return s.watchContainer(request, stream)
+	}
+
+	if request.GetFromSeq() != 0 {
+		return statusError(fmt.Errorf("%w: watch can be resumed for single container only", ErrInvalidArgument))
+	}
+
+	// Subscribe before taking snapshot to not miss transitions happened in between.
+	watch := s.registry.Watch()
+	defer watch.Stop()
+
+	seeds := make(map[string]bool, len(request.GetSeeds()))
+	for _, seed := range request.GetSeeds() {
+		seeds[seed] = true
+	}
+	match := func(info core.ContainerInfo) bool {
+		return len(seeds) == 0 || seeds[info.Params.Seed]
+	}
+
+	containers, err := s.registry.Containers()
+	if err != nil {
+		return statusError(err)
+	}
+
+	// Transitions already reflected in snapshot are not sent again.
+	// Container ID changes on re-creation, so containers are identified by params.
+	snapshotTimes := make(map[string]time.Time, len(containers))
+	for _, container := range containers {
+		info := container.Snapshot()
+		if !match(info) {
+			continue
+		}
+
+		snapshotTimes[info.Params.Key()] = info.Updated
+		err = stream.Send(&apipb.WatchContainers_Event{
+			Type: apipb.WatchContainers_Event_SNAPSHOT,
+			Info: s.containerInfoToProto(info),
+			Time: timestamppb.Now(),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	err = stream.Send(&apipb.WatchContainers_Event{
+		Type: apipb.WatchContainers_Event_SNAPSHOT_DONE,
+		Time: timestamppb.Now(),
+	})
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[API] container events watch started, seeds: %v", request.GetSeeds())
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			log.Printf("[API] container events watch finished: %v", stream.Context().Err())
+			return nil
+
+		case event, ok := <-watch.C:
+			if !ok {
+				log.Printf("[API] container events watch dropped: %v", watch.Err())
+				return statusError(watch.Err())
+			}
+			if !match(event.Container) {
+				continue
+			}
+			if t, ok := snapshotTimes[event.Container.Params.Key()]; ok && !event.Time.After(t) {
+				continue
+			}
+
+			err = stream.Send(s.transitionEventToProto(event))
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// watchContainer streams transitions of the single container. The watch is resumed from sequence number, if it is given.
+func (s *Server) watchContainer(request *apipb.WatchContainers_Request, stream apipb.ZapuskatorAPI_WatchContainersServer) error {
+	container, err := s.registry.PeekByID(request.GetContainerId())
+	if err != nil {
+		return statusError(err)
+	}
+
+	var subscription registry.Subscription
+	if request.GetFromSeq() == 0 {
+		subscription = container.Subscribe()
+	} else {
+		subscription, err = container.SubscribeFrom(request.GetFromSeq())
+		if err != nil {
+			return statusError(err)
+		}
+	}
+	defer subscription.Unsubscribe()
+
+	if request.GetFromSeq() == 0 {
+		err = stream.Send(&apipb.WatchContainers_Event{
+			Type: apipb.WatchContainers_Event_SNAPSHOT,
+			Info: s.containerInfoToProto(subscription.Info),
+			Time: timestamppb.Now(),
+			Seq:  subscription.Seq,
+		})
+		if err != nil {
+			return err
+		}
+
+		err = stream.Send(&apipb.WatchContainers_Event{
+			Type: apipb.WatchContainers_Event_SNAPSHOT_DONE,
+			Time: timestamppb.Now(),
+			Seq:  subscription.Seq,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	log.Printf("[API] container '%s' events watch started from '%d'", request.GetContainerId(), request.GetFromSeq())
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			log.Printf("[API] container '%s' events watch finished: %v", request.GetContainerId(), stream.Context().Err())
+			return nil
+
+		case event, ok := <-subscription.C:
+			if !ok {
+				log.Printf("[API] container '%s' events watch dropped: %v", request.GetContainerId(), subscription.Err())
+				return statusError(subscription.Err())
+			}
+
+			err = stream.Send(s.transitionEventToProto(event))
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (s *Server) transitionEventToProto(event registry.Event) *apipb.WatchContainers_Event {
+	return &apipb.WatchContainers_Event{
+		Type:      apipb.WatchContainers_Event_TRANSITION,
+		Info:      s.containerInfoToProto(event.Container),
+		OldStatus: protoContainerStatuses[event.OldStatus],
+		Time:      timestamppb.New(event.Time),
+		Seq:       event.Seq,
+	}
+}
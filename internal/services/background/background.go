@@ -3,8 +3,13 @@ package background
 import (
 	"context"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"log"
+	"net"
 	"net/http"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -13,22 +18,42 @@ import (
 	"github.com/denkoren/mi-labs-test/internal/interconnect/registry"
 )
 
+// Progress endpoint returns just a number
+const maxProgressResponseSize = 16
+
 type Config struct {
+	Services *core.Services
+
+	// Default for services without own timeout
 	InactiveContainerTimeout time.Duration
 	ContainersCheckInterval time.Duration
+
+	// Container is restarted after this number of calculation timeouts in a row. Zero disables the watchdog.
+	HungContainerTimeouts int
+	// Container is considered unreachable after this number of failed healthchecks in a row. Is at least 1.
+	UnreachableHealthchecks int
+
 }
 
 type Background struct {
 	config Config
 	registry *registry.ContainerRegistry
 	docker *docker.Manager
+
+	healthcheckFailures map[string]int // Failed healthchecks in a row by container ID. Is used by watchActiveContainers only.
 }
 
 func NewBackground(config Config, registry *registry.ContainerRegistry, docker *docker.Manager) (*Background, error) {
+	if config.UnreachableHealthchecks < 1 {
+		config.UnreachableHealthchecks = 1
+	}
+
 	return &Background{
 		config: config,
 		registry: registry,
 		docker: docker,
+
+		healthcheckFailures: make(map[string]int),
 	}, nil
 }
 
@@ -41,6 +66,13 @@ func (s *Background) Run(ctx context.Context) error {
 	wg.Add(1)
 	go s.stopInactiveContainers(ctx, wg)
 
+	wg.Add(1)
+	go s.stopDrainedContainers(ctx, wg)
+
+	if s.config.HungContainerTimeouts > 0 {
+		wg.Add(1)
+		go s.restartHungContainers(ctx, wg)
+	}
 
 	wg.Wait()
 	return nil
@@ -52,19 +84,13 @@ func (s *Background) watchActiveContainers(ctx context.Context, wg *sync.WaitGro
 	ticker := time.NewTicker(s.config.ContainersCheckInterval)
 	defer ticker.Stop()
 
+	// Containers loaded from store could be stopped or removed while the service was down
+	s.checkActiveContainers(ctx)
+
 	for {
 		select {
 		case <-ticker.C:
-			containers, err := s.registry.ActiveContainers()
-			if err != nil {
-				log.Printf("[BG] failed to load active containers list: %s", err.Error())
-				continue
-			}
-
-			log.Printf("[BG] detected '%d' active containers", len(containers))
-			for _, container := range containers {
-				s.updateDockerContainerStatus(ctx, container)
-			}
+			s.checkActiveContainers(ctx)
 
 		case <-ctx.Done():
 			log.Printf("[BG] task 'watchActiveContainers' context done: %v", ctx.Err())
@@ -73,59 +99,159 @@ func (s *Background) watchActiveContainers(ctx context.Context, wg *sync.WaitGro
 	}
 }
 
-func (s *Background) updateDockerContainerStatus(ctx context.Context, container *registry.ContainerInfo) {
-	dState, err := s.docker.ContainerState(ctx, container.ID)
+func (s *Background) checkActiveContainers(ctx context.Context) {
+	containers, err := s.registry.ActiveContainers()
 	if err != nil {
-		log.Printf("[BG] can't check docker container '%s' status: %v", container.ID, err)
+		log.Printf("[BG] failed to load active containers list: %s", err.Error())
 		return
 	}
 
+	log.Printf("[BG] detected '%d' active containers", len(containers))
+	active := make(map[string]bool, len(containers))
+	for _, container := range containers {
+		info := container.Snapshot()
+		active[info.ID] = true
+		s.updateDockerContainerStatus(ctx, container, info)
+	}
+
+	for id := range s.healthcheckFailures {
+		if !active[id] {
+			delete(s.healthcheckFailures, id)
+		}
+	}
+}
+
+func (s *Background) updateDockerContainerStatus(ctx context.Context, container *registry.ContainerInfo, info core.ContainerInfo) {
 	logErr := func(err error) {
 		if err == nil {
 			return
 		}
-		log.Printf("[BG] failed to update '%s' container status: %s", container.ID, err.Error())
+		log.Printf("[BG] failed to update '%s' container status: %s", info.ID, err.Error())
+	}
+
+	dState, err := s.docker.ContainerState(ctx, info.ID)
+	if docker.IsNotFound(err) {
+		// Container is created again on the next request
+		log.Printf("[BG] docker container '%s' was removed", info.ID)
+		s.docker.ReleaseCPUs(info.ID)
+		logErr(container.ToStopped(byBackground, logTransition))
+		return
+	}
+	if err != nil {
+		log.Printf("[BG] can't check docker container '%s' status: %v", info.ID, err)
+		return
 	}
 
 	switch dState {
 	case docker.ContainerStateRunning:
-		logErr(s.containerHealthcheck(ctx, container))
+		logErr(s.containerHealthcheck(ctx, container, info))
 	case docker.ContainerStatePaused:
-		logErr(container.ToPaused(logTransition))
+		logErr(container.ToPaused(byBackground, logTransition))
 	case docker.ContainerStateRestarting:
-		logErr(container.ToStarting(logTransition))
+		logErr(container.ToStarting(byBackground, logTransition))
 	case docker.ContainerStateRemoving,
 		docker.ContainerStateExited,
 		docker.ContainerStateDead:
-		logErr(container.ToStopped(logTransition))
+		// Container stopped by itself keeps no cores
+		s.docker.ReleaseCPUs(info.ID)
+		logErr(container.ToStopped(byBackground, logTransition))
 	}
 }
 
-func (s *Background) containerHealthcheck(ctx context.Context, container *registry.ContainerInfo) error {
+func (s *Background) containerHealthcheck(ctx context.Context, container *registry.ContainerInfo, info core.ContainerInfo) error {
+	service, err := s.config.Services.Get(info.Params.Service)
+	if err != nil {
+		return err
+	}
+
 	req, err := http.NewRequestWithContext(
 		ctx,
 		http.MethodGet,
-		fmt.Sprintf("http://%s:8080/health", container.Addr),
+		containerURL(info, service.Port, service.HealthPath),
 		nil,
 	)
 	if err != nil {
-		log.Printf("[BG] container '%s' healthcheck failed: %s", container.ID, err.Error())
-		return container.ToUnreachable(logTransition)
+		return s.healthcheckFailed(container, info, err)
 	}
 
 	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
-		log.Printf("[BG] container '%s' healthcheck failed: %s", container.ID, err.Error())
-		return container.ToUnreachable(logTransition)
+		return s.healthcheckFailed(container, info, err)
 	}
+	_ = resp.Body.Close()
+	delete(s.healthcheckFailures, info.ID)
 
 	if resp.StatusCode != http.StatusOK {
-		log.Printf("[BG] container '%s' is not ready (healthcheck '%d')", container.ID, resp.StatusCode)
-		return container.ToRunning(logTransition)
+		log.Printf("[BG] container '%s' is not ready (healthcheck '%d')", info.ID, resp.StatusCode)
+		s.updateContainerProgress(ctx, container, info, service)
+		return container.ToRunning(byBackground, logTransition)
 	}
 
-	log.Printf("[BG] container '%s' is ready (healthcheck '%d')", container.ID, resp.StatusCode)
-	return container.ToReady(logTransition)
+	log.Printf("[BG] container '%s' is ready (healthcheck '%d')", info.ID, resp.StatusCode)
+	return container.ToReady(byBackground, logTransition)
+}
+
+// healthcheckFailed marks container unreachable after several failed healthchecks in a row:
+// calculations in unreachable container are recovered by restart, so single network glitch should not cause it.
+func (s *Background) healthcheckFailed(container *registry.ContainerInfo, info core.ContainerInfo, err error) error {
+	s.healthcheckFailures[info.ID]++
+	failures := s.healthcheckFailures[info.ID]
+
+	log.Printf("[BG] container '%s' healthcheck failed ('%d' of '%d' in a row): %s",
+		info.ID, failures, s.config.UnreachableHealthchecks, err.Error())
+	if failures < s.config.UnreachableHealthchecks {
+		return nil
+	}
+
+	delete(s.healthcheckFailures, info.ID)
+	return container.ToUnreachable(byBackground, logTransition)
+}
+
+// updateContainerProgress reads container initialization progress from its progress endpoint.
+// Containers without the endpoint are just skipped.
+func (s *Background) updateContainerProgress(ctx context.Context, container *registry.ContainerInfo, info core.ContainerInfo, service core.Service) {
+	if service.ProgressPath == "" {
+		return
+	}
+
+	req, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodGet,
+		containerURL(info, service.Port, service.ProgressPath),
+		nil,
+	)
+	if err != nil {
+		return
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return
+	}
+
+	// Read one byte more to detect truncated response
+	data, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxProgressResponseSize+1))
+	if err != nil {
+		return
+	}
+	if len(data) > maxProgressResponseSize {
+		log.Printf("[BG] container '%s' progress response is too long", info.ID)
+		return
+	}
+
+	progress, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil || progress < 0 || progress > 100 {
+		log.Printf("[BG] container '%s' reported invalid progress '%s'", info.ID, data)
+		return
+	}
+
+	log.Printf("[BG] container '%s' initialization progress: %d%%", info.ID, progress)
+	container.SetProgress(progress)
 }
 
 func (s *Background) stopInactiveContainers(ctx context.Context, wg *sync.WaitGroup) {
@@ -137,9 +263,9 @@ func (s *Background) stopInactiveContainers(ctx context.Context, wg *sync.WaitGr
 	for {
 		select {
 		case <-ticker.C:
-			lastUsedBefore := time.Now().Add(-s.config.InactiveContainerTimeout)
+			now := time.Now()
 
-			containers, err := s.registry.OldContainers(lastUsedBefore)
+			containers, err := s.registry.OldContainers(now.Add(-s.minInactiveContainerTimeout()))
 			if err != nil {
 				log.Printf("[BG] failed to load old containers list")
 				continue
@@ -147,13 +273,20 @@ func (s *Background) stopInactiveContainers(ctx context.Context, wg *sync.WaitGr
 
 			log.Printf("[BG] detected '%d' old containers", len(containers))
 			for _, container := range containers {
+				info := container.Snapshot()
+				lastUsedBefore := now.Add(-s.inactiveContainerTimeout(info.Params))
+				if !info.LastUsed.Before(lastUsedBefore) {
+					// Service of container has longer timeout
+					continue
+				}
+
 				err = s.scheduleContainerStop(ctx, container, lastUsedBefore)
 				if err != nil {
-					log.Printf("[BG] failed to stop container '%s': %v", container.ID, err)
+					log.Printf("[BG] failed to stop container '%s': %v", info.ID, err)
 					continue
 				}
 
-				log.Printf("[BG] scheduled container '%s' stop", container.ID)
+				log.Printf("[BG] scheduled container '%s' stop", info.ID)
 			}
 
 		case <-ctx.Done():
@@ -171,6 +304,15 @@ func (s *Background) scheduleContainerStop(ctx context.Context, container *regis
 			return fmt.Errorf("someone used the container '%s' before it was scheduled for stopping", c.ID)
 		}
 
+		if c.Pinned {
+			return fmt.Errorf("container '%s' was pinned before it was scheduled for stopping", c.ID)
+		}
+
+		if c.ActiveCalculations > 0 {
+			// Detached and lingering calculations are not waited by clients, but they still use the container
+			return fmt.Errorf("container '%s' has calculations in progress", c.ID)
+		}
+
 		err := s.docker.StopContainer(ctx, c.ID)
 		if err != nil {
 			return err
@@ -179,9 +321,176 @@ func (s *Background) scheduleContainerStop(ctx context.Context, container *regis
 		return nil
 	}
 
-	return container.ToStopped(stopper, logTransition)
+	return container.ToStopped(byBackground, stopper, logTransition)
+}
+
+// stopDrainedContainers stops draining containers once they have no calculations in progress.
+func (s *Background) stopDrainedContainers(ctx context.Context, wg *sync.WaitGroup) {
+	defer wg.Done()
+
+	ticker := time.NewTicker(s.config.ContainersCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			containers, err := s.registry.DrainedContainers()
+			if err != nil {
+				log.Printf("[BG] failed to load drained containers list: %s", err.Error())
+				continue
+			}
+
+			for _, container := range containers {
+				id := container.Snapshot().ID
+				err = s.stopDrainedContainer(ctx, container)
+				if err != nil {
+					log.Printf("[BG] failed to stop drained container '%s': %v", id, err)
+					continue
+				}
+
+				log.Printf("[BG] drained container '%s' stopped", id)
+			}
+
+			s.removeRetiredContainers(ctx)
+
+		case <-ctx.Done():
+			log.Printf("[BG] task 'stopDrainedContainers' context done: %v", ctx.Err())
+			return
+		}
+	}
+}
+
+func (s *Background) stopDrainedContainer(ctx context.Context, container *registry.ContainerInfo) error {
+	var stopper registry.TransitionHook = func(c *registry.ContainerInfo, _ core.ContainerStatus) error {
+		// Check the container once again under lock: draining could be canceled by container stop.
+		if !c.Draining || c.ActiveCalculations != 0 {
+			return fmt.Errorf("container '%s' is not drained", c.ID)
+		}
+
+		return s.docker.StopContainer(ctx, c.ID)
+	}
+
+	return container.ToStopped(byBackground, stopper, logTransition)
+}
+
+// removeRetiredContainers removes stopped retired containers: they are replaced and will never be started again.
+func (s *Background) removeRetiredContainers(ctx context.Context) {
+	containers, err := s.registry.RetiredContainers()
+	if err != nil {
+		log.Printf("[BG] failed to load retired containers list: %s", err.Error())
+		return
+	}
+
+	for _, container := range containers {
+		id := container.Snapshot().ID
+
+		err = s.docker.RemoveContainer(ctx, id)
+		if err != nil && !docker.IsNotFound(err) {
+			log.Printf("[BG] failed to remove retired container '%s': %v", id, err)
+			continue
+		}
+
+		err = s.registry.Remove(container)
+		if err != nil {
+			log.Printf("[BG] failed to remove retired container '%s' from registry: %v", id, err)
+			continue
+		}
+
+		log.Printf("[BG] retired container '%s' removed", id)
+	}
+}
+
+// restartHungContainers is a watchdog, that restarts containers with repeated calculation timeouts.
+func (s *Background) restartHungContainers(ctx context.Context, wg *sync.WaitGroup) {
+	defer wg.Done()
+
+	ticker := time.NewTicker(s.config.ContainersCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			s.checkHungContainers(ctx)
+
+		case <-ctx.Done():
+			log.Printf("[BG] task 'restartHungContainers' context done: %v", ctx.Err())
+			return
+		}
+	}
+}
+
+// checkHungContainers restarts containers with <HungContainerTimeouts> calculation timeouts in a row
+func (s *Background) checkHungContainers(ctx context.Context) {
+	containers, err := s.registry.HungContainers(s.config.HungContainerTimeouts)
+	if err != nil {
+		log.Printf("[BG] failed to load hung containers list: %s", err.Error())
+		return
+	}
+
+	for _, container := range containers {
+		info := container.Snapshot()
+		log.Printf("[BG] container '%s' is hung: '%d' calculations timed out in a row", info.ID, info.CalculationTimeouts)
+
+		err = s.restartContainer(ctx, container)
+		if err != nil {
+			log.Printf("[BG] failed to restart hung container '%s': %v", info.ID, err)
+			continue
+		}
+
+		log.Printf("[BG] hung container '%s' restarted", info.ID)
+	}
+}
+
+// restartContainer marks the container unhealthy and restarts it.
+// Container healthcheck brings it back to ready status once it is initialized.
+func (s *Background) restartContainer(ctx context.Context, container *registry.ContainerInfo) error {
+	err := container.ToUnreachable(byBackground, logTransition)
+	if err != nil {
+		return err
+	}
+
+	var restarter registry.TransitionHook = func(c *registry.ContainerInfo, _ core.ContainerStatus) error {
+		addr, err := s.docker.RestartContainer(ctx, c.ID)
+		if err != nil {
+			return err
+		}
+
+		c.Addr = addr
+		c.CalculationTimeouts = 0
+		return nil
+	}
+
+	return container.ToStarting(byBackground, restarter, logTransition)
 }
 
+// inactiveContainerTimeout is the time container of the service may stay unused before it is stopped
+func (s *Background) inactiveContainerTimeout(params core.ContainerParams) time.Duration {
+	service, err := s.config.Services.Get(params.Service)
+	if err != nil || service.InactiveContainerTimeout == 0 {
+		return s.config.InactiveContainerTimeout
+	}
+
+	return service.InactiveContainerTimeout
+}
+
+func (s *Background) minInactiveContainerTimeout() time.Duration {
+	result := s.config.InactiveContainerTimeout
+	for _, service := range s.config.Services.All() {
+		if service.InactiveContainerTimeout != 0 && service.InactiveContainerTimeout < result {
+			result = service.InactiveContainerTimeout
+		}
+	}
+
+	return result
+}
+
+func containerURL(info core.ContainerInfo, port int, path string) string {
+	return fmt.Sprintf("http://%s%s", net.JoinHostPort(info.Addr, strconv.Itoa(port)), path)
+}
+
+// byBackground marks transitions performed by background tasks. Should be the first transition hook.
+var byBackground = registry.By(registry.ActorBackground)
+
 func logTransition(c *registry.ContainerInfo, newStatus core.ContainerStatus) error {
 	log.Printf("[BG] container '%s' transitioned from '%s' to '%s'", c.ID, c.Status.String(), newStatus.String())
 	return nil
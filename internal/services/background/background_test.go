@@ -0,0 +1,206 @@
+package background
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/denkoren/mi-labs-test/internal/core"
+	"github.com/denkoren/mi-labs-test/internal/interconnect/docker"
+	"github.com/denkoren/mi-labs-test/internal/interconnect/registry"
+)
+
+// startTestContainer serves container endpoints and registers the container as running.
+func startTestContainer(t *testing.T, progressPath string, handler http.HandlerFunc) (*Background, *registry.ContainerInfo) {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	serverURL, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	host, port, err := net.SplitHostPort(serverURL.Host)
+	require.NoError(t, err)
+	portNumber, err := strconv.Atoi(port)
+	require.NoError(t, err)
+
+	services, err := core.NewServices("compute", core.Service{
+		Name:         "compute",
+		Image:        "compute:1.0",
+		Port:         portNumber,
+		HealthPath:   "/health",
+		ProgressPath: progressPath,
+	})
+	require.NoError(t, err)
+
+	r, err := registry.NewContainerRegistry(registry.NopStore{})
+	require.NoError(t, err)
+
+	container := registry.NewContainerInfo(r, core.ContainerInfo{
+		ID:       "running",
+		Addr:     host,
+		Params:   core.ContainerParams{Service: "compute", Seed: "42"},
+		Status:   core.ContainerStatusRunning,
+		Progress: 10,
+	})
+	require.NoError(t, r.Register(container))
+
+	s, err := NewBackground(Config{Services: services}, r, nil)
+	require.NoError(t, err)
+
+	return s, container
+}
+
+func TestBackground_UpdateContainerProgress(t *testing.T) {
+	tests := []struct {
+		name         string
+		progressPath string
+		ready        bool
+		progress     string
+		wantProgress int
+	}{
+		{name: "progress", progressPath: "/progress", progress: "42", wantProgress: 42},
+		{name: "progress with spaces", progressPath: "/progress", progress: " 42\n", wantProgress: 42},
+		{name: "progress above 100", progressPath: "/progress", progress: "150", wantProgress: 10},
+		{name: "negative progress", progressPath: "/progress", progress: "-1", wantProgress: 10},
+		{name: "not a number", progressPath: "/progress", progress: "42%", wantProgress: 10},
+		{name: "too long response", progressPath: "/progress", progress: "00000000000000000042", wantProgress: 10},
+		{name: "no progress endpoint", progressPath: "/missing", progress: "42", wantProgress: 10},
+		{name: "polling disabled", progress: "42", wantProgress: 10},
+		{name: "ready container", progressPath: "/progress", ready: true, progress: "42", wantProgress: 100},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			healthStatus, wantStatus := http.StatusServiceUnavailable, core.ContainerStatusRunning
+			if tt.ready {
+				healthStatus, wantStatus = http.StatusOK, core.ContainerStatusReady
+			}
+
+			s, container := startTestContainer(t, tt.progressPath, func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/health":
+					w.WriteHeader(healthStatus)
+				case "/progress":
+					_, _ = fmt.Fprint(w, tt.progress)
+				default:
+					w.WriteHeader(http.StatusNotFound)
+				}
+			})
+
+			require.NoError(t, s.containerHealthcheck(context.Background(), container, container.Snapshot()))
+
+			info := container.Snapshot()
+			assert.Equal(t, wantStatus, info.Status)
+			assert.Equal(t, tt.wantProgress, info.Progress)
+		})
+	}
+}
+
+// newRestartTestDocker emulates Docker API restarting containers. Restarted containers get the new address.
+func newRestartTestDocker(t *testing.T, services *core.Services, restarts *int32) *docker.Manager {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/_ping"):
+			w.Header().Set("API-Version", "1.41")
+		case strings.HasSuffix(r.URL.Path, "/restart"):
+			atomic.AddInt32(restarts, 1)
+			w.WriteHeader(http.StatusNoContent)
+		case strings.HasSuffix(r.URL.Path, "/json"):
+			_ = json.NewEncoder(w).Encode(types.ContainerJSON{
+				ContainerJSONBase: &types.ContainerJSONBase{State: &types.ContainerState{Status: "running"}},
+				Config:            &container.Config{},
+				NetworkSettings:   &types.NetworkSettings{DefaultNetworkSettings: types.DefaultNetworkSettings{IPAddress: "10.0.0.2"}},
+			})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	manager, err := docker.NewManager(docker.ManagerConfig{
+		Host:           "tcp://" + server.Listener.Addr().String(),
+		RequestTimeout: time.Second,
+		Services:       services,
+	})
+	require.NoError(t, err)
+
+	return manager
+}
+
+func TestBackground_CheckHungContainers(t *testing.T) {
+	tests := []struct {
+		name         string
+		timeouts     int
+		responded    bool // Container responds after timeouts
+		wantRestart  bool
+		wantTimeouts int
+	}{
+		{name: "no timeouts", wantTimeouts: 0},
+		{name: "less timeouts than limit", timeouts: 2, wantTimeouts: 2},
+		{name: "timeouts limit reached", timeouts: 3, wantRestart: true, wantTimeouts: 0},
+		{name: "timeouts limit exceeded", timeouts: 5, wantRestart: true, wantTimeouts: 0},
+		{name: "responded after timeouts", timeouts: 5, responded: true, wantTimeouts: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			services, err := core.NewServices("compute", core.Service{Name: "compute", Image: "compute:1.0", Port: 8080})
+			require.NoError(t, err)
+
+			var restarts int32
+			manager := newRestartTestDocker(t, services, &restarts)
+
+			r, err := registry.NewContainerRegistry(registry.NopStore{})
+			require.NoError(t, err)
+
+			c := registry.NewContainerInfo(r, core.ContainerInfo{
+				ID:     "hung",
+				Addr:   "10.0.0.1",
+				Params: core.ContainerParams{Service: "compute", Seed: "42"},
+				Status: core.ContainerStatusReady,
+			})
+			require.NoError(t, r.Register(c))
+
+			s, err := NewBackground(Config{Services: services, HungContainerTimeouts: 3}, r, manager)
+			require.NoError(t, err)
+
+			// Calculation targets report timeouts and responses like this
+			for i := 0; i < tt.timeouts; i++ {
+				c.CalculationTimedOut()
+			}
+			if tt.responded {
+				c.CalculationResponded()
+			}
+
+			s.checkHungContainers(context.Background())
+
+			info := c.Snapshot()
+			assert.Equal(t, tt.wantTimeouts, info.CalculationTimeouts)
+			if !tt.wantRestart {
+				assert.Zero(t, atomic.LoadInt32(&restarts))
+				assert.Equal(t, core.ContainerStatusReady, info.Status)
+				assert.Equal(t, "10.0.0.1", info.Addr)
+				return
+			}
+
+			// Healthcheck makes restarted container ready again
+			assert.Equal(t, int32(1), atomic.LoadInt32(&restarts))
+			assert.Equal(t, core.ContainerStatusStarting, info.Status)
+			assert.Equal(t, "10.0.0.2", info.Addr)
+		})
+	}
+}
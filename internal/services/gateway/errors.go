@@ -0,0 +1,30 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/status"
+)
+
+// HTTPError writes gRPC error to HTTP response like runtime.DefaultHTTPError does.
+// When the error has google.rpc.RetryInfo details, the delay is reported in 'Retry-After' header too.
+func HTTPError(ctx context.Context, mux *runtime.ServeMux, marshaler runtime.Marshaler, w http.ResponseWriter, r *http.Request, err error) {
+	if st, ok := status.FromError(err); ok {
+		for _, detail := range st.Details() {
+			retryInfo, ok := detail.(*errdetails.RetryInfo)
+			if !ok {
+				continue
+			}
+
+			seconds := math.Ceil(retryInfo.GetRetryDelay().AsDuration().Seconds())
+			w.Header().Set("Retry-After", fmt.Sprintf("%d", int64(seconds)))
+		}
+	}
+
+	runtime.DefaultHTTPError(ctx, mux, marshaler, w, r, err)
+}
@@ -0,0 +1,58 @@
+package gateway
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"google.golang.org/grpc/metadata"
+)
+
+const MIMEOctetStream = "application/octet-stream"
+
+// dataMessage is a response message with binary payload, e.g. Calculate.Response.
+type dataMessage interface {
+	GetData() []byte
+}
+
+// RawMarshaler passes HTTP request body to bytes field of gRPC request as is, without any decoding.
+// Responses with binary payload are written the same way. Everything else is handled by JSONPb marshaler.
+type RawMarshaler struct {
+	runtime.JSONPb
+}
+
+func (m *RawMarshaler) ContentType() string {
+	return MIMEOctetStream
+}
+
+func (m *RawMarshaler) Marshal(v interface{}) ([]byte, error) {
+	if msg, ok := v.(dataMessage); ok {
+		return msg.GetData(), nil
+	}
+
+	return m.JSONPb.Marshal(v)
+}
+
+func (m *RawMarshaler) NewDecoder(r io.Reader) runtime.Decoder {
+	return runtime.DecoderFunc(func(v interface{}) error {
+		data, ok := v.(*[]byte)
+		if !ok {
+			return m.JSONPb.NewDecoder(r).Decode(v)
+		}
+
+		var err error
+		*data, err = ioutil.ReadAll(r)
+		return err
+	})
+}
+
+// InputBodyMetadata marks requests with body for gRPC server: empty body is lost in gRPC message otherwise.
+func InputBodyMetadata(_ context.Context, r *http.Request) metadata.MD {
+	if r.Method != http.MethodPost {
+		return nil
+	}
+
+	return metadata.Pairs("x-input-body", "true")
+}
@@ -0,0 +1,115 @@
+package gateway
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"google.golang.org/grpc/status"
+
+	apipb "github.com/denkoren/mi-labs-test/proto/api/v1"
+)
+
+// SSEHandler streams container lifecycle events from WatchContainers gRPC as text/event-stream.
+// Seeds to watch are passed by 'seeds' query parameters, the single container - by 'container_id' one.
+// Events of the single container have IDs, so the browser resumes the stream with Last-Event-ID header
+// after reconnect. The stream is resumed from 'from_seq' query parameter too.
+// Handler is served on public HTTP port, so events of all containers are not streamed: seeds or container
+// are required. Unfiltered stream is available by gRPC, that is listened on localhost only.
+type SSEHandler struct {
+	client    apipb.ZapuskatorAPIClient
+	marshaler runtime.Marshaler
+}
+
+func NewSSEHandler(client apipb.ZapuskatorAPIClient) *SSEHandler {
+	return &SSEHandler{
+		client:    client,
+		marshaler: &runtime.JSONPb{OrigName: true, EmitDefaults: true},
+	}
+}
+
+func (h *SSEHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming is not supported", http.StatusInternalServerError)
+		return
+	}
+
+	var err error
+
+	request := &apipb.WatchContainers_Request{
+		Seeds:       r.URL.Query()["seeds"],
+		ContainerId: r.URL.Query().Get("container_id"),
+	}
+	if len(request.Seeds) == 0 && request.ContainerId == "" {
+		http.Error(w, "'seeds' or 'container_id' query parameter is required", http.StatusBadRequest)
+		return
+	}
+
+	fromSeq := r.Header.Get("Last-Event-ID")
+	if fromSeq == "" {
+		fromSeq = r.URL.Query().Get("from_seq")
+	}
+	if fromSeq != "" {
+		request.FromSeq, err = strconv.ParseUint(fromSeq, 10, 64)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("invalid sequence number '%s'", fromSeq), http.StatusBadRequest)
+			return
+		}
+	}
+
+	stream, err := h.client.WatchContainers(r.Context(), request)
+	if err != nil {
+		http.Error(w, err.Error(), runtime.HTTPStatusFromCode(status.Code(err)))
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	for {
+		event, err := stream.Recv()
+		if err == io.EOF {
+			return
+		}
+		if err != nil {
+			if r.Context().Err() == nil {
+				log.Printf("[SSE] container events stream failed: %v", err)
+				_, _ = fmt.Fprintf(w, "event: error\ndata: %s\n\n", strings.ReplaceAll(err.Error(), "\n", " "))
+				flusher.Flush()
+			}
+			return
+		}
+
+		data, err := h.marshaler.Marshal(event)
+		if err != nil {
+			log.Printf("[SSE] failed to marshal container event: %v", err)
+			return
+		}
+
+		if request.ContainerId != "" && event.GetSeq() != 0 {
+			_, err = fmt.Fprintf(w, "id: %d\n", event.GetSeq())
+			if err != nil {
+				return
+			}
+		}
+
+		_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", strings.ToLower(event.GetType().String()), data)
+		if err != nil {
+			return
+		}
+		flusher.Flush()
+	}
+}
@@ -0,0 +1,39 @@
+package gateway
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSSEHandler_BadRequests(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		url    string
+		header http.Header
+		want   int
+	}{
+		{name: "post", method: http.MethodPost, url: "/v1/events?seeds=42", want: http.StatusMethodNotAllowed},
+		{name: "no filter", method: http.MethodGet, url: "/v1/events", want: http.StatusBadRequest},
+		{name: "invalid sequence", method: http.MethodGet, url: "/v1/events?container_id=abc&from_seq=x", want: http.StatusBadRequest},
+		{name: "invalid last event id", method: http.MethodGet, url: "/v1/events?container_id=abc", header: http.Header{"Last-Event-Id": {"-1"}}, want: http.StatusBadRequest},
+	}
+
+	// Bad requests are rejected before the stream is opened, so client is not needed
+	h := NewSSEHandler(nil)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(tt.method, tt.url, nil)
+			for name, values := range tt.header {
+				r.Header[name] = values
+			}
+
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+			assert.Equal(t, tt.want, w.Code)
+		})
+	}
+}
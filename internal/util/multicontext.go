@@ -4,12 +4,17 @@ import (
 	"context"
 	"reflect"
 	"sync"
+	"time"
 )
 
 type Multicontext struct {
 	outContext       context.Context
 	cancelOutContext context.CancelFunc
 	isCanceled       bool
+	isDetached       bool
+
+	linger    time.Duration
+	lingering bool
 
 	inContexts []context.Context
 	inCancels  []context.CancelFunc
@@ -19,6 +24,12 @@ type Multicontext struct {
 }
 
 func NewMulticontext() *Multicontext {
+	return NewLingeringMulticontext(0)
+}
+
+// NewLingeringMulticontext creates Multicontext, that keeps out context alive for <linger> time
+// after all in contexts are done. New context added during this time makes it active again.
+func NewLingeringMulticontext(linger time.Duration) *Multicontext {
 	outCtx, cancelOutCtx := context.WithCancel(context.Background())
 
 	stubCtx, cancelStubCtx := context.WithCancel(outCtx)
@@ -34,6 +45,8 @@ func NewMulticontext() *Multicontext {
 
 		inContexts: inContexts,
 		inCancels:  inCancels,
+
+		linger: linger,
 	}
 
 	go mCtx.waitAllCanceled()
@@ -55,6 +68,20 @@ func (m *Multicontext) Cancel() {
 	}
 }
 
+// Detach makes out context independent of in contexts: it stays alive even when all of them are done
+// and is canceled only by explicit Cancel() call.
+func (m *Multicontext) Detach() error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if m.isCanceled {
+		return context.Canceled
+	}
+
+	m.isDetached = true
+	return nil
+}
+
 func (m *Multicontext) AddCtx(ctxs ...context.Context) error {
 	m.lock.Lock()
 	defer m.lock.Unlock()
@@ -69,6 +96,7 @@ func (m *Multicontext) AddCtx(ctxs ...context.Context) error {
 		m.inCancels = append(m.inCancels, cancel)
 	}
 
+	m.lingering = false
 	m.cancelStubContext()
 	return nil
 }
@@ -116,9 +144,34 @@ func (m *Multicontext) waitAllCanceled() {
 		}
 
 		// All contexts are done.
+		if m.isDetached {
+			// Nobody waits for the result, but it still should be calculated.
+			m.lock.Unlock()
+			return
+		}
+
+		if m.linger > 0 && !m.lingering {
+			// Give a chance to new context to join before canceling
+			m.startLinger()
+			cases = selectCases()
+			m.lock.Unlock()
+			continue
+		}
+
 		m.cancelOutContext()
 		m.isCanceled = true
 		m.lock.Unlock()
 		return
 	}
 }
+
+// startLinger adds stub context, that is done after linger timeout or on new context registration.
+// is NOT thread safe
+func (m *Multicontext) startLinger() {
+	stubCtx, cancelStubCtx := context.WithTimeout(m.outContext, m.linger)
+
+	m.inContexts = append(m.inContexts, stubCtx)
+	m.inCancels = append(m.inCancels, cancelStubCtx)
+	m.cancelStubContext = cancelStubCtx
+	m.lingering = true
+}
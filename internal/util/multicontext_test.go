@@ -8,6 +8,15 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
+const (
+	// Time given to out context to be canceled. Is long enough for slow CI machines.
+	cancelTimeout = time.Second
+	// Time out context must stay active to consider it is not canceled
+	activeTime = 50 * time.Millisecond
+	// Linger timeout in tests is long enough to check out context is active during it
+	testLinger = 200 * time.Millisecond
+)
+
 func TestMulticontext_AllCanceled(t *testing.T) {
 	mc := NewMulticontext()
 
@@ -25,7 +34,7 @@ func TestMulticontext_AllCanceled(t *testing.T) {
 	select {
 	case <-mc.Ctx().Done():
 		return
-	case <-time.After(time.Millisecond):
+	case <-time.After(cancelTimeout):
 		assert.Fail(t, "out context was not canceled")
 	}
 }
@@ -37,7 +46,7 @@ func TestMulticontext_ForceCanceled(t *testing.T) {
 	select {
 	case <-mc.Ctx().Done():
 		return
-	case <-time.After(time.Millisecond):
+	case <-time.After(cancelTimeout):
 		assert.Fail(t, "out context was not canceled")
 	}
 }
@@ -45,7 +54,8 @@ func TestMulticontext_ForceCanceled(t *testing.T) {
 func TestMulticontext_ForceCanceledChilds(t *testing.T) {
 	mc := NewMulticontext()
 
-	ctx, _ := context.WithCancel(context.Background())
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
 	err := mc.AddCtx(ctx)
 	assert.NoError(t, err, "failed to register context")
 
@@ -54,7 +64,7 @@ func TestMulticontext_ForceCanceledChilds(t *testing.T) {
 	select {
 	case <-mc.Ctx().Done():
 		return
-	case <-time.After(time.Millisecond):
+	case <-time.After(cancelTimeout):
 		assert.Fail(t, "out context was not canceled")
 	}
 }
@@ -76,7 +86,82 @@ func TestMulticontext_PartiallyCanceled(t *testing.T) {
 	select {
 	case <-mc.Ctx().Done():
 		assert.Fail(t, "child context is active, but out context was canceled")
-	case <-time.After(time.Millisecond):
+	case <-time.After(activeTime):
 		return
 	}
 }
+
+func TestMulticontext_Lingering(t *testing.T) {
+	mc := NewLingeringMulticontext(testLinger)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	err := mc.AddCtx(ctx)
+	assert.NoError(t, err, "failed to register context")
+
+	cancel()
+
+	select {
+	case <-mc.Ctx().Done():
+		assert.Fail(t, "out context was canceled before linger timeout")
+	case <-time.After(activeTime):
+	}
+
+	select {
+	case <-mc.Ctx().Done():
+		return
+	case <-time.After(testLinger + cancelTimeout):
+		assert.Fail(t, "out context was not canceled after linger timeout")
+	}
+}
+
+func TestMulticontext_LingeringRejoined(t *testing.T) {
+	mc := NewLingeringMulticontext(testLinger)
+	defer mc.Cancel()
+
+	ctx1, cancel1 := context.WithCancel(context.Background())
+	err := mc.AddCtx(ctx1)
+	assert.NoError(t, err, "failed to register context")
+
+	cancel1()
+	time.Sleep(activeTime)
+
+	ctx2, cancel2 := context.WithCancel(context.Background())
+	defer cancel2()
+	err = mc.AddCtx(ctx2)
+	assert.NoError(t, err, "failed to register context during linger")
+
+	select {
+	case <-mc.Ctx().Done():
+		assert.Fail(t, "context joined during linger is active, but out context was canceled")
+	case <-time.After(2 * testLinger):
+		return
+	}
+}
+
+func TestMulticontext_Detached(t *testing.T) {
+	mc := NewMulticontext()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	err := mc.AddCtx(ctx)
+	assert.NoError(t, err, "failed to register context")
+
+	err = mc.Detach()
+	assert.NoError(t, err, "failed to detach")
+
+	cancel()
+
+	select {
+	case <-mc.Ctx().Done():
+		assert.Fail(t, "out context is detached, but was canceled")
+	case <-time.After(activeTime):
+	}
+
+	mc.Cancel()
+
+	select {
+	case <-mc.Ctx().Done():
+		return
+	case <-time.After(cancelTimeout):
+		assert.Fail(t, "out context was not canceled")
+	}
+}
@@ -10,6 +10,8 @@ import (
 	_ "google.golang.org/genproto/googleapis/api/annotations"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 )
@@ -25,33 +27,40 @@ type Container_Status int32
 
 const (
 	Container_NEW         Container_Status = 0
+	Container_CREATED     Container_Status = 8
 	Container_STARTING    Container_Status = 1
+	Container_NOT_READY   Container_Status = 7 // Container is running, but is not initialized yet.
 	Container_READY       Container_Status = 2
-	Container_NOT_READY   Container_Status = 7
 	Container_UNREACHABLE Container_Status = 6
-	Container_STOPPING    Container_Status = 3
-	Container_STOPPED     Container_Status = 4
-	Container_FAILED      Container_Status = 5
+	Container_PAUSED      Container_Status = 9
+	// Deprecated: Do not use.
+	Container_STOPPING Container_Status = 3 // Is never reported: containers are stopped synchronously.
+	Container_STOPPED  Container_Status = 4
+	Container_FAILED   Container_Status = 5
 )
 
 // Enum value maps for Container_Status.
 var (
 	Container_Status_name = map[int32]string{
 		0: "NEW",
+		8: "CREATED",
 		1: "STARTING",
-		2: "READY",
 		7: "NOT_READY",
+		2: "READY",
 		6: "UNREACHABLE",
+		9: "PAUSED",
 		3: "STOPPING",
 		4: "STOPPED",
 		5: "FAILED",
 	}
 	Container_Status_value = map[string]int32{
 		"NEW":         0,
+		"CREATED":     8,
 		"STARTING":    1,
-		"READY":       2,
 		"NOT_READY":   7,
+		"READY":       2,
 		"UNREACHABLE": 6,
+		"PAUSED":      9,
 		"STOPPING":    3,
 		"STOPPED":     4,
 		"FAILED":      5,
@@ -82,17 +91,66 @@ func (x Container_Status) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use Container_Status.Descriptor instead.
 func (Container_Status) EnumDescriptor() ([]byte, []int) {
-	return file_api_v1_proto_rawDescGZIP(), []int{1, 0}
+	return file_api_v1_proto_rawDescGZIP(), []int{6, 0}
 }
 
-type Calculate struct {
+type WatchContainers_Event_Type int32
+
+const (
+	WatchContainers_Event_SNAPSHOT      WatchContainers_Event_Type = 0 // Current state of container at the moment of subscription.
+	WatchContainers_Event_SNAPSHOT_DONE WatchContainers_Event_Type = 1 // All containers were sent in snapshot. Has no container info.
+	WatchContainers_Event_TRANSITION    WatchContainers_Event_Type = 2
+)
+
+// Enum value maps for WatchContainers_Event_Type.
+var (
+	WatchContainers_Event_Type_name = map[int32]string{
+		0: "SNAPSHOT",
+		1: "SNAPSHOT_DONE",
+		2: "TRANSITION",
+	}
+	WatchContainers_Event_Type_value = map[string]int32{
+		"SNAPSHOT":      0,
+		"SNAPSHOT_DONE": 1,
+		"TRANSITION":    2,
+	}
+)
+
+func (x WatchContainers_Event_Type) Enum() *WatchContainers_Event_Type {
+	p := new(WatchContainers_Event_Type)
+	*p = x
+	return p
+}
+
+func (x WatchContainers_Event_Type) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (WatchContainers_Event_Type) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_v1_proto_enumTypes[1].Descriptor()
+}
+
+func (WatchContainers_Event_Type) Type() protoreflect.EnumType {
+	return &file_api_v1_proto_enumTypes[1]
+}
+
+func (x WatchContainers_Event_Type) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use WatchContainers_Event_Type.Descriptor instead.
+func (WatchContainers_Event_Type) EnumDescriptor() ([]byte, []int) {
+	return file_api_v1_proto_rawDescGZIP(), []int{9, 1, 0}
+}
+
+type Admin struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 }
 
-func (x *Calculate) Reset() {
-	*x = Calculate{}
+func (x *Admin) Reset() {
+	*x = Admin{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_api_v1_proto_msgTypes[0]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -100,13 +158,13 @@ func (x *Calculate) Reset() {
 	}
 }
 
-func (x *Calculate) String() string {
+func (x *Admin) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Calculate) ProtoMessage() {}
+func (*Admin) ProtoMessage() {}
 
-func (x *Calculate) ProtoReflect() protoreflect.Message {
+func (x *Admin) ProtoReflect() protoreflect.Message {
 	mi := &file_api_v1_proto_msgTypes[0]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -118,19 +176,19 @@ func (x *Calculate) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Calculate.ProtoReflect.Descriptor instead.
-func (*Calculate) Descriptor() ([]byte, []int) {
+// Deprecated: Use Admin.ProtoReflect.Descriptor instead.
+func (*Admin) Descriptor() ([]byte, []int) {
 	return file_api_v1_proto_rawDescGZIP(), []int{0}
 }
 
-type Container struct {
+type Upgrade struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 }
 
-func (x *Container) Reset() {
-	*x = Container{}
+func (x *Upgrade) Reset() {
+	*x = Upgrade{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_api_v1_proto_msgTypes[1]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -138,13 +196,13 @@ func (x *Container) Reset() {
 	}
 }
 
-func (x *Container) String() string {
+func (x *Upgrade) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Container) ProtoMessage() {}
+func (*Upgrade) ProtoMessage() {}
 
-func (x *Container) ProtoReflect() protoreflect.Message {
+func (x *Upgrade) ProtoReflect() protoreflect.Message {
 	mi := &file_api_v1_proto_msgTypes[1]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -156,21 +214,19 @@ func (x *Container) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Container.ProtoReflect.Descriptor instead.
-func (*Container) Descriptor() ([]byte, []int) {
+// Deprecated: Use Upgrade.ProtoReflect.Descriptor instead.
+func (*Upgrade) Descriptor() ([]byte, []int) {
 	return file_api_v1_proto_rawDescGZIP(), []int{1}
 }
 
-type Calculate_Request struct {
+type Shadow struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
-
-	Params *Container_Params `protobuf:"bytes,1,opt,name=params,proto3" json:"params,omitempty"`
 }
 
-func (x *Calculate_Request) Reset() {
-	*x = Calculate_Request{}
+func (x *Shadow) Reset() {
+	*x = Shadow{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_api_v1_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -178,13 +234,13 @@ func (x *Calculate_Request) Reset() {
 	}
 }
 
-func (x *Calculate_Request) String() string {
+func (x *Shadow) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Calculate_Request) ProtoMessage() {}
+func (*Shadow) ProtoMessage() {}
 
-func (x *Calculate_Request) ProtoReflect() protoreflect.Message {
+func (x *Shadow) ProtoReflect() protoreflect.Message {
 	mi := &file_api_v1_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -196,28 +252,19 @@ func (x *Calculate_Request) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Calculate_Request.ProtoReflect.Descriptor instead.
-func (*Calculate_Request) Descriptor() ([]byte, []int) {
-	return file_api_v1_proto_rawDescGZIP(), []int{0, 0}
-}
-
-func (x *Calculate_Request) GetParams() *Container_Params {
-	if x != nil {
-		return x.Params
-	}
-	return nil
+// Deprecated: Use Shadow.ProtoReflect.Descriptor instead.
+func (*Shadow) Descriptor() ([]byte, []int) {
+	return file_api_v1_proto_rawDescGZIP(), []int{2}
 }
 
-type Calculate_Response struct {
+type ShadowReport struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
-
-	Data []byte `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
 }
 
-func (x *Calculate_Response) Reset() {
-	*x = Calculate_Response{}
+func (x *ShadowReport) Reset() {
+	*x = ShadowReport{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_api_v1_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -225,13 +272,13 @@ func (x *Calculate_Response) Reset() {
 	}
 }
 
-func (x *Calculate_Response) String() string {
+func (x *ShadowReport) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Calculate_Response) ProtoMessage() {}
+func (*ShadowReport) ProtoMessage() {}
 
-func (x *Calculate_Response) ProtoReflect() protoreflect.Message {
+func (x *ShadowReport) ProtoReflect() protoreflect.Message {
 	mi := &file_api_v1_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -243,29 +290,19 @@ func (x *Calculate_Response) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Calculate_Response.ProtoReflect.Descriptor instead.
-func (*Calculate_Response) Descriptor() ([]byte, []int) {
-	return file_api_v1_proto_rawDescGZIP(), []int{0, 1}
-}
-
-func (x *Calculate_Response) GetData() []byte {
-	if x != nil {
-		return x.Data
-	}
-	return nil
+// Deprecated: Use ShadowReport.ProtoReflect.Descriptor instead.
+func (*ShadowReport) Descriptor() ([]byte, []int) {
+	return file_api_v1_proto_rawDescGZIP(), []int{3}
 }
 
-type Container_Params struct {
+type Determinism struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
-
-	Seed  string `protobuf:"bytes,1,opt,name=seed,proto3" json:"seed,omitempty"`
-	Input string `protobuf:"bytes,2,opt,name=input,proto3" json:"input,omitempty"`
 }
 
-func (x *Container_Params) Reset() {
-	*x = Container_Params{}
+func (x *Determinism) Reset() {
+	*x = Determinism{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_api_v1_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -273,13 +310,13 @@ func (x *Container_Params) Reset() {
 	}
 }
 
-func (x *Container_Params) String() string {
+func (x *Determinism) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Container_Params) ProtoMessage() {}
+func (*Determinism) ProtoMessage() {}
 
-func (x *Container_Params) ProtoReflect() protoreflect.Message {
+func (x *Determinism) ProtoReflect() protoreflect.Message {
 	mi := &file_api_v1_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -291,53 +328,72 @@ func (x *Container_Params) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Container_Params.ProtoReflect.Descriptor instead.
-func (*Container_Params) Descriptor() ([]byte, []int) {
-	return file_api_v1_proto_rawDescGZIP(), []int{1, 0}
+// Deprecated: Use Determinism.ProtoReflect.Descriptor instead.
+func (*Determinism) Descriptor() ([]byte, []int) {
+	return file_api_v1_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *Container_Params) GetSeed() string {
-	if x != nil {
-		return x.Seed
+type Calculate struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *Calculate) Reset() {
+	*x = Calculate{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return ""
 }
 
-func (x *Container_Params) GetInput() string {
-	if x != nil {
-		return x.Input
+func (x *Calculate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Calculate) ProtoMessage() {}
+
+func (x *Calculate) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-type Container_Info struct {
+// Deprecated: Use Calculate.ProtoReflect.Descriptor instead.
+func (*Calculate) Descriptor() ([]byte, []int) {
+	return file_api_v1_proto_rawDescGZIP(), []int{5}
+}
+
+type Container struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
-
-	Id     string            `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Addr   string            `protobuf:"bytes,2,opt,name=addr,proto3" json:"addr,omitempty"`
-	Params *Container_Params `protobuf:"bytes,3,opt,name=params,proto3" json:"params,omitempty"`
-	Status Container_Status  `protobuf:"varint,4,opt,name=status,proto3,enum=Zapuskator.API.v1.Container_Status" json:"status,omitempty"`
 }
 
-func (x *Container_Info) Reset() {
-	*x = Container_Info{}
+func (x *Container) Reset() {
+	*x = Container{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_v1_proto_msgTypes[5]
+		mi := &file_api_v1_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Container_Info) String() string {
+func (x *Container) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Container_Info) ProtoMessage() {}
+func (*Container) ProtoMessage() {}
 
-func (x *Container_Info) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_proto_msgTypes[5]
+func (x *Container) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -348,64 +404,72 @@ func (x *Container_Info) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Container_Info.ProtoReflect.Descriptor instead.
-func (*Container_Info) Descriptor() ([]byte, []int) {
-	return file_api_v1_proto_rawDescGZIP(), []int{1, 1}
+// Deprecated: Use Container.ProtoReflect.Descriptor instead.
+func (*Container) Descriptor() ([]byte, []int) {
+	return file_api_v1_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *Container_Info) GetId() string {
-	if x != nil {
-		return x.Id
-	}
-	return ""
+type ContainerHistory struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 }
 
-func (x *Container_Info) GetAddr() string {
-	if x != nil {
-		return x.Addr
+func (x *ContainerHistory) Reset() {
+	*x = ContainerHistory{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return ""
 }
 
-func (x *Container_Info) GetParams() *Container_Params {
-	if x != nil {
-		return x.Params
-	}
-	return nil
+func (x *ContainerHistory) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *Container_Info) GetStatus() Container_Status {
-	if x != nil {
-		return x.Status
+func (*ContainerHistory) ProtoMessage() {}
+
+func (x *ContainerHistory) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return Container_NEW
+	return mi.MessageOf(x)
 }
 
-type Container_Request struct {
+// Deprecated: Use ContainerHistory.ProtoReflect.Descriptor instead.
+func (*ContainerHistory) Descriptor() ([]byte, []int) {
+	return file_api_v1_proto_rawDescGZIP(), []int{7}
+}
+
+type ListContainers struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
-
-	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 }
 
-func (x *Container_Request) Reset() {
-	*x = Container_Request{}
+func (x *ListContainers) Reset() {
+	*x = ListContainers{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_v1_proto_msgTypes[6]
+		mi := &file_api_v1_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Container_Request) String() string {
+func (x *ListContainers) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Container_Request) ProtoMessage() {}
+func (*ListContainers) ProtoMessage() {}
 
-func (x *Container_Request) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_proto_msgTypes[6]
+func (x *ListContainers) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -416,43 +480,77 @@ func (x *Container_Request) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Container_Request.ProtoReflect.Descriptor instead.
-func (*Container_Request) Descriptor() ([]byte, []int) {
-	return file_api_v1_proto_rawDescGZIP(), []int{1, 2}
+// Deprecated: Use ListContainers.ProtoReflect.Descriptor instead.
+func (*ListContainers) Descriptor() ([]byte, []int) {
+	return file_api_v1_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *Container_Request) GetId() string {
-	if x != nil {
-		return x.Id
+type WatchContainers struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *WatchContainers) Reset() {
+	*x = WatchContainers{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return ""
 }
 
-type Container_Response struct {
+func (x *WatchContainers) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchContainers) ProtoMessage() {}
+
+func (x *WatchContainers) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchContainers.ProtoReflect.Descriptor instead.
+func (*WatchContainers) Descriptor() ([]byte, []int) {
+	return file_api_v1_proto_rawDescGZIP(), []int{9}
+}
+
+type Admin_Request struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Info *Container_Info `protobuf:"bytes,1,opt,name=info,proto3" json:"info,omitempty"`
+	Seed         string            `protobuf:"bytes,1,opt,name=seed,proto3" json:"seed,omitempty"`
+	Values       map[string]string `protobuf:"bytes,2,rep,name=values,proto3" json:"values,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"` // Named params of container besides seed.
+	Service      string            `protobuf:"bytes,3,opt,name=service,proto3" json:"service,omitempty"`                                                                                       // The default service when empty.
+	ImageVersion string            `protobuf:"bytes,4,opt,name=image_version,json=imageVersion,proto3" json:"image_version,omitempty"`                                                         // Tag, digest or ID of service image. The current service image is used when empty.
 }
 
-func (x *Container_Response) Reset() {
-	*x = Container_Response{}
+func (x *Admin_Request) Reset() {
+	*x = Admin_Request{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_v1_proto_msgTypes[7]
+		mi := &file_api_v1_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Container_Response) String() string {
+func (x *Admin_Request) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Container_Response) ProtoMessage() {}
+func (*Admin_Request) ProtoMessage() {}
 
-func (x *Container_Response) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_proto_msgTypes[7]
+func (x *Admin_Request) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -463,82 +561,2488 @@ func (x *Container_Response) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Container_Response.ProtoReflect.Descriptor instead.
-func (*Container_Response) Descriptor() ([]byte, []int) {
-	return file_api_v1_proto_rawDescGZIP(), []int{1, 3}
+// Deprecated: Use Admin_Request.ProtoReflect.Descriptor instead.
+func (*Admin_Request) Descriptor() ([]byte, []int) {
+	return file_api_v1_proto_rawDescGZIP(), []int{0, 0}
 }
 
-func (x *Container_Response) GetInfo() *Container_Info {
+func (x *Admin_Request) GetSeed() string {
 	if x != nil {
-		return x.Info
+		return x.Seed
+	}
+	return ""
+}
+
+func (x *Admin_Request) GetValues() map[string]string {
+	if x != nil {
+		return x.Values
 	}
 	return nil
 }
 
+func (x *Admin_Request) GetService() string {
+	if x != nil {
+		return x.Service
+	}
+	return ""
+}
+
+func (x *Admin_Request) GetImageVersion() string {
+	if x != nil {
+		return x.ImageVersion
+	}
+	return ""
+}
+
+type Admin_Response struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Info *Container_Info `protobuf:"bytes,1,opt,name=info,proto3" json:"info,omitempty"`
+}
+
+func (x *Admin_Response) Reset() {
+	*x = Admin_Response{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Admin_Response) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Admin_Response) ProtoMessage() {}
+
+func (x *Admin_Response) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Admin_Response.ProtoReflect.Descriptor instead.
+func (*Admin_Response) Descriptor() ([]byte, []int) {
+	return file_api_v1_proto_rawDescGZIP(), []int{0, 1}
+}
+
+func (x *Admin_Response) GetInfo() *Container_Info {
+	if x != nil {
+		return x.Info
+	}
+	return nil
+}
+
+type Upgrade_Request struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Service      string `protobuf:"bytes,1,opt,name=service,proto3" json:"service,omitempty"`
+	ImageVersion string `protobuf:"bytes,2,opt,name=image_version,json=imageVersion,proto3" json:"image_version,omitempty"` // Tag, digest or ID of service image.
+}
+
+func (x *Upgrade_Request) Reset() {
+	*x = Upgrade_Request{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Upgrade_Request) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Upgrade_Request) ProtoMessage() {}
+
+func (x *Upgrade_Request) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Upgrade_Request.ProtoReflect.Descriptor instead.
+func (*Upgrade_Request) Descriptor() ([]byte, []int) {
+	return file_api_v1_proto_rawDescGZIP(), []int{1, 0}
+}
+
+func (x *Upgrade_Request) GetService() string {
+	if x != nil {
+		return x.Service
+	}
+	return ""
+}
+
+func (x *Upgrade_Request) GetImageVersion() string {
+	if x != nil {
+		return x.ImageVersion
+	}
+	return ""
+}
+
+type Upgrade_Response struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	OldImageDigest string            `protobuf:"bytes,1,opt,name=old_image_digest,json=oldImageDigest,proto3" json:"old_image_digest,omitempty"`
+	NewImageDigest string            `protobuf:"bytes,2,opt,name=new_image_digest,json=newImageDigest,proto3" json:"new_image_digest,omitempty"`
+	Started        []*Container_Info `protobuf:"bytes,3,rep,name=started,proto3" json:"started,omitempty"` // Containers of the new version.
+	Retired        []*Container_Info `protobuf:"bytes,4,rep,name=retired,proto3" json:"retired,omitempty"` // Drained containers of previous versions.
+}
+
+func (x *Upgrade_Response) Reset() {
+	*x = Upgrade_Response{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Upgrade_Response) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Upgrade_Response) ProtoMessage() {}
+
+func (x *Upgrade_Response) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Upgrade_Response.ProtoReflect.Descriptor instead.
+func (*Upgrade_Response) Descriptor() ([]byte, []int) {
+	return file_api_v1_proto_rawDescGZIP(), []int{1, 1}
+}
+
+func (x *Upgrade_Response) GetOldImageDigest() string {
+	if x != nil {
+		return x.OldImageDigest
+	}
+	return ""
+}
+
+func (x *Upgrade_Response) GetNewImageDigest() string {
+	if x != nil {
+		return x.NewImageDigest
+	}
+	return ""
+}
+
+func (x *Upgrade_Response) GetStarted() []*Container_Info {
+	if x != nil {
+		return x.Started
+	}
+	return nil
+}
+
+func (x *Upgrade_Response) GetRetired() []*Container_Info {
+	if x != nil {
+		return x.Retired
+	}
+	return nil
+}
+
+type Shadow_Request struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Service      string  `protobuf:"bytes,1,opt,name=service,proto3" json:"service,omitempty"`
+	ImageVersion string  `protobuf:"bytes,2,opt,name=image_version,json=imageVersion,proto3" json:"image_version,omitempty"` // Tag, digest or ID of candidate image.
+	SampleRate   float64 `protobuf:"fixed64,3,opt,name=sample_rate,json=sampleRate,proto3" json:"sample_rate,omitempty"`     // Fraction of calculations duplicated, from 0 to 1.
+}
+
+func (x *Shadow_Request) Reset() {
+	*x = Shadow_Request{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Shadow_Request) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Shadow_Request) ProtoMessage() {}
+
+func (x *Shadow_Request) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Shadow_Request.ProtoReflect.Descriptor instead.
+func (*Shadow_Request) Descriptor() ([]byte, []int) {
+	return file_api_v1_proto_rawDescGZIP(), []int{2, 0}
+}
+
+func (x *Shadow_Request) GetService() string {
+	if x != nil {
+		return x.Service
+	}
+	return ""
+}
+
+func (x *Shadow_Request) GetImageVersion() string {
+	if x != nil {
+		return x.ImageVersion
+	}
+	return ""
+}
+
+func (x *Shadow_Request) GetSampleRate() float64 {
+	if x != nil {
+		return x.SampleRate
+	}
+	return 0
+}
+
+type Shadow_Response struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ImageDigest string  `protobuf:"bytes,1,opt,name=image_digest,json=imageDigest,proto3" json:"image_digest,omitempty"`
+	SampleRate  float64 `protobuf:"fixed64,2,opt,name=sample_rate,json=sampleRate,proto3" json:"sample_rate,omitempty"`
+}
+
+func (x *Shadow_Response) Reset() {
+	*x = Shadow_Response{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Shadow_Response) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Shadow_Response) ProtoMessage() {}
+
+func (x *Shadow_Response) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Shadow_Response.ProtoReflect.Descriptor instead.
+func (*Shadow_Response) Descriptor() ([]byte, []int) {
+	return file_api_v1_proto_rawDescGZIP(), []int{2, 1}
+}
+
+func (x *Shadow_Response) GetImageDigest() string {
+	if x != nil {
+		return x.ImageDigest
+	}
+	return ""
+}
+
+func (x *Shadow_Response) GetSampleRate() float64 {
+	if x != nil {
+		return x.SampleRate
+	}
+	return 0
+}
+
+type ShadowReport_Request struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Service string `protobuf:"bytes,1,opt,name=service,proto3" json:"service,omitempty"`
+}
+
+func (x *ShadowReport_Request) Reset() {
+	*x = ShadowReport_Request{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ShadowReport_Request) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShadowReport_Request) ProtoMessage() {}
+
+func (x *ShadowReport_Request) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShadowReport_Request.ProtoReflect.Descriptor instead.
+func (*ShadowReport_Request) Descriptor() ([]byte, []int) {
+	return file_api_v1_proto_rawDescGZIP(), []int{3, 0}
+}
+
+func (x *ShadowReport_Request) GetService() string {
+	if x != nil {
+		return x.Service
+	}
+	return ""
+}
+
+type ShadowReport_Mismatch struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Time                  *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=time,proto3" json:"time,omitempty"`
+	Params                *Container_Params      `protobuf:"bytes,2,opt,name=params,proto3" json:"params,omitempty"` // Seed, values and input passed in path.
+	InputBody             []byte                 `protobuf:"bytes,3,opt,name=input_body,json=inputBody,proto3" json:"input_body,omitempty"`
+	InputTruncated        bool                   `protobuf:"varint,4,opt,name=input_truncated,json=inputTruncated,proto3" json:"input_truncated,omitempty"` // Only beginning of huge input is kept.
+	PrimaryImageDigest    string                 `protobuf:"bytes,5,opt,name=primary_image_digest,json=primaryImageDigest,proto3" json:"primary_image_digest,omitempty"`
+	PrimaryResultSha256   string                 `protobuf:"bytes,6,opt,name=primary_result_sha256,json=primaryResultSha256,proto3" json:"primary_result_sha256,omitempty"`
+	PrimaryResultSize     int64                  `protobuf:"varint,7,opt,name=primary_result_size,json=primaryResultSize,proto3" json:"primary_result_size,omitempty"`
+	CandidateImageDigest  string                 `protobuf:"bytes,8,opt,name=candidate_image_digest,json=candidateImageDigest,proto3" json:"candidate_image_digest,omitempty"`
+	CandidateResultSha256 string                 `protobuf:"bytes,9,opt,name=candidate_result_sha256,json=candidateResultSha256,proto3" json:"candidate_result_sha256,omitempty"`
+	CandidateResultSize   int64                  `protobuf:"varint,10,opt,name=candidate_result_size,json=candidateResultSize,proto3" json:"candidate_result_size,omitempty"`
+	CandidateError        string                 `protobuf:"bytes,11,opt,name=candidate_error,json=candidateError,proto3" json:"candidate_error,omitempty"` // Candidate failed to calculate.
+}
+
+func (x *ShadowReport_Mismatch) Reset() {
+	*x = ShadowReport_Mismatch{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ShadowReport_Mismatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShadowReport_Mismatch) ProtoMessage() {}
+
+func (x *ShadowReport_Mismatch) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShadowReport_Mismatch.ProtoReflect.Descriptor instead.
+func (*ShadowReport_Mismatch) Descriptor() ([]byte, []int) {
+	return file_api_v1_proto_rawDescGZIP(), []int{3, 1}
+}
+
+func (x *ShadowReport_Mismatch) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *ShadowReport_Mismatch) GetParams() *Container_Params {
+	if x != nil {
+		return x.Params
+	}
+	return nil
+}
+
+func (x *ShadowReport_Mismatch) GetInputBody() []byte {
+	if x != nil {
+		return x.InputBody
+	}
+	return nil
+}
+
+func (x *ShadowReport_Mismatch) GetInputTruncated() bool {
+	if x != nil {
+		return x.InputTruncated
+	}
+	return false
+}
+
+func (x *ShadowReport_Mismatch) GetPrimaryImageDigest() string {
+	if x != nil {
+		return x.PrimaryImageDigest
+	}
+	return ""
+}
+
+func (x *ShadowReport_Mismatch) GetPrimaryResultSha256() string {
+	if x != nil {
+		return x.PrimaryResultSha256
+	}
+	return ""
+}
+
+func (x *ShadowReport_Mismatch) GetPrimaryResultSize() int64 {
+	if x != nil {
+		return x.PrimaryResultSize
+	}
+	return 0
+}
+
+func (x *ShadowReport_Mismatch) GetCandidateImageDigest() string {
+	if x != nil {
+		return x.CandidateImageDigest
+	}
+	return ""
+}
+
+func (x *ShadowReport_Mismatch) GetCandidateResultSha256() string {
+	if x != nil {
+		return x.CandidateResultSha256
+	}
+	return ""
+}
+
+func (x *ShadowReport_Mismatch) GetCandidateResultSize() int64 {
+	if x != nil {
+		return x.CandidateResultSize
+	}
+	return 0
+}
+
+func (x *ShadowReport_Mismatch) GetCandidateError() string {
+	if x != nil {
+		return x.CandidateError
+	}
+	return ""
+}
+
+type ShadowReport_Response struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ImageDigest string                   `protobuf:"bytes,1,opt,name=image_digest,json=imageDigest,proto3" json:"image_digest,omitempty"`
+	SampleRate  float64                  `protobuf:"fixed64,2,opt,name=sample_rate,json=sampleRate,proto3" json:"sample_rate,omitempty"`
+	Compared    int64                    `protobuf:"varint,3,opt,name=compared,proto3" json:"compared,omitempty"`
+	Matched     int64                    `protobuf:"varint,4,opt,name=matched,proto3" json:"matched,omitempty"`
+	Mismatched  int64                    `protobuf:"varint,5,opt,name=mismatched,proto3" json:"mismatched,omitempty"`
+	Failed      int64                    `protobuf:"varint,6,opt,name=failed,proto3" json:"failed,omitempty"`        // Candidate failed to calculate.
+	Skipped     int64                    `protobuf:"varint,7,opt,name=skipped,proto3" json:"skipped,omitempty"`      // Sampled, but not performed due to concurrency limit.
+	Mismatches  []*ShadowReport_Mismatch `protobuf:"bytes,8,rep,name=mismatches,proto3" json:"mismatches,omitempty"` // The latest first.
+}
+
+func (x *ShadowReport_Response) Reset() {
+	*x = ShadowReport_Response{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ShadowReport_Response) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShadowReport_Response) ProtoMessage() {}
+
+func (x *ShadowReport_Response) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShadowReport_Response.ProtoReflect.Descriptor instead.
+func (*ShadowReport_Response) Descriptor() ([]byte, []int) {
+	return file_api_v1_proto_rawDescGZIP(), []int{3, 2}
+}
+
+func (x *ShadowReport_Response) GetImageDigest() string {
+	if x != nil {
+		return x.ImageDigest
+	}
+	return ""
+}
+
+func (x *ShadowReport_Response) GetSampleRate() float64 {
+	if x != nil {
+		return x.SampleRate
+	}
+	return 0
+}
+
+func (x *ShadowReport_Response) GetCompared() int64 {
+	if x != nil {
+		return x.Compared
+	}
+	return 0
+}
+
+func (x *ShadowReport_Response) GetMatched() int64 {
+	if x != nil {
+		return x.Matched
+	}
+	return 0
+}
+
+func (x *ShadowReport_Response) GetMismatched() int64 {
+	if x != nil {
+		return x.Mismatched
+	}
+	return 0
+}
+
+func (x *ShadowReport_Response) GetFailed() int64 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+func (x *ShadowReport_Response) GetSkipped() int64 {
+	if x != nil {
+		return x.Skipped
+	}
+	return 0
+}
+
+func (x *ShadowReport_Response) GetMismatches() []*ShadowReport_Mismatch {
+	if x != nil {
+		return x.Mismatches
+	}
+	return nil
+}
+
+type Determinism_Request struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Service string `protobuf:"bytes,1,opt,name=service,proto3" json:"service,omitempty"` // Report nondeterministic results of this service only. All services are reported when empty.
+}
+
+func (x *Determinism_Request) Reset() {
+	*x = Determinism_Request{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Determinism_Request) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Determinism_Request) ProtoMessage() {}
+
+func (x *Determinism_Request) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Determinism_Request.ProtoReflect.Descriptor instead.
+func (*Determinism_Request) Descriptor() ([]byte, []int) {
+	return file_api_v1_proto_rawDescGZIP(), []int{4, 0}
+}
+
+func (x *Determinism_Request) GetService() string {
+	if x != nil {
+		return x.Service
+	}
+	return ""
+}
+
+type Determinism_Finding struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Params                    *Container_Params      `protobuf:"bytes,1,opt,name=params,proto3" json:"params,omitempty"` // Seed, values and input passed in path.
+	InputBody                 []byte                 `protobuf:"bytes,2,opt,name=input_body,json=inputBody,proto3" json:"input_body,omitempty"`
+	InputTruncated            bool                   `protobuf:"varint,3,opt,name=input_truncated,json=inputTruncated,proto3" json:"input_truncated,omitempty"` // Only beginning of huge input is kept.
+	ImageDigest               string                 `protobuf:"bytes,4,opt,name=image_digest,json=imageDigest,proto3" json:"image_digest,omitempty"`
+	Calculated                *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=calculated,proto3" json:"calculated,omitempty"`
+	ContainerId               string                 `protobuf:"bytes,6,opt,name=container_id,json=containerId,proto3" json:"container_id,omitempty"`
+	Result                    []byte                 `protobuf:"bytes,7,opt,name=result,proto3" json:"result,omitempty"`
+	ResultSha256              string                 `protobuf:"bytes,8,opt,name=result_sha256,json=resultSha256,proto3" json:"result_sha256,omitempty"`
+	ResultSize                int64                  `protobuf:"varint,9,opt,name=result_size,json=resultSize,proto3" json:"result_size,omitempty"`
+	Recalculated              *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=recalculated,proto3" json:"recalculated,omitempty"`
+	RecalculationContainerId  string                 `protobuf:"bytes,11,opt,name=recalculation_container_id,json=recalculationContainerId,proto3" json:"recalculation_container_id,omitempty"`
+	RecalculationResult       []byte                 `protobuf:"bytes,12,opt,name=recalculation_result,json=recalculationResult,proto3" json:"recalculation_result,omitempty"`
+	RecalculationResultSha256 string                 `protobuf:"bytes,13,opt,name=recalculation_result_sha256,json=recalculationResultSha256,proto3" json:"recalculation_result_sha256,omitempty"`
+	RecalculationResultSize   int64                  `protobuf:"varint,14,opt,name=recalculation_result_size,json=recalculationResultSize,proto3" json:"recalculation_result_size,omitempty"`
+	ResultsTruncated          bool                   `protobuf:"varint,15,opt,name=results_truncated,json=resultsTruncated,proto3" json:"results_truncated,omitempty"` // Only beginning of huge results is kept.
+}
+
+func (x *Determinism_Finding) Reset() {
+	*x = Determinism_Finding{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Determinism_Finding) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Determinism_Finding) ProtoMessage() {}
+
+func (x *Determinism_Finding) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Determinism_Finding.ProtoReflect.Descriptor instead.
+func (*Determinism_Finding) Descriptor() ([]byte, []int) {
+	return file_api_v1_proto_rawDescGZIP(), []int{4, 1}
+}
+
+func (x *Determinism_Finding) GetParams() *Container_Params {
+	if x != nil {
+		return x.Params
+	}
+	return nil
+}
+
+func (x *Determinism_Finding) GetInputBody() []byte {
+	if x != nil {
+		return x.InputBody
+	}
+	return nil
+}
+
+func (x *Determinism_Finding) GetInputTruncated() bool {
+	if x != nil {
+		return x.InputTruncated
+	}
+	return false
+}
+
+func (x *Determinism_Finding) GetImageDigest() string {
+	if x != nil {
+		return x.ImageDigest
+	}
+	return ""
+}
+
+func (x *Determinism_Finding) GetCalculated() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Calculated
+	}
+	return nil
+}
+
+func (x *Determinism_Finding) GetContainerId() string {
+	if x != nil {
+		return x.ContainerId
+	}
+	return ""
+}
+
+func (x *Determinism_Finding) GetResult() []byte {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+func (x *Determinism_Finding) GetResultSha256() string {
+	if x != nil {
+		return x.ResultSha256
+	}
+	return ""
+}
+
+func (x *Determinism_Finding) GetResultSize() int64 {
+	if x != nil {
+		return x.ResultSize
+	}
+	return 0
+}
+
+func (x *Determinism_Finding) GetRecalculated() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Recalculated
+	}
+	return nil
+}
+
+func (x *Determinism_Finding) GetRecalculationContainerId() string {
+	if x != nil {
+		return x.RecalculationContainerId
+	}
+	return ""
+}
+
+func (x *Determinism_Finding) GetRecalculationResult() []byte {
+	if x != nil {
+		return x.RecalculationResult
+	}
+	return nil
+}
+
+func (x *Determinism_Finding) GetRecalculationResultSha256() string {
+	if x != nil {
+		return x.RecalculationResultSha256
+	}
+	return ""
+}
+
+func (x *Determinism_Finding) GetRecalculationResultSize() int64 {
+	if x != nil {
+		return x.RecalculationResultSize
+	}
+	return 0
+}
+
+func (x *Determinism_Finding) GetResultsTruncated() bool {
+	if x != nil {
+		return x.ResultsTruncated
+	}
+	return false
+}
+
+type Determinism_Response struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Sampled          int64                  `protobuf:"varint,1,opt,name=sampled,proto3" json:"sampled,omitempty"`
+	Pending          int64                  `protobuf:"varint,2,opt,name=pending,proto3" json:"pending,omitempty"` // Waiting for container restart.
+	Deterministic    int64                  `protobuf:"varint,3,opt,name=deterministic,proto3" json:"deterministic,omitempty"`
+	Nondeterministic int64                  `protobuf:"varint,4,opt,name=nondeterministic,proto3" json:"nondeterministic,omitempty"`
+	Failed           int64                  `protobuf:"varint,5,opt,name=failed,proto3" json:"failed,omitempty"`    // Recalculation failed.
+	Expired          int64                  `protobuf:"varint,6,opt,name=expired,proto3" json:"expired,omitempty"`  // Container was not restarted in time or was retired.
+	Findings         []*Determinism_Finding `protobuf:"bytes,7,rep,name=findings,proto3" json:"findings,omitempty"` // Nondeterministic results, the latest first.
+}
+
+func (x *Determinism_Response) Reset() {
+	*x = Determinism_Response{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Determinism_Response) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Determinism_Response) ProtoMessage() {}
+
+func (x *Determinism_Response) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Determinism_Response.ProtoReflect.Descriptor instead.
+func (*Determinism_Response) Descriptor() ([]byte, []int) {
+	return file_api_v1_proto_rawDescGZIP(), []int{4, 2}
+}
+
+func (x *Determinism_Response) GetSampled() int64 {
+	if x != nil {
+		return x.Sampled
+	}
+	return 0
+}
+
+func (x *Determinism_Response) GetPending() int64 {
+	if x != nil {
+		return x.Pending
+	}
+	return 0
+}
+
+func (x *Determinism_Response) GetDeterministic() int64 {
+	if x != nil {
+		return x.Deterministic
+	}
+	return 0
+}
+
+func (x *Determinism_Response) GetNondeterministic() int64 {
+	if x != nil {
+		return x.Nondeterministic
+	}
+	return 0
+}
+
+func (x *Determinism_Response) GetFailed() int64 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+func (x *Determinism_Response) GetExpired() int64 {
+	if x != nil {
+		return x.Expired
+	}
+	return 0
+}
+
+func (x *Determinism_Response) GetFindings() []*Determinism_Finding {
+	if x != nil {
+		return x.Findings
+	}
+	return nil
+}
+
+type Calculate_Request struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Params *Container_Params `protobuf:"bytes,1,opt,name=params,proto3" json:"params,omitempty"`
+	// Keep calculation running after client disconnect to store its result in cache.
+	Detach bool `protobuf:"varint,2,opt,name=detach,proto3" json:"detach,omitempty"`
+	// Calculation input of any size and content. Is sent to container in request body instead of URL.
+	InputBody []byte `protobuf:"bytes,3,opt,name=input_body,json=inputBody,proto3" json:"input_body,omitempty"`
+}
+
+func (x *Calculate_Request) Reset() {
+	*x = Calculate_Request{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Calculate_Request) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Calculate_Request) ProtoMessage() {}
+
+func (x *Calculate_Request) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Calculate_Request.ProtoReflect.Descriptor instead.
+func (*Calculate_Request) Descriptor() ([]byte, []int) {
+	return file_api_v1_proto_rawDescGZIP(), []int{5, 0}
+}
+
+func (x *Calculate_Request) GetParams() *Container_Params {
+	if x != nil {
+		return x.Params
+	}
+	return nil
+}
+
+func (x *Calculate_Request) GetDetach() bool {
+	if x != nil {
+		return x.Detach
+	}
+	return false
+}
+
+func (x *Calculate_Request) GetInputBody() []byte {
+	if x != nil {
+		return x.InputBody
+	}
+	return nil
+}
+
+type Calculate_Response struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Data []byte `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	// Image produced the result: its digest in registry ('repository@sha256:...') or local ID, if the image has no digest.
+	// Is reported in 'x-image-digest' response metadata too.
+	ImageDigest string `protobuf:"bytes,2,opt,name=image_digest,json=imageDigest,proto3" json:"image_digest,omitempty"`
+}
+
+func (x *Calculate_Response) Reset() {
+	*x = Calculate_Response{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Calculate_Response) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Calculate_Response) ProtoMessage() {}
+
+func (x *Calculate_Response) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Calculate_Response.ProtoReflect.Descriptor instead.
+func (*Calculate_Response) Descriptor() ([]byte, []int) {
+	return file_api_v1_proto_rawDescGZIP(), []int{5, 1}
+}
+
+func (x *Calculate_Response) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *Calculate_Response) GetImageDigest() string {
+	if x != nil {
+		return x.ImageDigest
+	}
+	return ""
+}
+
+type Container_Params struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Seed  string `protobuf:"bytes,1,opt,name=seed,proto3" json:"seed,omitempty"`
+	Input string `protobuf:"bytes,2,opt,name=input,proto3" json:"input,omitempty"`
+	// Named params of container besides seed, e.g. reference genome build or thread count.
+	// Containers with different params are separate. Params are passed to container by its image template.
+	Values map[string]string `protobuf:"bytes,3,rep,name=values,proto3" json:"values,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Compute service name. Calculation is routed to the default service when empty.
+	Service string `protobuf:"bytes,4,opt,name=service,proto3" json:"service,omitempty"`
+	// Tag, digest ('sha256:...' or 'repository@sha256:...') or ID of service image.
+	// The current service image is used when empty.
+	ImageVersion string `protobuf:"bytes,5,opt,name=image_version,json=imageVersion,proto3" json:"image_version,omitempty"`
+}
+
+func (x *Container_Params) Reset() {
+	*x = Container_Params{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Container_Params) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Container_Params) ProtoMessage() {}
+
+func (x *Container_Params) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Container_Params.ProtoReflect.Descriptor instead.
+func (*Container_Params) Descriptor() ([]byte, []int) {
+	return file_api_v1_proto_rawDescGZIP(), []int{6, 0}
+}
+
+func (x *Container_Params) GetSeed() string {
+	if x != nil {
+		return x.Seed
+	}
+	return ""
+}
+
+func (x *Container_Params) GetInput() string {
+	if x != nil {
+		return x.Input
+	}
+	return ""
+}
+
+func (x *Container_Params) GetValues() map[string]string {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+func (x *Container_Params) GetService() string {
+	if x != nil {
+		return x.Service
+	}
+	return ""
+}
+
+func (x *Container_Params) GetImageVersion() string {
+	if x != nil {
+		return x.ImageVersion
+	}
+	return ""
+}
+
+type Container_Info struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id     string            `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Addr   string            `protobuf:"bytes,2,opt,name=addr,proto3" json:"addr,omitempty"`
+	Params *Container_Params `protobuf:"bytes,3,opt,name=params,proto3" json:"params,omitempty"`
+	Status Container_Status  `protobuf:"varint,4,opt,name=status,proto3,enum=Zapuskator.API.v1.Container_Status" json:"status,omitempty"`
+	// Expected time left until container is ready to handle calculations.
+	ReadyIn *durationpb.Duration `protobuf:"bytes,5,opt,name=ready_in,json=readyIn,proto3" json:"ready_in,omitempty"`
+	// Container initialization progress in percents, reported by container itself.
+	Progress           int32                  `protobuf:"varint,6,opt,name=progress,proto3" json:"progress,omitempty"`
+	Created            *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created,proto3" json:"created,omitempty"`
+	Scheduled          *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=scheduled,proto3" json:"scheduled,omitempty"`
+	Started            *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=started,proto3" json:"started,omitempty"`
+	Stopped            *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=stopped,proto3" json:"stopped,omitempty"`
+	Updated            *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=updated,proto3" json:"updated,omitempty"`
+	LastUsed           *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=last_used,json=lastUsed,proto3" json:"last_used,omitempty"`
+	Pinned             bool                   `protobuf:"varint,13,opt,name=pinned,proto3" json:"pinned,omitempty"`
+	Draining           bool                   `protobuf:"varint,14,opt,name=draining,proto3" json:"draining,omitempty"`
+	ActiveCalculations int32                  `protobuf:"varint,15,opt,name=active_calculations,json=activeCalculations,proto3" json:"active_calculations,omitempty"`
+	ImageDigest        string                 `protobuf:"bytes,16,opt,name=image_digest,json=imageDigest,proto3" json:"image_digest,omitempty"` // Digest or ID of image container was created from.
+	Resources          *Container_Resources   `protobuf:"bytes,17,opt,name=resources,proto3" json:"resources,omitempty"`                        // Limits applied to container on creation.
+}
+
+func (x *Container_Info) Reset() {
+	*x = Container_Info{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Container_Info) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Container_Info) ProtoMessage() {}
+
+func (x *Container_Info) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Container_Info.ProtoReflect.Descriptor instead.
+func (*Container_Info) Descriptor() ([]byte, []int) {
+	return file_api_v1_proto_rawDescGZIP(), []int{6, 1}
+}
+
+func (x *Container_Info) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Container_Info) GetAddr() string {
+	if x != nil {
+		return x.Addr
+	}
+	return ""
+}
+
+func (x *Container_Info) GetParams() *Container_Params {
+	if x != nil {
+		return x.Params
+	}
+	return nil
+}
+
+func (x *Container_Info) GetStatus() Container_Status {
+	if x != nil {
+		return x.Status
+	}
+	return Container_NEW
+}
+
+func (x *Container_Info) GetReadyIn() *durationpb.Duration {
+	if x != nil {
+		return x.ReadyIn
+	}
+	return nil
+}
+
+func (x *Container_Info) GetProgress() int32 {
+	if x != nil {
+		return x.Progress
+	}
+	return 0
+}
+
+func (x *Container_Info) GetCreated() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Created
+	}
+	return nil
+}
+
+func (x *Container_Info) GetScheduled() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Scheduled
+	}
+	return nil
+}
+
+func (x *Container_Info) GetStarted() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Started
+	}
+	return nil
+}
+
+func (x *Container_Info) GetStopped() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Stopped
+	}
+	return nil
+}
+
+func (x *Container_Info) GetUpdated() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Updated
+	}
+	return nil
+}
+
+func (x *Container_Info) GetLastUsed() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastUsed
+	}
+	return nil
+}
+
+func (x *Container_Info) GetPinned() bool {
+	if x != nil {
+		return x.Pinned
+	}
+	return false
+}
+
+func (x *Container_Info) GetDraining() bool {
+	if x != nil {
+		return x.Draining
+	}
+	return false
+}
+
+func (x *Container_Info) GetActiveCalculations() int32 {
+	if x != nil {
+		return x.ActiveCalculations
+	}
+	return 0
+}
+
+func (x *Container_Info) GetImageDigest() string {
+	if x != nil {
+		return x.ImageDigest
+	}
+	return ""
+}
+
+func (x *Container_Info) GetResources() *Container_Resources {
+	if x != nil {
+		return x.Resources
+	}
+	return nil
+}
+
+// Host resources limits of container. Zero values mean no limit.
+type Container_Resources struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CpuShares         int64               `protobuf:"varint,1,opt,name=cpu_shares,json=cpuShares,proto3" json:"cpu_shares,omitempty"`                         // Relative CPU weight against other containers.
+	CpuQuota          int64               `protobuf:"varint,2,opt,name=cpu_quota,json=cpuQuota,proto3" json:"cpu_quota,omitempty"`                            // CPU time in microseconds, the container may use per CPU period.
+	CpuPeriod         int64               `protobuf:"varint,3,opt,name=cpu_period,json=cpuPeriod,proto3" json:"cpu_period,omitempty"`                         // Microseconds.
+	Memory            int64               `protobuf:"varint,4,opt,name=memory,proto3" json:"memory,omitempty"`                                                // Bytes.
+	MemoryReservation int64               `protobuf:"varint,5,opt,name=memory_reservation,json=memoryReservation,proto3" json:"memory_reservation,omitempty"` // Memory soft limit in bytes.
+	PidsLimit         int64               `protobuf:"varint,6,opt,name=pids_limit,json=pidsLimit,proto3" json:"pids_limit,omitempty"`
+	Ulimits           []*Container_Ulimit `protobuf:"bytes,7,rep,name=ulimits,proto3" json:"ulimits,omitempty"`
+	Cores             int64               `protobuf:"varint,8,opt,name=cores,proto3" json:"cores,omitempty"`  // Number of cores dedicated to container, when CPU pinning is enabled.
+	Cpuset            string              `protobuf:"bytes,9,opt,name=cpuset,proto3" json:"cpuset,omitempty"` // Cores assigned to container on its start. Empty when container shares cores with others.
+}
+
+func (x *Container_Resources) Reset() {
+	*x = Container_Resources{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Container_Resources) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Container_Resources) ProtoMessage() {}
+
+func (x *Container_Resources) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Container_Resources.ProtoReflect.Descriptor instead.
+func (*Container_Resources) Descriptor() ([]byte, []int) {
+	return file_api_v1_proto_rawDescGZIP(), []int{6, 2}
+}
+
+func (x *Container_Resources) GetCpuShares() int64 {
+	if x != nil {
+		return x.CpuShares
+	}
+	return 0
+}
+
+func (x *Container_Resources) GetCpuQuota() int64 {
+	if x != nil {
+		return x.CpuQuota
+	}
+	return 0
+}
+
+func (x *Container_Resources) GetCpuPeriod() int64 {
+	if x != nil {
+		return x.CpuPeriod
+	}
+	return 0
+}
+
+func (x *Container_Resources) GetMemory() int64 {
+	if x != nil {
+		return x.Memory
+	}
+	return 0
+}
+
+func (x *Container_Resources) GetMemoryReservation() int64 {
+	if x != nil {
+		return x.MemoryReservation
+	}
+	return 0
+}
+
+func (x *Container_Resources) GetPidsLimit() int64 {
+	if x != nil {
+		return x.PidsLimit
+	}
+	return 0
+}
+
+func (x *Container_Resources) GetUlimits() []*Container_Ulimit {
+	if x != nil {
+		return x.Ulimits
+	}
+	return nil
+}
+
+func (x *Container_Resources) GetCores() int64 {
+	if x != nil {
+		return x.Cores
+	}
+	return 0
+}
+
+func (x *Container_Resources) GetCpuset() string {
+	if x != nil {
+		return x.Cpuset
+	}
+	return ""
+}
+
+type Container_Ulimit struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Soft int64  `protobuf:"varint,2,opt,name=soft,proto3" json:"soft,omitempty"`
+	Hard int64  `protobuf:"varint,3,opt,name=hard,proto3" json:"hard,omitempty"`
+}
+
+func (x *Container_Ulimit) Reset() {
+	*x = Container_Ulimit{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Container_Ulimit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Container_Ulimit) ProtoMessage() {}
+
+func (x *Container_Ulimit) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Container_Ulimit.ProtoReflect.Descriptor instead.
+func (*Container_Ulimit) Descriptor() ([]byte, []int) {
+	return file_api_v1_proto_rawDescGZIP(), []int{6, 3}
+}
+
+func (x *Container_Ulimit) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Container_Ulimit) GetSoft() int64 {
+	if x != nil {
+		return x.Soft
+	}
+	return 0
+}
+
+func (x *Container_Ulimit) GetHard() int64 {
+	if x != nil {
+		return x.Hard
+	}
+	return 0
+}
+
+type Container_Request struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *Container_Request) Reset() {
+	*x = Container_Request{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Container_Request) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Container_Request) ProtoMessage() {}
+
+func (x *Container_Request) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Container_Request.ProtoReflect.Descriptor instead.
+func (*Container_Request) Descriptor() ([]byte, []int) {
+	return file_api_v1_proto_rawDescGZIP(), []int{6, 4}
+}
+
+func (x *Container_Request) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type Container_Response struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Info *Container_Info `protobuf:"bytes,1,opt,name=info,proto3" json:"info,omitempty"`
+}
+
+func (x *Container_Response) Reset() {
+	*x = Container_Response{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Container_Response) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Container_Response) ProtoMessage() {}
+
+func (x *Container_Response) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Container_Response.ProtoReflect.Descriptor instead.
+func (*Container_Response) Descriptor() ([]byte, []int) {
+	return file_api_v1_proto_rawDescGZIP(), []int{6, 5}
+}
+
+func (x *Container_Response) GetInfo() *Container_Info {
+	if x != nil {
+		return x.Info
+	}
+	return nil
+}
+
+type ContainerHistory_Transition struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Time  *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=time,proto3" json:"time,omitempty"`
+	From  Container_Status       `protobuf:"varint,2,opt,name=from,proto3,enum=Zapuskator.API.v1.Container_Status" json:"from,omitempty"`
+	To    Container_Status       `protobuf:"varint,3,opt,name=to,proto3,enum=Zapuskator.API.v1.Container_Status" json:"to,omitempty"`
+	Actor string                 `protobuf:"bytes,4,opt,name=actor,proto3" json:"actor,omitempty"` // Part of service performed the transition: api, background or admin.
+	Error string                 `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"` // Reason of transition failure. Status was not changed when set.
+}
+
+func (x *ContainerHistory_Transition) Reset() {
+	*x = ContainerHistory_Transition{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ContainerHistory_Transition) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ContainerHistory_Transition) ProtoMessage() {}
+
+func (x *ContainerHistory_Transition) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ContainerHistory_Transition.ProtoReflect.Descriptor instead.
+func (*ContainerHistory_Transition) Descriptor() ([]byte, []int) {
+	return file_api_v1_proto_rawDescGZIP(), []int{7, 0}
+}
+
+func (x *ContainerHistory_Transition) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *ContainerHistory_Transition) GetFrom() Container_Status {
+	if x != nil {
+		return x.From
+	}
+	return Container_NEW
+}
+
+func (x *ContainerHistory_Transition) GetTo() Container_Status {
+	if x != nil {
+		return x.To
+	}
+	return Container_NEW
+}
+
+func (x *ContainerHistory_Transition) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *ContainerHistory_Transition) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type ContainerHistory_Response struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Transitions []*ContainerHistory_Transition `protobuf:"bytes,1,rep,name=transitions,proto3" json:"transitions,omitempty"` // Oldest first.
+}
+
+func (x *ContainerHistory_Response) Reset() {
+	*x = ContainerHistory_Response{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ContainerHistory_Response) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ContainerHistory_Response) ProtoMessage() {}
+
+func (x *ContainerHistory_Response) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ContainerHistory_Response.ProtoReflect.Descriptor instead.
+func (*ContainerHistory_Response) Descriptor() ([]byte, []int) {
+	return file_api_v1_proto_rawDescGZIP(), []int{7, 1}
+}
+
+func (x *ContainerHistory_Response) GetTransitions() []*ContainerHistory_Transition {
+	if x != nil {
+		return x.Transitions
+	}
+	return nil
+}
+
+type ListContainers_Request struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Filters. Containers matching all of them are listed.
+	Statuses  []Container_Status   `protobuf:"varint,1,rep,packed,name=statuses,proto3,enum=Zapuskator.API.v1.Container_Status" json:"statuses,omitempty"`
+	Seed      string               `protobuf:"bytes,2,opt,name=seed,proto3" json:"seed,omitempty"`
+	IdleFor   *durationpb.Duration `protobuf:"bytes,3,opt,name=idle_for,json=idleFor,proto3" json:"idle_for,omitempty"` // Minimum time since container was used last time.
+	Service   string               `protobuf:"bytes,6,opt,name=service,proto3" json:"service,omitempty"`
+	PageSize  int32                `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken string               `protobuf:"bytes,5,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+}
+
+func (x *ListContainers_Request) Reset() {
+	*x = ListContainers_Request{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_proto_msgTypes[34]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListContainers_Request) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListContainers_Request) ProtoMessage() {}
+
+func (x *ListContainers_Request) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_proto_msgTypes[34]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListContainers_Request.ProtoReflect.Descriptor instead.
+func (*ListContainers_Request) Descriptor() ([]byte, []int) {
+	return file_api_v1_proto_rawDescGZIP(), []int{8, 0}
+}
+
+func (x *ListContainers_Request) GetStatuses() []Container_Status {
+	if x != nil {
+		return x.Statuses
+	}
+	return nil
+}
+
+func (x *ListContainers_Request) GetSeed() string {
+	if x != nil {
+		return x.Seed
+	}
+	return ""
+}
+
+func (x *ListContainers_Request) GetIdleFor() *durationpb.Duration {
+	if x != nil {
+		return x.IdleFor
+	}
+	return nil
+}
+
+func (x *ListContainers_Request) GetService() string {
+	if x != nil {
+		return x.Service
+	}
+	return ""
+}
+
+func (x *ListContainers_Request) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListContainers_Request) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListContainers_Response struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Containers    []*Container_Info `protobuf:"bytes,1,rep,name=containers,proto3" json:"containers,omitempty"`
+	NextPageToken string            `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+}
+
+func (x *ListContainers_Response) Reset() {
+	*x = ListContainers_Response{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_proto_msgTypes[35]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListContainers_Response) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListContainers_Response) ProtoMessage() {}
+
+func (x *ListContainers_Response) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_proto_msgTypes[35]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListContainers_Response.ProtoReflect.Descriptor instead.
+func (*ListContainers_Response) Descriptor() ([]byte, []int) {
+	return file_api_v1_proto_rawDescGZIP(), []int{8, 1}
+}
+
+func (x *ListContainers_Response) GetContainers() []*Container_Info {
+	if x != nil {
+		return x.Containers
+	}
+	return nil
+}
+
+func (x *ListContainers_Response) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type WatchContainers_Request struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Seeds []string `protobuf:"bytes,1,rep,name=seeds,proto3" json:"seeds,omitempty"` // Watch containers of these seeds only. All containers are watched when empty.
+	// Watch the single container only, seeds are ignored. Is required to resume the watch.
+	ContainerId string `protobuf:"bytes,2,opt,name=container_id,json=containerId,proto3" json:"container_id,omitempty"`
+	// Resume the container watch after transition with this sequence number: missed transitions are sent
+	// without snapshot. Fails with OUT_OF_RANGE, when they are not kept anymore.
+	FromSeq uint64 `protobuf:"varint,3,opt,name=from_seq,json=fromSeq,proto3" json:"from_seq,omitempty"`
+}
+
+func (x *WatchContainers_Request) Reset() {
+	*x = WatchContainers_Request{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_proto_msgTypes[36]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WatchContainers_Request) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchContainers_Request) ProtoMessage() {}
+
+func (x *WatchContainers_Request) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_proto_msgTypes[36]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchContainers_Request.ProtoReflect.Descriptor instead.
+func (*WatchContainers_Request) Descriptor() ([]byte, []int) {
+	return file_api_v1_proto_rawDescGZIP(), []int{9, 0}
+}
+
+func (x *WatchContainers_Request) GetSeeds() []string {
+	if x != nil {
+		return x.Seeds
+	}
+	return nil
+}
+
+func (x *WatchContainers_Request) GetContainerId() string {
+	if x != nil {
+		return x.ContainerId
+	}
+	return ""
+}
+
+func (x *WatchContainers_Request) GetFromSeq() uint64 {
+	if x != nil {
+		return x.FromSeq
+	}
+	return 0
+}
+
+type WatchContainers_Event struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type      WatchContainers_Event_Type `protobuf:"varint,1,opt,name=type,proto3,enum=Zapuskator.API.v1.WatchContainers_Event_Type" json:"type,omitempty"`
+	Info      *Container_Info            `protobuf:"bytes,2,opt,name=info,proto3" json:"info,omitempty"`
+	OldStatus Container_Status           `protobuf:"varint,3,opt,name=old_status,json=oldStatus,proto3,enum=Zapuskator.API.v1.Container_Status" json:"old_status,omitempty"`
+	Time      *timestamppb.Timestamp     `protobuf:"bytes,4,opt,name=time,proto3" json:"time,omitempty"`
+	// Sequence number of the container transition. Snapshot of the single container has the number
+	// of its last transition. Is zero for snapshots of all containers.
+	Seq uint64 `protobuf:"varint,5,opt,name=seq,proto3" json:"seq,omitempty"`
+}
+
+func (x *WatchContainers_Event) Reset() {
+	*x = WatchContainers_Event{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_proto_msgTypes[37]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WatchContainers_Event) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchContainers_Event) ProtoMessage() {}
+
+func (x *WatchContainers_Event) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_proto_msgTypes[37]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchContainers_Event.ProtoReflect.Descriptor instead.
+func (*WatchContainers_Event) Descriptor() ([]byte, []int) {
+	return file_api_v1_proto_rawDescGZIP(), []int{9, 1}
+}
+
+func (x *WatchContainers_Event) GetType() WatchContainers_Event_Type {
+	if x != nil {
+		return x.Type
+	}
+	return WatchContainers_Event_SNAPSHOT
+}
+
+func (x *WatchContainers_Event) GetInfo() *Container_Info {
+	if x != nil {
+		return x.Info
+	}
+	return nil
+}
+
+func (x *WatchContainers_Event) GetOldStatus() Container_Status {
+	if x != nil {
+		return x.OldStatus
+	}
+	return Container_NEW
+}
+
+func (x *WatchContainers_Event) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *WatchContainers_Event) GetSeq() uint64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
 var File_api_v1_proto protoreflect.FileDescriptor
 
 var file_api_v1_proto_rawDesc = []byte{
 	0x0a, 0x0c, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x11,
 	0x5a, 0x61, 0x70, 0x75, 0x73, 0x6b, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x41, 0x50, 0x49, 0x2e, 0x76,
 	0x31, 0x1a, 0x1c, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x61, 0x6e,
-	0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22,
-	0x73, 0x0a, 0x09, 0x43, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x65, 0x1a, 0x46, 0x0a, 0x07,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x3b, 0x0a, 0x06, 0x70, 0x61, 0x72, 0x61, 0x6d,
-	0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x5a, 0x61, 0x70, 0x75, 0x73, 0x6b,
+	0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a,
+	0x1e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a,
+	0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x22, 0xaa, 0x02, 0x0a, 0x05, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x1a, 0xdd, 0x01, 0x0a, 0x07, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x65, 0x65, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x73, 0x65, 0x65, 0x64, 0x12, 0x44, 0x0a, 0x06, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2c, 0x2e, 0x5a, 0x61, 0x70,
+	0x75, 0x73, 0x6b, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x41, 0x50, 0x49, 0x2e, 0x76, 0x31, 0x2e, 0x41,
+	0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x56, 0x61, 0x6c,
+	0x75, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73,
+	0x12, 0x18, 0x0a, 0x07, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x69, 0x6d,
+	0x61, 0x67, 0x65, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0c, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x1a,
+	0x39, 0x0a, 0x0b, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10,
+	0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79,
+	0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x41, 0x0a, 0x08, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x35, 0x0a, 0x04, 0x69, 0x6e, 0x66, 0x6f, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x5a, 0x61, 0x70, 0x75, 0x73, 0x6b, 0x61, 0x74, 0x6f,
+	0x72, 0x2e, 0x41, 0x50, 0x49, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e,
+	0x65, 0x72, 0x2e, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x04, 0x69, 0x6e, 0x66, 0x6f, 0x22, 0xae, 0x02,
+	0x0a, 0x07, 0x55, 0x70, 0x67, 0x72, 0x61, 0x64, 0x65, 0x1a, 0x48, 0x0a, 0x07, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x23,
+	0x0a, 0x0d, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x56, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x1a, 0xd8, 0x01, 0x0a, 0x08, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x28, 0x0a, 0x10, 0x6f, 0x6c, 0x64, 0x5f, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x5f, 0x64, 0x69,
+	0x67, 0x65, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x6f, 0x6c, 0x64, 0x49,
+	0x6d, 0x61, 0x67, 0x65, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x12, 0x28, 0x0a, 0x10, 0x6e, 0x65,
+	0x77, 0x5f, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x5f, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x6e, 0x65, 0x77, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x44, 0x69,
+	0x67, 0x65, 0x73, 0x74, 0x12, 0x3b, 0x0a, 0x07, 0x73, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x18,
+	0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x5a, 0x61, 0x70, 0x75, 0x73, 0x6b, 0x61, 0x74,
+	0x6f, 0x72, 0x2e, 0x41, 0x50, 0x49, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69,
+	0x6e, 0x65, 0x72, 0x2e, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x07, 0x73, 0x74, 0x61, 0x72, 0x74, 0x65,
+	0x64, 0x12, 0x3b, 0x0a, 0x07, 0x72, 0x65, 0x74, 0x69, 0x72, 0x65, 0x64, 0x18, 0x04, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x21, 0x2e, 0x5a, 0x61, 0x70, 0x75, 0x73, 0x6b, 0x61, 0x74, 0x6f, 0x72, 0x2e,
+	0x41, 0x50, 0x49, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72,
+	0x2e, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x07, 0x72, 0x65, 0x74, 0x69, 0x72, 0x65, 0x64, 0x22, 0xc3,
+	0x01, 0x0a, 0x06, 0x53, 0x68, 0x61, 0x64, 0x6f, 0x77, 0x1a, 0x69, 0x0a, 0x07, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x23,
+	0x0a, 0x0d, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x56, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x5f, 0x72, 0x61,
+	0x74, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0a, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65,
+	0x52, 0x61, 0x74, 0x65, 0x1a, 0x4e, 0x0a, 0x08, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x21, 0x0a, 0x0c, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x5f, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x44, 0x69, 0x67,
+	0x65, 0x73, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x5f, 0x72, 0x61,
+	0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0a, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65,
+	0x52, 0x61, 0x74, 0x65, 0x22, 0xf9, 0x06, 0x0a, 0x0c, 0x53, 0x68, 0x61, 0x64, 0x6f, 0x77, 0x52,
+	0x65, 0x70, 0x6f, 0x72, 0x74, 0x1a, 0x23, 0x0a, 0x07, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x18, 0x0a, 0x07, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x1a, 0xa0, 0x04, 0x0a, 0x08, 0x4d,
+	0x69, 0x73, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x12, 0x2e, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x3b, 0x0a, 0x06, 0x70, 0x61, 0x72, 0x61, 0x6d,
+	0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x5a, 0x61, 0x70, 0x75, 0x73, 0x6b,
 	0x61, 0x74, 0x6f, 0x72, 0x2e, 0x41, 0x50, 0x49, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x74,
 	0x61, 0x69, 0x6e, 0x65, 0x72, 0x2e, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x52, 0x06, 0x70, 0x61,
-	0x72, 0x61, 0x6d, 0x73, 0x1a, 0x1e, 0x0a, 0x08, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04,
-	0x64, 0x61, 0x74, 0x61, 0x22, 0xb7, 0x03, 0x0a, 0x09, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e,
-	0x65, 0x72, 0x1a, 0x32, 0x0a, 0x06, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x12, 0x12, 0x0a, 0x04,
-	0x73, 0x65, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x73, 0x65, 0x65, 0x64,
-	0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x05, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x1a, 0xa4, 0x01, 0x0a, 0x04, 0x49, 0x6e, 0x66, 0x6f, 0x12,
-	0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12,
-	0x12, 0x0a, 0x04, 0x61, 0x64, 0x64, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x61,
-	0x64, 0x64, 0x72, 0x12, 0x3b, 0x0a, 0x06, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x18, 0x03, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x5a, 0x61, 0x70, 0x75, 0x73, 0x6b, 0x61, 0x74, 0x6f, 0x72,
+	0x72, 0x61, 0x6d, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x5f, 0x62, 0x6f,
+	0x64, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x42,
+	0x6f, 0x64, 0x79, 0x12, 0x27, 0x0a, 0x0f, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x5f, 0x74, 0x72, 0x75,
+	0x6e, 0x63, 0x61, 0x74, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x69, 0x6e,
+	0x70, 0x75, 0x74, 0x54, 0x72, 0x75, 0x6e, 0x63, 0x61, 0x74, 0x65, 0x64, 0x12, 0x30, 0x0a, 0x14,
+	0x70, 0x72, 0x69, 0x6d, 0x61, 0x72, 0x79, 0x5f, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x5f, 0x64, 0x69,
+	0x67, 0x65, 0x73, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x12, 0x70, 0x72, 0x69, 0x6d,
+	0x61, 0x72, 0x79, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x12, 0x32,
+	0x0a, 0x15, 0x70, 0x72, 0x69, 0x6d, 0x61, 0x72, 0x79, 0x5f, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74,
+	0x5f, 0x73, 0x68, 0x61, 0x32, 0x35, 0x36, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x13, 0x70,
+	0x72, 0x69, 0x6d, 0x61, 0x72, 0x79, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x53, 0x68, 0x61, 0x32,
+	0x35, 0x36, 0x12, 0x2e, 0x0a, 0x13, 0x70, 0x72, 0x69, 0x6d, 0x61, 0x72, 0x79, 0x5f, 0x72, 0x65,
+	0x73, 0x75, 0x6c, 0x74, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x11, 0x70, 0x72, 0x69, 0x6d, 0x61, 0x72, 0x79, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x53, 0x69,
+	0x7a, 0x65, 0x12, 0x34, 0x0a, 0x16, 0x63, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x5f,
+	0x69, 0x6d, 0x61, 0x67, 0x65, 0x5f, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x08, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x14, 0x63, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x49, 0x6d, 0x61,
+	0x67, 0x65, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x12, 0x36, 0x0a, 0x17, 0x63, 0x61, 0x6e, 0x64,
+	0x69, 0x64, 0x61, 0x74, 0x65, 0x5f, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x5f, 0x73, 0x68, 0x61,
+	0x32, 0x35, 0x36, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x15, 0x63, 0x61, 0x6e, 0x64, 0x69,
+	0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x53, 0x68, 0x61, 0x32, 0x35, 0x36,
+	0x12, 0x32, 0x0a, 0x15, 0x63, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x5f, 0x72, 0x65,
+	0x73, 0x75, 0x6c, 0x74, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x13, 0x63, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74,
+	0x53, 0x69, 0x7a, 0x65, 0x12, 0x27, 0x0a, 0x0f, 0x63, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74,
+	0x65, 0x5f, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x63,
+	0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x1a, 0xa0, 0x02,
+	0x0a, 0x08, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x69, 0x6d,
+	0x61, 0x67, 0x65, 0x5f, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0b, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x12, 0x1f, 0x0a,
+	0x0b, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x5f, 0x72, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x0a, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x52, 0x61, 0x74, 0x65, 0x12, 0x1a,
+	0x0a, 0x08, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x72, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x08, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x72, 0x65, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x61,
+	0x74, 0x63, 0x68, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x6d, 0x61, 0x74,
+	0x63, 0x68, 0x65, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x6d, 0x69, 0x73, 0x6d, 0x61, 0x74, 0x63, 0x68,
+	0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x6d, 0x69, 0x73, 0x6d, 0x61, 0x74,
+	0x63, 0x68, 0x65, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x12, 0x18, 0x0a, 0x07,
+	0x73, 0x6b, 0x69, 0x70, 0x70, 0x65, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x73,
+	0x6b, 0x69, 0x70, 0x70, 0x65, 0x64, 0x12, 0x48, 0x0a, 0x0a, 0x6d, 0x69, 0x73, 0x6d, 0x61, 0x74,
+	0x63, 0x68, 0x65, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x28, 0x2e, 0x5a, 0x61, 0x70,
+	0x75, 0x73, 0x6b, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x41, 0x50, 0x49, 0x2e, 0x76, 0x31, 0x2e, 0x53,
+	0x68, 0x61, 0x64, 0x6f, 0x77, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x4d, 0x69, 0x73, 0x6d,
+	0x61, 0x74, 0x63, 0x68, 0x52, 0x0a, 0x6d, 0x69, 0x73, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x73,
+	0x22, 0x86, 0x08, 0x0a, 0x0b, 0x44, 0x65, 0x74, 0x65, 0x72, 0x6d, 0x69, 0x6e, 0x69, 0x73, 0x6d,
+	0x1a, 0x23, 0x0a, 0x07, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x73,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x73, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x1a, 0xc8, 0x05, 0x0a, 0x07, 0x46, 0x69, 0x6e, 0x64, 0x69, 0x6e,
+	0x67, 0x12, 0x3b, 0x0a, 0x06, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x23, 0x2e, 0x5a, 0x61, 0x70, 0x75, 0x73, 0x6b, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x41,
+	0x50, 0x49, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x2e,
+	0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x52, 0x06, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x12, 0x1d,
+	0x0a, 0x0a, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x5f, 0x62, 0x6f, 0x64, 0x79, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x09, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x42, 0x6f, 0x64, 0x79, 0x12, 0x27, 0x0a,
+	0x0f, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x5f, 0x74, 0x72, 0x75, 0x6e, 0x63, 0x61, 0x74, 0x65, 0x64,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x54, 0x72, 0x75,
+	0x6e, 0x63, 0x61, 0x74, 0x65, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x5f,
+	0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x69, 0x6d,
+	0x61, 0x67, 0x65, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x12, 0x3a, 0x0a, 0x0a, 0x63, 0x61, 0x6c,
+	0x63, 0x75, 0x6c, 0x61, 0x74, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0a, 0x63, 0x61, 0x6c, 0x63, 0x75,
+	0x6c, 0x61, 0x74, 0x65, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e,
+	0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x6f, 0x6e,
+	0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75,
+	0x6c, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74,
+	0x12, 0x23, 0x0a, 0x0d, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x5f, 0x73, 0x68, 0x61, 0x32, 0x35,
+	0x36, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x53,
+	0x68, 0x61, 0x32, 0x35, 0x36, 0x12, 0x1f, 0x0a, 0x0b, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x5f,
+	0x73, 0x69, 0x7a, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x72, 0x65, 0x73, 0x75,
+	0x6c, 0x74, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x3e, 0x0a, 0x0c, 0x72, 0x65, 0x63, 0x61, 0x6c, 0x63,
+	0x75, 0x6c, 0x61, 0x74, 0x65, 0x64, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0c, 0x72, 0x65, 0x63, 0x61, 0x6c, 0x63,
+	0x75, 0x6c, 0x61, 0x74, 0x65, 0x64, 0x12, 0x3c, 0x0a, 0x1a, 0x72, 0x65, 0x63, 0x61, 0x6c, 0x63,
+	0x75, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65,
+	0x72, 0x5f, 0x69, 0x64, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x18, 0x72, 0x65, 0x63, 0x61,
+	0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e,
+	0x65, 0x72, 0x49, 0x64, 0x12, 0x31, 0x0a, 0x14, 0x72, 0x65, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x0c, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x13, 0x72, 0x65, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x3e, 0x0a, 0x1b, 0x72, 0x65, 0x63, 0x61, 0x6c,
+	0x63, 0x75, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x5f,
+	0x73, 0x68, 0x61, 0x32, 0x35, 0x36, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x52, 0x19, 0x72, 0x65,
+	0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x75, 0x6c,
+	0x74, 0x53, 0x68, 0x61, 0x32, 0x35, 0x36, 0x12, 0x3a, 0x0a, 0x19, 0x72, 0x65, 0x63, 0x61, 0x6c,
+	0x63, 0x75, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x5f,
+	0x73, 0x69, 0x7a, 0x65, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x03, 0x52, 0x17, 0x72, 0x65, 0x63, 0x61,
+	0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x53,
+	0x69, 0x7a, 0x65, 0x12, 0x2b, 0x0a, 0x11, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x5f, 0x74,
+	0x72, 0x75, 0x6e, 0x63, 0x61, 0x74, 0x65, 0x64, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x08, 0x52, 0x10,
+	0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x54, 0x72, 0x75, 0x6e, 0x63, 0x61, 0x74, 0x65, 0x64,
+	0x1a, 0x86, 0x02, 0x0a, 0x08, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a,
+	0x07, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07,
+	0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x65, 0x6e, 0x64, 0x69,
+	0x6e, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x70, 0x65, 0x6e, 0x64, 0x69, 0x6e,
+	0x67, 0x12, 0x24, 0x0a, 0x0d, 0x64, 0x65, 0x74, 0x65, 0x72, 0x6d, 0x69, 0x6e, 0x69, 0x73, 0x74,
+	0x69, 0x63, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x64, 0x65, 0x74, 0x65, 0x72, 0x6d,
+	0x69, 0x6e, 0x69, 0x73, 0x74, 0x69, 0x63, 0x12, 0x2a, 0x0a, 0x10, 0x6e, 0x6f, 0x6e, 0x64, 0x65,
+	0x74, 0x65, 0x72, 0x6d, 0x69, 0x6e, 0x69, 0x73, 0x74, 0x69, 0x63, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x10, 0x6e, 0x6f, 0x6e, 0x64, 0x65, 0x74, 0x65, 0x72, 0x6d, 0x69, 0x6e, 0x69, 0x73,
+	0x74, 0x69, 0x63, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x06, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x65,
+	0x78, 0x70, 0x69, 0x72, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x65, 0x78,
+	0x70, 0x69, 0x72, 0x65, 0x64, 0x12, 0x42, 0x0a, 0x08, 0x66, 0x69, 0x6e, 0x64, 0x69, 0x6e, 0x67,
+	0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x5a, 0x61, 0x70, 0x75, 0x73, 0x6b,
+	0x61, 0x74, 0x6f, 0x72, 0x2e, 0x41, 0x50, 0x49, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x74, 0x65,
+	0x72, 0x6d, 0x69, 0x6e, 0x69, 0x73, 0x6d, 0x2e, 0x46, 0x69, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x52,
+	0x08, 0x66, 0x69, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x73, 0x22, 0xcd, 0x01, 0x0a, 0x09, 0x43, 0x61,
+	0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x65, 0x1a, 0x7d, 0x0a, 0x07, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x3b, 0x0a, 0x06, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x23, 0x2e, 0x5a, 0x61, 0x70, 0x75, 0x73, 0x6b, 0x61, 0x74, 0x6f, 0x72, 0x2e,
+	0x41, 0x50, 0x49, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72,
+	0x2e, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x52, 0x06, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x12,
+	0x16, 0x0a, 0x06, 0x64, 0x65, 0x74, 0x61, 0x63, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x06, 0x64, 0x65, 0x74, 0x61, 0x63, 0x68, 0x12, 0x1d, 0x0a, 0x0a, 0x69, 0x6e, 0x70, 0x75, 0x74,
+	0x5f, 0x62, 0x6f, 0x64, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x69, 0x6e, 0x70,
+	0x75, 0x74, 0x42, 0x6f, 0x64, 0x79, 0x1a, 0x41, 0x0a, 0x08, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x21, 0x0a, 0x0c, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x5f,
+	0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x69, 0x6d,
+	0x61, 0x67, 0x65, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x22, 0x86, 0x0d, 0x0a, 0x09, 0x43, 0x6f,
+	0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x1a, 0xf5, 0x01, 0x0a, 0x06, 0x50, 0x61, 0x72, 0x61,
+	0x6d, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x65, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x73, 0x65, 0x65, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x12, 0x47, 0x0a, 0x06,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2f, 0x2e, 0x5a,
+	0x61, 0x70, 0x75, 0x73, 0x6b, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x41, 0x50, 0x49, 0x2e, 0x76, 0x31,
+	0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x2e, 0x50, 0x61, 0x72, 0x61, 0x6d,
+	0x73, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12,
+	0x23, 0x0a, 0x0d, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x56, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x1a, 0x39, 0x0a, 0x0b, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a,
+	0x8f, 0x06, 0x0a, 0x04, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x61, 0x64, 0x64, 0x72,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x61, 0x64, 0x64, 0x72, 0x12, 0x3b, 0x0a, 0x06,
+	0x70, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x5a,
+	0x61, 0x70, 0x75, 0x73, 0x6b, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x41, 0x50, 0x49, 0x2e, 0x76, 0x31,
+	0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x2e, 0x50, 0x61, 0x72, 0x61, 0x6d,
+	0x73, 0x52, 0x06, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x12, 0x3b, 0x0a, 0x06, 0x73, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x23, 0x2e, 0x5a, 0x61, 0x70, 0x75,
+	0x73, 0x6b, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x41, 0x50, 0x49, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f,
+	0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06,
+	0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x34, 0x0a, 0x08, 0x72, 0x65, 0x61, 0x64, 0x79, 0x5f,
+	0x69, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x52, 0x07, 0x72, 0x65, 0x61, 0x64, 0x79, 0x49, 0x6e, 0x12, 0x1a, 0x0a, 0x08,
+	0x70, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08,
+	0x70, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x12, 0x34, 0x0a, 0x07, 0x63, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x07, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x12, 0x38,
+	0x0a, 0x09, 0x73, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x73,
+	0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x12, 0x34, 0x0a, 0x07, 0x73, 0x74, 0x61, 0x72,
+	0x74, 0x65, 0x64, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x07, 0x73, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x12, 0x34,
+	0x0a, 0x07, 0x73, 0x74, 0x6f, 0x70, 0x70, 0x65, 0x64, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x07, 0x73, 0x74, 0x6f,
+	0x70, 0x70, 0x65, 0x64, 0x12, 0x34, 0x0a, 0x07, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x18,
+	0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x52, 0x07, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x12, 0x37, 0x0a, 0x09, 0x6c, 0x61,
+	0x73, 0x74, 0x5f, 0x75, 0x73, 0x65, 0x64, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x08, 0x6c, 0x61, 0x73, 0x74, 0x55,
+	0x73, 0x65, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x69, 0x6e, 0x6e, 0x65, 0x64, 0x18, 0x0d, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x06, 0x70, 0x69, 0x6e, 0x6e, 0x65, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x64,
+	0x72, 0x61, 0x69, 0x6e, 0x69, 0x6e, 0x67, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x64,
+	0x72, 0x61, 0x69, 0x6e, 0x69, 0x6e, 0x67, 0x12, 0x2f, 0x0a, 0x13, 0x61, 0x63, 0x74, 0x69, 0x76,
+	0x65, 0x5f, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x0f,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x12, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x43, 0x61, 0x6c, 0x63,
+	0x75, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x69, 0x6d, 0x61, 0x67,
+	0x65, 0x5f, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x10, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b,
+	0x69, 0x6d, 0x61, 0x67, 0x65, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x12, 0x44, 0x0a, 0x09, 0x72,
+	0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x18, 0x11, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x26,
+	0x2e, 0x5a, 0x61, 0x70, 0x75, 0x73, 0x6b, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x41, 0x50, 0x49, 0x2e,
+	0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x2e, 0x52, 0x65, 0x73,
+	0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x52, 0x09, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x73, 0x1a, 0xb9, 0x02, 0x0a, 0x09, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x12,
+	0x1d, 0x0a, 0x0a, 0x63, 0x70, 0x75, 0x5f, 0x73, 0x68, 0x61, 0x72, 0x65, 0x73, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x09, 0x63, 0x70, 0x75, 0x53, 0x68, 0x61, 0x72, 0x65, 0x73, 0x12, 0x1b,
+	0x0a, 0x09, 0x63, 0x70, 0x75, 0x5f, 0x71, 0x75, 0x6f, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x08, 0x63, 0x70, 0x75, 0x51, 0x75, 0x6f, 0x74, 0x61, 0x12, 0x1d, 0x0a, 0x0a, 0x63,
+	0x70, 0x75, 0x5f, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x09, 0x63, 0x70, 0x75, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x65,
+	0x6d, 0x6f, 0x72, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x6d, 0x65, 0x6d, 0x6f,
+	0x72, 0x79, 0x12, 0x2d, 0x0a, 0x12, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x5f, 0x72, 0x65, 0x73,
+	0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x11,
+	0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x69, 0x64, 0x73, 0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x70, 0x69, 0x64, 0x73, 0x4c, 0x69, 0x6d, 0x69, 0x74,
+	0x12, 0x3d, 0x0a, 0x07, 0x75, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x23, 0x2e, 0x5a, 0x61, 0x70, 0x75, 0x73, 0x6b, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x41,
+	0x50, 0x49, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x2e,
+	0x55, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x52, 0x07, 0x75, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x12,
+	0x14, 0x0a, 0x05, 0x63, 0x6f, 0x72, 0x65, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05,
+	0x63, 0x6f, 0x72, 0x65, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x70, 0x75, 0x73, 0x65, 0x74, 0x18,
+	0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x70, 0x75, 0x73, 0x65, 0x74, 0x1a, 0x44, 0x0a,
+	0x06, 0x55, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x73,
+	0x6f, 0x66, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x73, 0x6f, 0x66, 0x74, 0x12,
+	0x12, 0x0a, 0x04, 0x68, 0x61, 0x72, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x68,
+	0x61, 0x72, 0x64, 0x1a, 0x19, 0x0a, 0x07, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e,
+	0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x1a, 0x41,
+	0x0a, 0x08, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x35, 0x0a, 0x04, 0x69, 0x6e,
+	0x66, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x5a, 0x61, 0x70, 0x75, 0x73,
+	0x6b, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x41, 0x50, 0x49, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e,
+	0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x2e, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x04, 0x69, 0x6e, 0x66,
+	0x6f, 0x22, 0x8e, 0x01, 0x0a, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x07, 0x0a, 0x03,
+	0x4e, 0x45, 0x57, 0x10, 0x00, 0x12, 0x0b, 0x0a, 0x07, 0x43, 0x52, 0x45, 0x41, 0x54, 0x45, 0x44,
+	0x10, 0x08, 0x12, 0x0c, 0x0a, 0x08, 0x53, 0x54, 0x41, 0x52, 0x54, 0x49, 0x4e, 0x47, 0x10, 0x01,
+	0x12, 0x0d, 0x0a, 0x09, 0x4e, 0x4f, 0x54, 0x5f, 0x52, 0x45, 0x41, 0x44, 0x59, 0x10, 0x07, 0x12,
+	0x09, 0x0a, 0x05, 0x52, 0x45, 0x41, 0x44, 0x59, 0x10, 0x02, 0x12, 0x0f, 0x0a, 0x0b, 0x55, 0x4e,
+	0x52, 0x45, 0x41, 0x43, 0x48, 0x41, 0x42, 0x4c, 0x45, 0x10, 0x06, 0x12, 0x0a, 0x0a, 0x06, 0x50,
+	0x41, 0x55, 0x53, 0x45, 0x44, 0x10, 0x09, 0x12, 0x10, 0x0a, 0x08, 0x53, 0x54, 0x4f, 0x50, 0x50,
+	0x49, 0x4e, 0x47, 0x10, 0x03, 0x1a, 0x02, 0x08, 0x01, 0x12, 0x0b, 0x0a, 0x07, 0x53, 0x54, 0x4f,
+	0x50, 0x50, 0x45, 0x44, 0x10, 0x04, 0x12, 0x0a, 0x0a, 0x06, 0x46, 0x41, 0x49, 0x4c, 0x45, 0x44,
+	0x10, 0x05, 0x22, 0xc9, 0x02, 0x0a, 0x10, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72,
+	0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x1a, 0xd6, 0x01, 0x0a, 0x0a, 0x54, 0x72, 0x61, 0x6e,
+	0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2e, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x37, 0x0a, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x23, 0x2e, 0x5a, 0x61, 0x70, 0x75, 0x73, 0x6b, 0x61, 0x74, 0x6f,
+	0x72, 0x2e, 0x41, 0x50, 0x49, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e,
+	0x65, 0x72, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x12,
+	0x33, 0x0a, 0x02, 0x74, 0x6f, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x23, 0x2e, 0x5a, 0x61,
+	0x70, 0x75, 0x73, 0x6b, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x41, 0x50, 0x49, 0x2e, 0x76, 0x31, 0x2e,
+	0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x52, 0x02, 0x74, 0x6f, 0x12, 0x14, 0x0a, 0x05, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72,
+	0x72, 0x6f, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72,
+	0x1a, 0x5c, 0x0a, 0x08, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x50, 0x0a, 0x0b,
+	0x74, 0x72, 0x61, 0x6e, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x2e, 0x2e, 0x5a, 0x61, 0x70, 0x75, 0x73, 0x6b, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x41,
+	0x50, 0x49, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x48,
+	0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x69, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x0b, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0xf4,
+	0x02, 0x0a, 0x0e, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72,
+	0x73, 0x1a, 0xea, 0x01, 0x0a, 0x07, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x3f, 0x0a,
+	0x08, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0e, 0x32,
+	0x23, 0x2e, 0x5a, 0x61, 0x70, 0x75, 0x73, 0x6b, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x41, 0x50, 0x49,
+	0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x2e, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x52, 0x08, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x65, 0x73, 0x12, 0x12,
+	0x0a, 0x04, 0x73, 0x65, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x73, 0x65,
+	0x65, 0x64, 0x12, 0x34, 0x0a, 0x08, 0x69, 0x64, 0x6c, 0x65, 0x5f, 0x66, 0x6f, 0x72, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x07, 0x69, 0x64, 0x6c, 0x65, 0x46, 0x6f, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x73, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x12,
+	0x1d, 0x0a, 0x0a, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x1a, 0x75,
+	0x0a, 0x08, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x41, 0x0a, 0x0a, 0x63, 0x6f,
+	0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21,
+	0x2e, 0x5a, 0x61, 0x70, 0x75, 0x73, 0x6b, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x41, 0x50, 0x49, 0x2e,
+	0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x2e, 0x49, 0x6e, 0x66,
+	0x6f, 0x52, 0x0a, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x73, 0x12, 0x26, 0x0a,
+	0x0f, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6e, 0x65, 0x78, 0x74, 0x50, 0x61, 0x67, 0x65,
+	0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0xb3, 0x03, 0x0a, 0x0f, 0x57, 0x61, 0x74, 0x63, 0x68, 0x43,
+	0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x73, 0x1a, 0x5d, 0x0a, 0x07, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x65, 0x65, 0x64, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x05, 0x73, 0x65, 0x65, 0x64, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x6f,
+	0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x49, 0x64, 0x12, 0x19, 0x0a,
+	0x08, 0x66, 0x72, 0x6f, 0x6d, 0x5f, 0x73, 0x65, 0x71, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x07, 0x66, 0x72, 0x6f, 0x6d, 0x53, 0x65, 0x71, 0x1a, 0xc0, 0x02, 0x0a, 0x05, 0x45, 0x76, 0x65,
+	0x6e, 0x74, 0x12, 0x41, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x2d, 0x2e, 0x5a, 0x61, 0x70, 0x75, 0x73, 0x6b, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x41, 0x50,
+	0x49, 0x2e, 0x76, 0x31, 0x2e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69,
+	0x6e, 0x65, 0x72, 0x73, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x2e, 0x54, 0x79, 0x70, 0x65, 0x52,
+	0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x35, 0x0a, 0x04, 0x69, 0x6e, 0x66, 0x6f, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x5a, 0x61, 0x70, 0x75, 0x73, 0x6b, 0x61, 0x74, 0x6f, 0x72,
 	0x2e, 0x41, 0x50, 0x49, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65,
-	0x72, 0x2e, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x52, 0x06, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73,
-	0x12, 0x3b, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e,
+	0x72, 0x2e, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x04, 0x69, 0x6e, 0x66, 0x6f, 0x12, 0x42, 0x0a, 0x0a,
+	0x6f, 0x6c, 0x64, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e,
 	0x32, 0x23, 0x2e, 0x5a, 0x61, 0x70, 0x75, 0x73, 0x6b, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x41, 0x50,
 	0x49, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x2e, 0x53,
-	0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x1a, 0x19, 0x0a,
-	0x07, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x1a, 0x41, 0x0a, 0x08, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x35, 0x0a, 0x04, 0x69, 0x6e, 0x66, 0x6f, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x21, 0x2e, 0x5a, 0x61, 0x70, 0x75, 0x73, 0x6b, 0x61, 0x74, 0x6f, 0x72, 0x2e,
-	0x41, 0x50, 0x49, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72,
-	0x2e, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x04, 0x69, 0x6e, 0x66, 0x6f, 0x22, 0x71, 0x0a, 0x06, 0x53,
-	0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x07, 0x0a, 0x03, 0x4e, 0x45, 0x57, 0x10, 0x00, 0x12, 0x0c,
-	0x0a, 0x08, 0x53, 0x54, 0x41, 0x52, 0x54, 0x49, 0x4e, 0x47, 0x10, 0x01, 0x12, 0x09, 0x0a, 0x05,
-	0x52, 0x45, 0x41, 0x44, 0x59, 0x10, 0x02, 0x12, 0x0d, 0x0a, 0x09, 0x4e, 0x4f, 0x54, 0x5f, 0x52,
-	0x45, 0x41, 0x44, 0x59, 0x10, 0x07, 0x12, 0x0f, 0x0a, 0x0b, 0x55, 0x4e, 0x52, 0x45, 0x41, 0x43,
-	0x48, 0x41, 0x42, 0x4c, 0x45, 0x10, 0x06, 0x12, 0x0c, 0x0a, 0x08, 0x53, 0x54, 0x4f, 0x50, 0x50,
-	0x49, 0x4e, 0x47, 0x10, 0x03, 0x12, 0x0b, 0x0a, 0x07, 0x53, 0x54, 0x4f, 0x50, 0x50, 0x45, 0x44,
-	0x10, 0x04, 0x12, 0x0a, 0x0a, 0x06, 0x46, 0x41, 0x49, 0x4c, 0x45, 0x44, 0x10, 0x05, 0x32, 0x9b,
-	0x02, 0x0a, 0x0d, 0x5a, 0x61, 0x70, 0x75, 0x73, 0x6b, 0x61, 0x74, 0x6f, 0x72, 0x41, 0x50, 0x49,
-	0x12, 0x8c, 0x01, 0x0a, 0x09, 0x43, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x65, 0x12, 0x24,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x09, 0x6f, 0x6c, 0x64, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x12, 0x2e, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65,
+	0x12, 0x10, 0x0a, 0x03, 0x73, 0x65, 0x71, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x03, 0x73,
+	0x65, 0x71, 0x22, 0x37, 0x0a, 0x04, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0c, 0x0a, 0x08, 0x53, 0x4e,
+	0x41, 0x50, 0x53, 0x48, 0x4f, 0x54, 0x10, 0x00, 0x12, 0x11, 0x0a, 0x0d, 0x53, 0x4e, 0x41, 0x50,
+	0x53, 0x48, 0x4f, 0x54, 0x5f, 0x44, 0x4f, 0x4e, 0x45, 0x10, 0x01, 0x12, 0x0e, 0x0a, 0x0a, 0x54,
+	0x52, 0x41, 0x4e, 0x53, 0x49, 0x54, 0x49, 0x4f, 0x4e, 0x10, 0x02, 0x32, 0xde, 0x06, 0x0a, 0x0d,
+	0x5a, 0x61, 0x70, 0x75, 0x73, 0x6b, 0x61, 0x74, 0x6f, 0x72, 0x41, 0x50, 0x49, 0x12, 0xb4, 0x02,
+	0x0a, 0x09, 0x43, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x65, 0x12, 0x24, 0x2e, 0x5a, 0x61,
+	0x70, 0x75, 0x73, 0x6b, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x41, 0x50, 0x49, 0x2e, 0x76, 0x31, 0x2e,
+	0x43, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x65, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x25, 0x2e, 0x5a, 0x61, 0x70, 0x75, 0x73, 0x6b, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x41,
+	0x50, 0x49, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x65, 0x2e,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0xd9, 0x01, 0x82, 0xd3, 0xe4, 0x93, 0x02,
+	0xd2, 0x01, 0x5a, 0x29, 0x3a, 0x0a, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x5f, 0x62, 0x6f, 0x64, 0x79,
+	0x22, 0x1b, 0x2f, 0x76, 0x31, 0x2f, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x65, 0x2f,
+	0x7b, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x2e, 0x73, 0x65, 0x65, 0x64, 0x7d, 0x5a, 0x3d, 0x12,
+	0x3b, 0x2f, 0x76, 0x31, 0x2f, 0x7b, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x2e, 0x73, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x7d, 0x2f, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x65, 0x2f,
+	0x7b, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x2e, 0x73, 0x65, 0x65, 0x64, 0x7d, 0x2f, 0x7b, 0x70,
+	0x61, 0x72, 0x61, 0x6d, 0x73, 0x2e, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x7d, 0x5a, 0x3a, 0x3a, 0x0a,
+	0x69, 0x6e, 0x70, 0x75, 0x74, 0x5f, 0x62, 0x6f, 0x64, 0x79, 0x22, 0x2c, 0x2f, 0x76, 0x31, 0x2f,
+	0x7b, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x7d,
+	0x2f, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x65, 0x2f, 0x7b, 0x70, 0x61, 0x72, 0x61,
+	0x6d, 0x73, 0x2e, 0x73, 0x65, 0x65, 0x64, 0x7d, 0x12, 0x2a, 0x2f, 0x76, 0x31, 0x2f, 0x63, 0x61,
+	0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x65, 0x2f, 0x7b, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x2e,
+	0x73, 0x65, 0x65, 0x64, 0x7d, 0x2f, 0x7b, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x2e, 0x69, 0x6e,
+	0x70, 0x75, 0x74, 0x7d, 0x12, 0x7b, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x74, 0x61,
+	0x69, 0x6e, 0x65, 0x72, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x24, 0x2e, 0x5a, 0x61, 0x70, 0x75, 0x73,
+	0x6b, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x41, 0x50, 0x49, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e,
+	0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x25,
 	0x2e, 0x5a, 0x61, 0x70, 0x75, 0x73, 0x6b, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x41, 0x50, 0x49, 0x2e,
-	0x76, 0x31, 0x2e, 0x43, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x65, 0x2e, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x1a, 0x25, 0x2e, 0x5a, 0x61, 0x70, 0x75, 0x73, 0x6b, 0x61, 0x74, 0x6f,
-	0x72, 0x2e, 0x41, 0x50, 0x49, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61,
-	0x74, 0x65, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x32, 0x82, 0xd3, 0xe4,
-	0x93, 0x02, 0x2c, 0x12, 0x2a, 0x2f, 0x76, 0x31, 0x2f, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61,
-	0x74, 0x65, 0x2f, 0x7b, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x2e, 0x73, 0x65, 0x65, 0x64, 0x7d,
-	0x2f, 0x7b, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x2e, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x7d, 0x12,
-	0x7b, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x49,
-	0x6e, 0x66, 0x6f, 0x12, 0x24, 0x2e, 0x5a, 0x61, 0x70, 0x75, 0x73, 0x6b, 0x61, 0x74, 0x6f, 0x72,
-	0x2e, 0x41, 0x50, 0x49, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65,
-	0x72, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x25, 0x2e, 0x5a, 0x61, 0x70, 0x75,
+	0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x2e, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x1a, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x14, 0x12, 0x12, 0x2f,
+	0x76, 0x31, 0x2f, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x2f, 0x7b, 0x69, 0x64,
+	0x7d, 0x12, 0x8d, 0x01, 0x0a, 0x13, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e,
+	0x65, 0x72, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x12, 0x24, 0x2e, 0x5a, 0x61, 0x70, 0x75,
 	0x73, 0x6b, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x41, 0x50, 0x49, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f,
-	0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x22, 0x1a, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x14, 0x12, 0x12, 0x2f, 0x76, 0x31, 0x2f, 0x63, 0x6f,
-	0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x2f, 0x7b, 0x69, 0x64, 0x7d, 0x42, 0x2f, 0x5a, 0x2d,
-	0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x64, 0x65, 0x6e, 0x6b, 0x6f,
-	0x72, 0x65, 0x6e, 0x2f, 0x6d, 0x69, 0x2d, 0x6c, 0x61, 0x62, 0x73, 0x2d, 0x74, 0x65, 0x73, 0x74,
-	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x62, 0x06, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x2c, 0x2e, 0x5a, 0x61, 0x70, 0x75, 0x73, 0x6b, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x41, 0x50, 0x49,
+	0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x48, 0x69, 0x73,
+	0x74, 0x6f, 0x72, 0x79, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x22, 0x82,
+	0xd3, 0xe4, 0x93, 0x02, 0x1c, 0x12, 0x1a, 0x2f, 0x76, 0x31, 0x2f, 0x63, 0x6f, 0x6e, 0x74, 0x61,
+	0x69, 0x6e, 0x65, 0x72, 0x2f, 0x7b, 0x69, 0x64, 0x7d, 0x2f, 0x68, 0x69, 0x73, 0x74, 0x6f, 0x72,
+	0x79, 0x12, 0x7f, 0x0a, 0x0e, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e,
+	0x65, 0x72, 0x73, 0x12, 0x29, 0x2e, 0x5a, 0x61, 0x70, 0x75, 0x73, 0x6b, 0x61, 0x74, 0x6f, 0x72,
+	0x2e, 0x41, 0x50, 0x49, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x6f, 0x6e, 0x74,
+	0x61, 0x69, 0x6e, 0x65, 0x72, 0x73, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2a,
+	0x2e, 0x5a, 0x61, 0x70, 0x75, 0x73, 0x6b, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x41, 0x50, 0x49, 0x2e,
+	0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72,
+	0x73, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x16, 0x82, 0xd3, 0xe4, 0x93,
+	0x02, 0x10, 0x12, 0x0e, 0x2f, 0x76, 0x31, 0x2f, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65,
+	0x72, 0x73, 0x12, 0x87, 0x01, 0x0a, 0x0f, 0x57, 0x61, 0x74, 0x63, 0x68, 0x43, 0x6f, 0x6e, 0x74,
+	0x61, 0x69, 0x6e, 0x65, 0x72, 0x73, 0x12, 0x2a, 0x2e, 0x5a, 0x61, 0x70, 0x75, 0x73, 0x6b, 0x61,
+	0x74, 0x6f, 0x72, 0x2e, 0x41, 0x50, 0x49, 0x2e, 0x76, 0x31, 0x2e, 0x57, 0x61, 0x74, 0x63, 0x68,
+	0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x73, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x28, 0x2e, 0x5a, 0x61, 0x70, 0x75, 0x73, 0x6b, 0x61, 0x74, 0x6f, 0x72, 0x2e,
+	0x41, 0x50, 0x49, 0x2e, 0x76, 0x31, 0x2e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x43, 0x6f, 0x6e, 0x74,
+	0x61, 0x69, 0x6e, 0x65, 0x72, 0x73, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x22, 0x1c, 0x82, 0xd3,
+	0xe4, 0x93, 0x02, 0x16, 0x12, 0x14, 0x2f, 0x76, 0x31, 0x2f, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69,
+	0x6e, 0x65, 0x72, 0x73, 0x2f, 0x77, 0x61, 0x74, 0x63, 0x68, 0x30, 0x01, 0x32, 0xb4, 0x0a, 0x0a,
+	0x12, 0x5a, 0x61, 0x70, 0x75, 0x73, 0x6b, 0x61, 0x74, 0x6f, 0x72, 0x41, 0x64, 0x6d, 0x69, 0x6e,
+	0x41, 0x50, 0x49, 0x12, 0x78, 0x0a, 0x0d, 0x53, 0x74, 0x6f, 0x70, 0x43, 0x6f, 0x6e, 0x74, 0x61,
+	0x69, 0x6e, 0x65, 0x72, 0x12, 0x20, 0x2e, 0x5a, 0x61, 0x70, 0x75, 0x73, 0x6b, 0x61, 0x74, 0x6f,
+	0x72, 0x2e, 0x41, 0x50, 0x49, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x5a, 0x61, 0x70, 0x75, 0x73, 0x6b, 0x61,
+	0x74, 0x6f, 0x72, 0x2e, 0x41, 0x50, 0x49, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x64, 0x6d, 0x69, 0x6e,
+	0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x22, 0x82, 0xd3, 0xe4, 0x93, 0x02,
+	0x1c, 0x22, 0x1a, 0x2f, 0x76, 0x31, 0x2f, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2f, 0x73, 0x65, 0x65,
+	0x64, 0x2f, 0x7b, 0x73, 0x65, 0x65, 0x64, 0x7d, 0x2f, 0x73, 0x74, 0x6f, 0x70, 0x12, 0x7e, 0x0a,
+	0x10, 0x52, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65,
+	0x72, 0x12, 0x20, 0x2e, 0x5a, 0x61, 0x70, 0x75, 0x73, 0x6b, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x41,
+	0x50, 0x49, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x5a, 0x61, 0x70, 0x75, 0x73, 0x6b, 0x61, 0x74, 0x6f, 0x72,
+	0x2e, 0x41, 0x50, 0x49, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x25, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1f, 0x22, 0x1d,
+	0x2f, 0x76, 0x31, 0x2f, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2f, 0x73, 0x65, 0x65, 0x64, 0x2f, 0x7b,
+	0x73, 0x65, 0x65, 0x64, 0x7d, 0x2f, 0x72, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x12, 0x80, 0x01,
+	0x0a, 0x11, 0x52, 0x65, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69,
+	0x6e, 0x65, 0x72, 0x12, 0x20, 0x2e, 0x5a, 0x61, 0x70, 0x75, 0x73, 0x6b, 0x61, 0x74, 0x6f, 0x72,
+	0x2e, 0x41, 0x50, 0x49, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x5a, 0x61, 0x70, 0x75, 0x73, 0x6b, 0x61, 0x74,
+	0x6f, 0x72, 0x2e, 0x41, 0x50, 0x49, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x2e,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x26, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x20,
+	0x22, 0x1e, 0x2f, 0x76, 0x31, 0x2f, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2f, 0x73, 0x65, 0x65, 0x64,
+	0x2f, 0x7b, 0x73, 0x65, 0x65, 0x64, 0x7d, 0x2f, 0x72, 0x65, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x12, 0x7a, 0x0a, 0x0e, 0x44, 0x72, 0x61, 0x69, 0x6e, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e,
+	0x65, 0x72, 0x12, 0x20, 0x2e, 0x5a, 0x61, 0x70, 0x75, 0x73, 0x6b, 0x61, 0x74, 0x6f, 0x72, 0x2e,
+	0x41, 0x50, 0x49, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x5a, 0x61, 0x70, 0x75, 0x73, 0x6b, 0x61, 0x74, 0x6f,
+	0x72, 0x2e, 0x41, 0x50, 0x49, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x23, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1d, 0x22,
+	0x1b, 0x2f, 0x76, 0x31, 0x2f, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2f, 0x73, 0x65, 0x65, 0x64, 0x2f,
+	0x7b, 0x73, 0x65, 0x65, 0x64, 0x7d, 0x2f, 0x64, 0x72, 0x61, 0x69, 0x6e, 0x12, 0x76, 0x0a, 0x0c,
+	0x50, 0x69, 0x6e, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x12, 0x20, 0x2e, 0x5a,
+	0x61, 0x70, 0x75, 0x73, 0x6b, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x41, 0x50, 0x49, 0x2e, 0x76, 0x31,
+	0x2e, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21,
+	0x2e, 0x5a, 0x61, 0x70, 0x75, 0x73, 0x6b, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x41, 0x50, 0x49, 0x2e,
+	0x76, 0x31, 0x2e, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x21, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1b, 0x22, 0x19, 0x2f, 0x76, 0x31, 0x2f, 0x61,
+	0x64, 0x6d, 0x69, 0x6e, 0x2f, 0x73, 0x65, 0x65, 0x64, 0x2f, 0x7b, 0x73, 0x65, 0x65, 0x64, 0x7d,
+	0x2f, 0x70, 0x69, 0x6e, 0x12, 0x7a, 0x0a, 0x0e, 0x55, 0x6e, 0x70, 0x69, 0x6e, 0x43, 0x6f, 0x6e,
+	0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x12, 0x20, 0x2e, 0x5a, 0x61, 0x70, 0x75, 0x73, 0x6b, 0x61,
+	0x74, 0x6f, 0x72, 0x2e, 0x41, 0x50, 0x49, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x64, 0x6d, 0x69, 0x6e,
+	0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x5a, 0x61, 0x70, 0x75, 0x73,
+	0x6b, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x41, 0x50, 0x49, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x64, 0x6d,
+	0x69, 0x6e, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x23, 0x82, 0xd3, 0xe4,
+	0x93, 0x02, 0x1d, 0x22, 0x1b, 0x2f, 0x76, 0x31, 0x2f, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2f, 0x73,
+	0x65, 0x65, 0x64, 0x2f, 0x7b, 0x73, 0x65, 0x65, 0x64, 0x7d, 0x2f, 0x75, 0x6e, 0x70, 0x69, 0x6e,
+	0x12, 0x89, 0x01, 0x0a, 0x0e, 0x55, 0x70, 0x67, 0x72, 0x61, 0x64, 0x65, 0x53, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x12, 0x22, 0x2e, 0x5a, 0x61, 0x70, 0x75, 0x73, 0x6b, 0x61, 0x74, 0x6f, 0x72,
+	0x2e, 0x41, 0x50, 0x49, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x70, 0x67, 0x72, 0x61, 0x64, 0x65, 0x2e,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e, 0x5a, 0x61, 0x70, 0x75, 0x73, 0x6b,
+	0x61, 0x74, 0x6f, 0x72, 0x2e, 0x41, 0x50, 0x49, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x70, 0x67, 0x72,
+	0x61, 0x64, 0x65, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x2e, 0x82, 0xd3,
+	0xe4, 0x93, 0x02, 0x28, 0x22, 0x23, 0x2f, 0x76, 0x31, 0x2f, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2f,
+	0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2f, 0x7b, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x7d, 0x2f, 0x75, 0x70, 0x67, 0x72, 0x61, 0x64, 0x65, 0x3a, 0x01, 0x2a, 0x12, 0x88, 0x01, 0x0a,
+	0x10, 0x53, 0x65, 0x74, 0x53, 0x68, 0x61, 0x64, 0x6f, 0x77, 0x54, 0x72, 0x61, 0x66, 0x66, 0x69,
+	0x63, 0x12, 0x21, 0x2e, 0x5a, 0x61, 0x70, 0x75, 0x73, 0x6b, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x41,
+	0x50, 0x49, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x68, 0x61, 0x64, 0x6f, 0x77, 0x2e, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x5a, 0x61, 0x70, 0x75, 0x73, 0x6b, 0x61, 0x74, 0x6f,
+	0x72, 0x2e, 0x41, 0x50, 0x49, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x68, 0x61, 0x64, 0x6f, 0x77, 0x2e,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x2d, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x27,
+	0x22, 0x22, 0x2f, 0x76, 0x31, 0x2f, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2f, 0x73, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x2f, 0x7b, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x7d, 0x2f, 0x73, 0x68,
+	0x61, 0x64, 0x6f, 0x77, 0x3a, 0x01, 0x2a, 0x12, 0x90, 0x01, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x53,
+	0x68, 0x61, 0x64, 0x6f, 0x77, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x27, 0x2e, 0x5a, 0x61,
+	0x70, 0x75, 0x73, 0x6b, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x41, 0x50, 0x49, 0x2e, 0x76, 0x31, 0x2e,
+	0x53, 0x68, 0x61, 0x64, 0x6f, 0x77, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x28, 0x2e, 0x5a, 0x61, 0x70, 0x75, 0x73, 0x6b, 0x61, 0x74, 0x6f,
+	0x72, 0x2e, 0x41, 0x50, 0x49, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x68, 0x61, 0x64, 0x6f, 0x77, 0x52,
+	0x65, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x2a,
+	0x82, 0xd3, 0xe4, 0x93, 0x02, 0x24, 0x12, 0x22, 0x2f, 0x76, 0x31, 0x2f, 0x61, 0x64, 0x6d, 0x69,
+	0x6e, 0x2f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2f, 0x7b, 0x73, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x7d, 0x2f, 0x73, 0x68, 0x61, 0x64, 0x6f, 0x77, 0x12, 0x86, 0x01, 0x0a, 0x14, 0x47,
+	0x65, 0x74, 0x44, 0x65, 0x74, 0x65, 0x72, 0x6d, 0x69, 0x6e, 0x69, 0x73, 0x6d, 0x52, 0x65, 0x70,
+	0x6f, 0x72, 0x74, 0x12, 0x26, 0x2e, 0x5a, 0x61, 0x70, 0x75, 0x73, 0x6b, 0x61, 0x74, 0x6f, 0x72,
+	0x2e, 0x41, 0x50, 0x49, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x74, 0x65, 0x72, 0x6d, 0x69, 0x6e,
+	0x69, 0x73, 0x6d, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x27, 0x2e, 0x5a, 0x61,
+	0x70, 0x75, 0x73, 0x6b, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x41, 0x50, 0x49, 0x2e, 0x76, 0x31, 0x2e,
+	0x44, 0x65, 0x74, 0x65, 0x72, 0x6d, 0x69, 0x6e, 0x69, 0x73, 0x6d, 0x2e, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x1d, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x17, 0x12, 0x15, 0x2f, 0x76,
+	0x31, 0x2f, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2f, 0x64, 0x65, 0x74, 0x65, 0x72, 0x6d, 0x69, 0x6e,
+	0x69, 0x73, 0x6d, 0x42, 0x2f, 0x5a, 0x2d, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f,
+	0x6d, 0x2f, 0x64, 0x65, 0x6e, 0x6b, 0x6f, 0x72, 0x65, 0x6e, 0x2f, 0x6d, 0x69, 0x2d, 0x6c, 0x61,
+	0x62, 0x73, 0x2d, 0x74, 0x65, 0x73, 0x74, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x61, 0x70,
+	0x69, 0x2f, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -553,33 +3057,124 @@ func file_api_v1_proto_rawDescGZIP() []byte {
 	return file_api_v1_proto_rawDescData
 }
 
-var file_api_v1_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_api_v1_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_api_v1_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_api_v1_proto_msgTypes = make([]protoimpl.MessageInfo, 38)
 var file_api_v1_proto_goTypes = []interface{}{
-	(Container_Status)(0),      // 0: Zapuskator.API.v1.Container.Status
-	(*Calculate)(nil),          // 1: Zapuskator.API.v1.Calculate
-	(*Container)(nil),          // 2: Zapuskator.API.v1.Container
-	(*Calculate_Request)(nil),  // 3: Zapuskator.API.v1.Calculate.Request
-	(*Calculate_Response)(nil), // 4: Zapuskator.API.v1.Calculate.Response
-	(*Container_Params)(nil),   // 5: Zapuskator.API.v1.Container.Params
-	(*Container_Info)(nil),     // 6: Zapuskator.API.v1.Container.Info
-	(*Container_Request)(nil),  // 7: Zapuskator.API.v1.Container.Request
-	(*Container_Response)(nil), // 8: Zapuskator.API.v1.Container.Response
+	(Container_Status)(0),               // 0: Zapuskator.API.v1.Container.Status
+	(WatchContainers_Event_Type)(0),     // 1: Zapuskator.API.v1.WatchContainers.Event.Type
+	(*Admin)(nil),                       // 2: Zapuskator.API.v1.Admin
+	(*Upgrade)(nil),                     // 3: Zapuskator.API.v1.Upgrade
+	(*Shadow)(nil),                      // 4: Zapuskator.API.v1.Shadow
+	(*ShadowReport)(nil),                // 5: Zapuskator.API.v1.ShadowReport
+	(*Determinism)(nil),                 // 6: Zapuskator.API.v1.Determinism
+	(*Calculate)(nil),                   // 7: Zapuskator.API.v1.Calculate
+	(*Container)(nil),                   // 8: Zapuskator.API.v1.Container
+	(*ContainerHistory)(nil),            // 9: Zapuskator.API.v1.ContainerHistory
+	(*ListContainers)(nil),              // 10: Zapuskator.API.v1.ListContainers
+	(*WatchContainers)(nil),             // 11: Zapuskator.API.v1.WatchContainers
+	(*Admin_Request)(nil),               // 12: Zapuskator.API.v1.Admin.Request
+	(*Admin_Response)(nil),              // 13: Zapuskator.API.v1.Admin.Response
+	nil,                                 // 14: Zapuskator.API.v1.Admin.Request.ValuesEntry
+	(*Upgrade_Request)(nil),             // 15: Zapuskator.API.v1.Upgrade.Request
+	(*Upgrade_Response)(nil),            // 16: Zapuskator.API.v1.Upgrade.Response
+	(*Shadow_Request)(nil),              // 17: Zapuskator.API.v1.Shadow.Request
+	(*Shadow_Response)(nil),             // 18: Zapuskator.API.v1.Shadow.Response
+	(*ShadowReport_Request)(nil),        // 19: Zapuskator.API.v1.ShadowReport.Request
+	(*ShadowReport_Mismatch)(nil),       // 20: Zapuskator.API.v1.ShadowReport.Mismatch
+	(*ShadowReport_Response)(nil),       // 21: Zapuskator.API.v1.ShadowReport.Response
+	(*Determinism_Request)(nil),         // 22: Zapuskator.API.v1.Determinism.Request
+	(*Determinism_Finding)(nil),         // 23: Zapuskator.API.v1.Determinism.Finding
+	(*Determinism_Response)(nil),        // 24: Zapuskator.API.v1.Determinism.Response
+	(*Calculate_Request)(nil),           // 25: Zapuskator.API.v1.Calculate.Request
+	(*Calculate_Response)(nil),          // 26: Zapuskator.API.v1.Calculate.Response
+	(*Container_Params)(nil),            // 27: Zapuskator.API.v1.Container.Params
+	(*Container_Info)(nil),              // 28: Zapuskator.API.v1.Container.Info
+	(*Container_Resources)(nil),         // 29: Zapuskator.API.v1.Container.Resources
+	(*Container_Ulimit)(nil),            // 30: Zapuskator.API.v1.Container.Ulimit
+	(*Container_Request)(nil),           // 31: Zapuskator.API.v1.Container.Request
+	(*Container_Response)(nil),          // 32: Zapuskator.API.v1.Container.Response
+	nil,                                 // 33: Zapuskator.API.v1.Container.Params.ValuesEntry
+	(*ContainerHistory_Transition)(nil), // 34: Zapuskator.API.v1.ContainerHistory.Transition
+	(*ContainerHistory_Response)(nil),   // 35: Zapuskator.API.v1.ContainerHistory.Response
+	(*ListContainers_Request)(nil),      // 36: Zapuskator.API.v1.ListContainers.Request
+	(*ListContainers_Response)(nil),     // 37: Zapuskator.API.v1.ListContainers.Response
+	(*WatchContainers_Request)(nil),     // 38: Zapuskator.API.v1.WatchContainers.Request
+	(*WatchContainers_Event)(nil),       // 39: Zapuskator.API.v1.WatchContainers.Event
+	(*timestamppb.Timestamp)(nil),       // 40: google.protobuf.Timestamp
+	(*durationpb.Duration)(nil),         // 41: google.protobuf.Duration
 }
 var file_api_v1_proto_depIdxs = []int32{
-	5, // 0: Zapuskator.API.v1.Calculate.Request.params:type_name -> Zapuskator.API.v1.Container.Params
-	5, // 1: Zapuskator.API.v1.Container.Info.params:type_name -> Zapuskator.API.v1.Container.Params
-	0, // 2: Zapuskator.API.v1.Container.Info.status:type_name -> Zapuskator.API.v1.Container.Status
-	6, // 3: Zapuskator.API.v1.Container.Response.info:type_name -> Zapuskator.API.v1.Container.Info
-	3, // 4: Zapuskator.API.v1.ZapuskatorAPI.Calculate:input_type -> Zapuskator.API.v1.Calculate.Request
-	7, // 5: Zapuskator.API.v1.ZapuskatorAPI.GetContainerInfo:input_type -> Zapuskator.API.v1.Container.Request
-	4, // 6: Zapuskator.API.v1.ZapuskatorAPI.Calculate:output_type -> Zapuskator.API.v1.Calculate.Response
-	8, // 7: Zapuskator.API.v1.ZapuskatorAPI.GetContainerInfo:output_type -> Zapuskator.API.v1.Container.Response
-	6, // [6:8] is the sub-list for method output_type
-	4, // [4:6] is the sub-list for method input_type
-	4, // [4:4] is the sub-list for extension type_name
-	4, // [4:4] is the sub-list for extension extendee
-	0, // [0:4] is the sub-list for field type_name
+	14, // 0: Zapuskator.API.v1.Admin.Request.values:type_name -> Zapuskator.API.v1.Admin.Request.ValuesEntry
+	28, // 1: Zapuskator.API.v1.Admin.Response.info:type_name -> Zapuskator.API.v1.Container.Info
+	28, // 2: Zapuskator.API.v1.Upgrade.Response.started:type_name -> Zapuskator.API.v1.Container.Info
+	28, // 3: Zapuskator.API.v1.Upgrade.Response.retired:type_name -> Zapuskator.API.v1.Container.Info
+	40, // 4: Zapuskator.API.v1.ShadowReport.Mismatch.time:type_name -> google.protobuf.Timestamp
+	27, // 5: Zapuskator.API.v1.ShadowReport.Mismatch.params:type_name -> Zapuskator.API.v1.Container.Params
+	20, // 6: Zapuskator.API.v1.ShadowReport.Response.mismatches:type_name -> Zapuskator.API.v1.ShadowReport.Mismatch
+	27, // 7: Zapuskator.API.v1.Determinism.Finding.params:type_name -> Zapuskator.API.v1.Container.Params
+	40, // 8: Zapuskator.API.v1.Determinism.Finding.calculated:type_name -> google.protobuf.Timestamp
+	40, // 9: Zapuskator.API.v1.Determinism.Finding.recalculated:type_name -> google.protobuf.Timestamp
+	23, // 10: Zapuskator.API.v1.Determinism.Response.findings:type_name -> Zapuskator.API.v1.Determinism.Finding
+	27, // 11: Zapuskator.API.v1.Calculate.Request.params:type_name -> Zapuskator.API.v1.Container.Params
+	33, // 12: Zapuskator.API.v1.Container.Params.values:type_name -> Zapuskator.API.v1.Container.Params.ValuesEntry
+	27, // 13: Zapuskator.API.v1.Container.Info.params:type_name -> Zapuskator.API.v1.Container.Params
+	0,  // 14: Zapuskator.API.v1.Container.Info.status:type_name -> Zapuskator.API.v1.Container.Status
+	41, // 15: Zapuskator.API.v1.Container.Info.ready_in:type_name -> google.protobuf.Duration
+	40, // 16: Zapuskator.API.v1.Container.Info.created:type_name -> google.protobuf.Timestamp
+	40, // 17: Zapuskator.API.v1.Container.Info.scheduled:type_name -> google.protobuf.Timestamp
+	40, // 18: Zapuskator.API.v1.Container.Info.started:type_name -> google.protobuf.Timestamp
+	40, // 19: Zapuskator.API.v1.Container.Info.stopped:type_name -> google.protobuf.Timestamp
+	40, // 20: Zapuskator.API.v1.Container.Info.updated:type_name -> google.protobuf.Timestamp
+	40, // 21: Zapuskator.API.v1.Container.Info.last_used:type_name -> google.protobuf.Timestamp
+	29, // 22: Zapuskator.API.v1.Container.Info.resources:type_name -> Zapuskator.API.v1.Container.Resources
+	30, // 23: Zapuskator.API.v1.Container.Resources.ulimits:type_name -> Zapuskator.API.v1.Container.Ulimit
+	28, // 24: Zapuskator.API.v1.Container.Response.info:type_name -> Zapuskator.API.v1.Container.Info
+	40, // 25: Zapuskator.API.v1.ContainerHistory.Transition.time:type_name -> google.protobuf.Timestamp
+	0,  // 26: Zapuskator.API.v1.ContainerHistory.Transition.from:type_name -> Zapuskator.API.v1.Container.Status
+	0,  // 27: Zapuskator.API.v1.ContainerHistory.Transition.to:type_name -> Zapuskator.API.v1.Container.Status
+	34, // 28: Zapuskator.API.v1.ContainerHistory.Response.transitions:type_name -> Zapuskator.API.v1.ContainerHistory.Transition
+	0,  // 29: Zapuskator.API.v1.ListContainers.Request.statuses:type_name -> Zapuskator.API.v1.Container.Status
+	41, // 30: Zapuskator.API.v1.ListContainers.Request.idle_for:type_name -> google.protobuf.Duration
+	28, // 31: Zapuskator.API.v1.ListContainers.Response.containers:type_name -> Zapuskator.API.v1.Container.Info
+	1,  // 32: Zapuskator.API.v1.WatchContainers.Event.type:type_name -> Zapuskator.API.v1.WatchContainers.Event.Type
+	28, // 33: Zapuskator.API.v1.WatchContainers.Event.info:type_name -> Zapuskator.API.v1.Container.Info
+	0,  // 34: Zapuskator.API.v1.WatchContainers.Event.old_status:type_name -> Zapuskator.API.v1.Container.Status
+	40, // 35: Zapuskator.API.v1.WatchContainers.Event.time:type_name -> google.protobuf.Timestamp
+	25, // 36: Zapuskator.API.v1.ZapuskatorAPI.Calculate:input_type -> Zapuskator.API.v1.Calculate.Request
+	31, // 37: Zapuskator.API.v1.ZapuskatorAPI.GetContainerInfo:input_type -> Zapuskator.API.v1.Container.Request
+	31, // 38: Zapuskator.API.v1.ZapuskatorAPI.GetContainerHistory:input_type -> Zapuskator.API.v1.Container.Request
+	36, // 39: Zapuskator.API.v1.ZapuskatorAPI.ListContainers:input_type -> Zapuskator.API.v1.ListContainers.Request
+	38, // 40: Zapuskator.API.v1.ZapuskatorAPI.WatchContainers:input_type -> Zapuskator.API.v1.WatchContainers.Request
+	12, // 41: Zapuskator.API.v1.ZapuskatorAdminAPI.StopContainer:input_type -> Zapuskator.API.v1.Admin.Request
+	12, // 42: Zapuskator.API.v1.ZapuskatorAdminAPI.RestartContainer:input_type -> Zapuskator.API.v1.Admin.Request
+	12, // 43: Zapuskator.API.v1.ZapuskatorAdminAPI.RecreateContainer:input_type -> Zapuskator.API.v1.Admin.Request
+	12, // 44: Zapuskator.API.v1.ZapuskatorAdminAPI.DrainContainer:input_type -> Zapuskator.API.v1.Admin.Request
+	12, // 45: Zapuskator.API.v1.ZapuskatorAdminAPI.PinContainer:input_type -> Zapuskator.API.v1.Admin.Request
+	12, // 46: Zapuskator.API.v1.ZapuskatorAdminAPI.UnpinContainer:input_type -> Zapuskator.API.v1.Admin.Request
+	15, // 47: Zapuskator.API.v1.ZapuskatorAdminAPI.UpgradeService:input_type -> Zapuskator.API.v1.Upgrade.Request
+	17, // 48: Zapuskator.API.v1.ZapuskatorAdminAPI.SetShadowTraffic:input_type -> Zapuskator.API.v1.Shadow.Request
+	19, // 49: Zapuskator.API.v1.ZapuskatorAdminAPI.GetShadowReport:input_type -> Zapuskator.API.v1.ShadowReport.Request
+	22, // 50: Zapuskator.API.v1.ZapuskatorAdminAPI.GetDeterminismReport:input_type -> Zapuskator.API.v1.Determinism.Request
+	26, // 51: Zapuskator.API.v1.ZapuskatorAPI.Calculate:output_type -> Zapuskator.API.v1.Calculate.Response
+	32, // 52: Zapuskator.API.v1.ZapuskatorAPI.GetContainerInfo:output_type -> Zapuskator.API.v1.Container.Response
+	35, // 53: Zapuskator.API.v1.ZapuskatorAPI.GetContainerHistory:output_type -> Zapuskator.API.v1.ContainerHistory.Response
+	37, // 54: Zapuskator.API.v1.ZapuskatorAPI.ListContainers:output_type -> Zapuskator.API.v1.ListContainers.Response
+	39, // 55: Zapuskator.API.v1.ZapuskatorAPI.WatchContainers:output_type -> Zapuskator.API.v1.WatchContainers.Event
+	13, // 56: Zapuskator.API.v1.ZapuskatorAdminAPI.StopContainer:output_type -> Zapuskator.API.v1.Admin.Response
+	13, // 57: Zapuskator.API.v1.ZapuskatorAdminAPI.RestartContainer:output_type -> Zapuskator.API.v1.Admin.Response
+	13, // 58: Zapuskator.API.v1.ZapuskatorAdminAPI.RecreateContainer:output_type -> Zapuskator.API.v1.Admin.Response
+	13, // 59: Zapuskator.API.v1.ZapuskatorAdminAPI.DrainContainer:output_type -> Zapuskator.API.v1.Admin.Response
+	13, // 60: Zapuskator.API.v1.ZapuskatorAdminAPI.PinContainer:output_type -> Zapuskator.API.v1.Admin.Response
+	13, // 61: Zapuskator.API.v1.ZapuskatorAdminAPI.UnpinContainer:output_type -> Zapuskator.API.v1.Admin.Response
+	16, // 62: Zapuskator.API.v1.ZapuskatorAdminAPI.UpgradeService:output_type -> Zapuskator.API.v1.Upgrade.Response
+	18, // 63: Zapuskator.API.v1.ZapuskatorAdminAPI.SetShadowTraffic:output_type -> Zapuskator.API.v1.Shadow.Response
+	21, // 64: Zapuskator.API.v1.ZapuskatorAdminAPI.GetShadowReport:output_type -> Zapuskator.API.v1.ShadowReport.Response
+	24, // 65: Zapuskator.API.v1.ZapuskatorAdminAPI.GetDeterminismReport:output_type -> Zapuskator.API.v1.Determinism.Response
+	51, // [51:66] is the sub-list for method output_type
+	36, // [36:51] is the sub-list for method input_type
+	36, // [36:36] is the sub-list for extension type_name
+	36, // [36:36] is the sub-list for extension extendee
+	0,  // [0:36] is the sub-list for field type_name
 }
 
 func init() { file_api_v1_proto_init() }
@@ -589,6 +3184,66 @@ func file_api_v1_proto_init() {
 	}
 	if !protoimpl.UnsafeEnabled {
 		file_api_v1_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Admin); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Upgrade); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Shadow); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ShadowReport); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Determinism); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Calculate); i {
 			case 0:
 				return &v.state
@@ -600,7 +3255,7 @@ func file_api_v1_proto_init() {
 				return nil
 			}
 		}
-		file_api_v1_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+		file_api_v1_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Container); i {
 			case 0:
 				return &v.state
@@ -612,7 +3267,187 @@ func file_api_v1_proto_init() {
 				return nil
 			}
 		}
-		file_api_v1_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+		file_api_v1_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ContainerHistory); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListContainers); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WatchContainers); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Admin_Request); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Admin_Response); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Upgrade_Request); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Upgrade_Response); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Shadow_Request); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Shadow_Response); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ShadowReport_Request); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ShadowReport_Mismatch); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ShadowReport_Response); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Determinism_Request); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Determinism_Finding); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Determinism_Response); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Calculate_Request); i {
 			case 0:
 				return &v.state
@@ -624,7 +3459,7 @@ func file_api_v1_proto_init() {
 				return nil
 			}
 		}
-		file_api_v1_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+		file_api_v1_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Calculate_Response); i {
 			case 0:
 				return &v.state
@@ -636,7 +3471,7 @@ func file_api_v1_proto_init() {
 				return nil
 			}
 		}
-		file_api_v1_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+		file_api_v1_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Container_Params); i {
 			case 0:
 				return &v.state
@@ -648,7 +3483,7 @@ func file_api_v1_proto_init() {
 				return nil
 			}
 		}
-		file_api_v1_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+		file_api_v1_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Container_Info); i {
 			case 0:
 				return &v.state
@@ -660,7 +3495,31 @@ func file_api_v1_proto_init() {
 				return nil
 			}
 		}
-		file_api_v1_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+		file_api_v1_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Container_Resources); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Container_Ulimit); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Container_Request); i {
 			case 0:
 				return &v.state
@@ -672,7 +3531,7 @@ func file_api_v1_proto_init() {
 				return nil
 			}
 		}
-		file_api_v1_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+		file_api_v1_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Container_Response); i {
 			case 0:
 				return &v.state
@@ -684,16 +3543,88 @@ func file_api_v1_proto_init() {
 				return nil
 			}
 		}
+		file_api_v1_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ContainerHistory_Transition); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ContainerHistory_Response); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListContainers_Request); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListContainers_Response); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WatchContainers_Request); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WatchContainers_Event); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_api_v1_proto_rawDesc,
-			NumEnums:      1,
-			NumMessages:   8,
+			NumEnums:      2,
+			NumMessages:   38,
 			NumExtensions: 0,
-			NumServices:   1,
+			NumServices:   2,
 		},
 		GoTypes:           file_api_v1_proto_goTypes,
 		DependencyIndexes: file_api_v1_proto_depIdxs,
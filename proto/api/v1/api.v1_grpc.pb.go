@@ -20,6 +20,11 @@ const _ = grpc.SupportPackageIsVersion7
 type ZapuskatorAPIClient interface {
 	Calculate(ctx context.Context, in *Calculate_Request, opts ...grpc.CallOption) (*Calculate_Response, error)
 	GetContainerInfo(ctx context.Context, in *Container_Request, opts ...grpc.CallOption) (*Container_Response, error)
+	// Status transitions of container, including the ones failed. Is available after container removal too.
+	GetContainerHistory(ctx context.Context, in *Container_Request, opts ...grpc.CallOption) (*ContainerHistory_Response, error)
+	ListContainers(ctx context.Context, in *ListContainers_Request, opts ...grpc.CallOption) (*ListContainers_Response, error)
+	// Streams current state of containers followed by their status transitions.
+	WatchContainers(ctx context.Context, in *WatchContainers_Request, opts ...grpc.CallOption) (ZapuskatorAPI_WatchContainersClient, error)
 }
 
 type zapuskatorAPIClient struct {
@@ -48,12 +53,67 @@ func (c *zapuskatorAPIClient) GetContainerInfo(ctx context.Context, in *Containe
 	return out, nil
 }
 
+func (c *zapuskatorAPIClient) GetContainerHistory(ctx context.Context, in *Container_Request, opts ...grpc.CallOption) (*ContainerHistory_Response, error) {
+	out := new(ContainerHistory_Response)
+	err := c.cc.Invoke(ctx, "/Zapuskator.API.v1.ZapuskatorAPI/GetContainerHistory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *zapuskatorAPIClient) ListContainers(ctx context.Context, in *ListContainers_Request, opts ...grpc.CallOption) (*ListContainers_Response, error) {
+	out := new(ListContainers_Response)
+	err := c.cc.Invoke(ctx, "/Zapuskator.API.v1.ZapuskatorAPI/ListContainers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *zapuskatorAPIClient) WatchContainers(ctx context.Context, in *WatchContainers_Request, opts ...grpc.CallOption) (ZapuskatorAPI_WatchContainersClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ZapuskatorAPI_ServiceDesc.Streams[0], "/Zapuskator.API.v1.ZapuskatorAPI/WatchContainers", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &zapuskatorAPIWatchContainersClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ZapuskatorAPI_WatchContainersClient interface {
+	Recv() (*WatchContainers_Event, error)
+	grpc.ClientStream
+}
+
+type zapuskatorAPIWatchContainersClient struct {
+	grpc.ClientStream
+}
+
+func (x *zapuskatorAPIWatchContainersClient) Recv() (*WatchContainers_Event, error) {
+	m := new(WatchContainers_Event)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // ZapuskatorAPIServer is the server API for ZapuskatorAPI service.
 // All implementations must embed UnimplementedZapuskatorAPIServer
 // for forward compatibility
 type ZapuskatorAPIServer interface {
 	Calculate(context.Context, *Calculate_Request) (*Calculate_Response, error)
 	GetContainerInfo(context.Context, *Container_Request) (*Container_Response, error)
+	// Status transitions of container, including the ones failed. Is available after container removal too.
+	GetContainerHistory(context.Context, *Container_Request) (*ContainerHistory_Response, error)
+	ListContainers(context.Context, *ListContainers_Request) (*ListContainers_Response, error)
+	// Streams current state of containers followed by their status transitions.
+	WatchContainers(*WatchContainers_Request, ZapuskatorAPI_WatchContainersServer) error
 	mustEmbedUnimplementedZapuskatorAPIServer()
 }
 
@@ -67,6 +127,15 @@ func (UnimplementedZapuskatorAPIServer) Calculate(context.Context, *Calculate_Re
 func (UnimplementedZapuskatorAPIServer) GetContainerInfo(context.Context, *Container_Request) (*Container_Response, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetContainerInfo not implemented")
 }
+func (UnimplementedZapuskatorAPIServer) GetContainerHistory(context.Context, *Container_Request) (*ContainerHistory_Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetContainerHistory not implemented")
+}
+func (UnimplementedZapuskatorAPIServer) ListContainers(context.Context, *ListContainers_Request) (*ListContainers_Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListContainers not implemented")
+}
+func (UnimplementedZapuskatorAPIServer) WatchContainers(*WatchContainers_Request, ZapuskatorAPI_WatchContainersServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchContainers not implemented")
+}
 func (UnimplementedZapuskatorAPIServer) mustEmbedUnimplementedZapuskatorAPIServer() {}
 
 // UnsafeZapuskatorAPIServer may be embedded to opt out of forward compatibility for this service.
@@ -116,6 +185,63 @@ func _ZapuskatorAPI_GetContainerInfo_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ZapuskatorAPI_GetContainerHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Container_Request)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ZapuskatorAPIServer).GetContainerHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Zapuskator.API.v1.ZapuskatorAPI/GetContainerHistory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ZapuskatorAPIServer).GetContainerHistory(ctx, req.(*Container_Request))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ZapuskatorAPI_ListContainers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListContainers_Request)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ZapuskatorAPIServer).ListContainers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Zapuskator.API.v1.ZapuskatorAPI/ListContainers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ZapuskatorAPIServer).ListContainers(ctx, req.(*ListContainers_Request))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ZapuskatorAPI_WatchContainers_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchContainers_Request)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ZapuskatorAPIServer).WatchContainers(m, &zapuskatorAPIWatchContainersServer{stream})
+}
+
+type ZapuskatorAPI_WatchContainersServer interface {
+	Send(*WatchContainers_Event) error
+	grpc.ServerStream
+}
+
+type zapuskatorAPIWatchContainersServer struct {
+	grpc.ServerStream
+}
+
+func (x *zapuskatorAPIWatchContainersServer) Send(m *WatchContainers_Event) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 // ZapuskatorAPI_ServiceDesc is the grpc.ServiceDesc for ZapuskatorAPI service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -131,6 +257,454 @@ var ZapuskatorAPI_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetContainerInfo",
 			Handler:    _ZapuskatorAPI_GetContainerInfo_Handler,
 		},
+		{
+			MethodName: "GetContainerHistory",
+			Handler:    _ZapuskatorAPI_GetContainerHistory_Handler,
+		},
+		{
+			MethodName: "ListContainers",
+			Handler:    _ZapuskatorAPI_ListContainers_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchContainers",
+			Handler:       _ZapuskatorAPI_WatchContainers_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "api.v1.proto",
+}
+
+// ZapuskatorAdminAPIClient is the client API for ZapuskatorAdminAPI service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ZapuskatorAdminAPIClient interface {
+	StopContainer(ctx context.Context, in *Admin_Request, opts ...grpc.CallOption) (*Admin_Response, error)
+	RestartContainer(ctx context.Context, in *Admin_Request, opts ...grpc.CallOption) (*Admin_Response, error)
+	// Removes container and creates the new one with the same params.
+	RecreateContainer(ctx context.Context, in *Admin_Request, opts ...grpc.CallOption) (*Admin_Response, error)
+	// Stops accepting new calculations by container. The container is stopped once it is idle.
+	DrainContainer(ctx context.Context, in *Admin_Request, opts ...grpc.CallOption) (*Admin_Response, error)
+	// Pinned container is never stopped due to inactivity.
+	PinContainer(ctx context.Context, in *Admin_Request, opts ...grpc.CallOption) (*Admin_Response, error)
+	UnpinContainer(ctx context.Context, in *Admin_Request, opts ...grpc.CallOption) (*Admin_Response, error)
+	// Switches service to another image version without downtime. Containers of the new version are started
+	// for all active containers of the service first. New calculations go to them once they all are ready,
+	// while old containers are drained: they finish calculations in progress and are stopped.
+	// Nothing is switched when any new container fails to start.
+	UpgradeService(ctx context.Context, in *Upgrade_Request, opts ...grpc.CallOption) (*Upgrade_Response, error)
+	// Duplicates sampled calculations of service to the candidate image and compares results.
+	// Candidate calculations run in background and don't affect clients. Zero sample rate disables shadowing.
+	SetShadowTraffic(ctx context.Context, in *Shadow_Request, opts ...grpc.CallOption) (*Shadow_Response, error)
+	// Results comparison statistics and the latest mismatches of the current candidate.
+	GetShadowReport(ctx context.Context, in *ShadowReport_Request, opts ...grpc.CallOption) (*ShadowReport_Response, error)
+	// Results of determinism verification: sampled results are recomputed by restarted or new container
+	// and compared with the original ones.
+	GetDeterminismReport(ctx context.Context, in *Determinism_Request, opts ...grpc.CallOption) (*Determinism_Response, error)
+}
+
+type zapuskatorAdminAPIClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewZapuskatorAdminAPIClient(cc grpc.ClientConnInterface) ZapuskatorAdminAPIClient {
+	return &zapuskatorAdminAPIClient{cc}
+}
+
+func (c *zapuskatorAdminAPIClient) StopContainer(ctx context.Context, in *Admin_Request, opts ...grpc.CallOption) (*Admin_Response, error) {
+	out := new(Admin_Response)
+	err := c.cc.Invoke(ctx, "/Zapuskator.API.v1.ZapuskatorAdminAPI/StopContainer", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *zapuskatorAdminAPIClient) RestartContainer(ctx context.Context, in *Admin_Request, opts ...grpc.CallOption) (*Admin_Response, error) {
+	out := new(Admin_Response)
+	err := c.cc.Invoke(ctx, "/Zapuskator.API.v1.ZapuskatorAdminAPI/RestartContainer", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *zapuskatorAdminAPIClient) RecreateContainer(ctx context.Context, in *Admin_Request, opts ...grpc.CallOption) (*Admin_Response, error) {
+	out := new(Admin_Response)
+	err := c.cc.Invoke(ctx, "/Zapuskator.API.v1.ZapuskatorAdminAPI/RecreateContainer", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *zapuskatorAdminAPIClient) DrainContainer(ctx context.Context, in *Admin_Request, opts ...grpc.CallOption) (*Admin_Response, error) {
+	out := new(Admin_Response)
+	err := c.cc.Invoke(ctx, "/Zapuskator.API.v1.ZapuskatorAdminAPI/DrainContainer", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *zapuskatorAdminAPIClient) PinContainer(ctx context.Context, in *Admin_Request, opts ...grpc.CallOption) (*Admin_Response, error) {
+	out := new(Admin_Response)
+	err := c.cc.Invoke(ctx, "/Zapuskator.API.v1.ZapuskatorAdminAPI/PinContainer", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *zapuskatorAdminAPIClient) UnpinContainer(ctx context.Context, in *Admin_Request, opts ...grpc.CallOption) (*Admin_Response, error) {
+	out := new(Admin_Response)
+	err := c.cc.Invoke(ctx, "/Zapuskator.API.v1.ZapuskatorAdminAPI/UnpinContainer", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *zapuskatorAdminAPIClient) UpgradeService(ctx context.Context, in *Upgrade_Request, opts ...grpc.CallOption) (*Upgrade_Response, error) {
+	out := new(Upgrade_Response)
+	err := c.cc.Invoke(ctx, "/Zapuskator.API.v1.ZapuskatorAdminAPI/UpgradeService", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *zapuskatorAdminAPIClient) SetShadowTraffic(ctx context.Context, in *Shadow_Request, opts ...grpc.CallOption) (*Shadow_Response, error) {
+	out := new(Shadow_Response)
+	err := c.cc.Invoke(ctx, "/Zapuskator.API.v1.ZapuskatorAdminAPI/SetShadowTraffic", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *zapuskatorAdminAPIClient) GetShadowReport(ctx context.Context, in *ShadowReport_Request, opts ...grpc.CallOption) (*ShadowReport_Response, error) {
+	out := new(ShadowReport_Response)
+	err := c.cc.Invoke(ctx, "/Zapuskator.API.v1.ZapuskatorAdminAPI/GetShadowReport", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *zapuskatorAdminAPIClient) GetDeterminismReport(ctx context.Context, in *Determinism_Request, opts ...grpc.CallOption) (*Determinism_Response, error) {
+	out := new(Determinism_Response)
+	err := c.cc.Invoke(ctx, "/Zapuskator.API.v1.ZapuskatorAdminAPI/GetDeterminismReport", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ZapuskatorAdminAPIServer is the server API for ZapuskatorAdminAPI service.
+// All implementations must embed UnimplementedZapuskatorAdminAPIServer
+// for forward compatibility
+type ZapuskatorAdminAPIServer interface {
+	StopContainer(context.Context, *Admin_Request) (*Admin_Response, error)
+	RestartContainer(context.Context, *Admin_Request) (*Admin_Response, error)
+	// Removes container and creates the new one with the same params.
+	RecreateContainer(context.Context, *Admin_Request) (*Admin_Response, error)
+	// Stops accepting new calculations by container. The container is stopped once it is idle.
+	DrainContainer(context.Context, *Admin_Request) (*Admin_Response, error)
+	// Pinned container is never stopped due to inactivity.
+	PinContainer(context.Context, *Admin_Request) (*Admin_Response, error)
+	UnpinContainer(context.Context, *Admin_Request) (*Admin_Response, error)
+	// Switches service to another image version without downtime. Containers of the new version are started
+	// for all active containers of the service first. New calculations go to them once they all are ready,
+	// while old containers are drained: they finish calculations in progress and are stopped.
+	// Nothing is switched when any new container fails to start.
+	UpgradeService(context.Context, *Upgrade_Request) (*Upgrade_Response, error)
+	// Duplicates sampled calculations of service to the candidate image and compares results.
+	// Candidate calculations run in background and don't affect clients. Zero sample rate disables shadowing.
+	SetShadowTraffic(context.Context, *Shadow_Request) (*Shadow_Response, error)
+	// Results comparison statistics and the latest mismatches of the current candidate.
+	GetShadowReport(context.Context, *ShadowReport_Request) (*ShadowReport_Response, error)
+	// Results of determinism verification: sampled results are recomputed by restarted or new container
+	// and compared with the original ones.
+	GetDeterminismReport(context.Context, *Determinism_Request) (*Determinism_Response, error)
+	mustEmbedUnimplementedZapuskatorAdminAPIServer()
+}
+
+// UnimplementedZapuskatorAdminAPIServer must be embedded to have forward compatible implementations.
+type UnimplementedZapuskatorAdminAPIServer struct {
+}
+
+func (UnimplementedZapuskatorAdminAPIServer) StopContainer(context.Context, *Admin_Request) (*Admin_Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StopContainer not implemented")
+}
+func (UnimplementedZapuskatorAdminAPIServer) RestartContainer(context.Context, *Admin_Request) (*Admin_Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestartContainer not implemented")
+}
+func (UnimplementedZapuskatorAdminAPIServer) RecreateContainer(context.Context, *Admin_Request) (*Admin_Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RecreateContainer not implemented")
+}
+func (UnimplementedZapuskatorAdminAPIServer) DrainContainer(context.Context, *Admin_Request) (*Admin_Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DrainContainer not implemented")
+}
+func (UnimplementedZapuskatorAdminAPIServer) PinContainer(context.Context, *Admin_Request) (*Admin_Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PinContainer not implemented")
+}
+func (UnimplementedZapuskatorAdminAPIServer) UnpinContainer(context.Context, *Admin_Request) (*Admin_Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnpinContainer not implemented")
+}
+func (UnimplementedZapuskatorAdminAPIServer) UpgradeService(context.Context, *Upgrade_Request) (*Upgrade_Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpgradeService not implemented")
+}
+func (UnimplementedZapuskatorAdminAPIServer) SetShadowTraffic(context.Context, *Shadow_Request) (*Shadow_Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetShadowTraffic not implemented")
+}
+func (UnimplementedZapuskatorAdminAPIServer) GetShadowReport(context.Context, *ShadowReport_Request) (*ShadowReport_Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetShadowReport not implemented")
+}
+func (UnimplementedZapuskatorAdminAPIServer) GetDeterminismReport(context.Context, *Determinism_Request) (*Determinism_Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDeterminismReport not implemented")
+}
+func (UnimplementedZapuskatorAdminAPIServer) mustEmbedUnimplementedZapuskatorAdminAPIServer() {}
+
+// UnsafeZapuskatorAdminAPIServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ZapuskatorAdminAPIServer will
+// result in compilation errors.
+type UnsafeZapuskatorAdminAPIServer interface {
+	mustEmbedUnimplementedZapuskatorAdminAPIServer()
+}
+
+func RegisterZapuskatorAdminAPIServer(s grpc.ServiceRegistrar, srv ZapuskatorAdminAPIServer) {
+	s.RegisterService(&ZapuskatorAdminAPI_ServiceDesc, srv)
+}
+
+func _ZapuskatorAdminAPI_StopContainer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Admin_Request)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ZapuskatorAdminAPIServer).StopContainer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Zapuskator.API.v1.ZapuskatorAdminAPI/StopContainer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ZapuskatorAdminAPIServer).StopContainer(ctx, req.(*Admin_Request))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ZapuskatorAdminAPI_RestartContainer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Admin_Request)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ZapuskatorAdminAPIServer).RestartContainer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Zapuskator.API.v1.ZapuskatorAdminAPI/RestartContainer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ZapuskatorAdminAPIServer).RestartContainer(ctx, req.(*Admin_Request))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ZapuskatorAdminAPI_RecreateContainer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Admin_Request)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ZapuskatorAdminAPIServer).RecreateContainer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Zapuskator.API.v1.ZapuskatorAdminAPI/RecreateContainer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ZapuskatorAdminAPIServer).RecreateContainer(ctx, req.(*Admin_Request))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ZapuskatorAdminAPI_DrainContainer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Admin_Request)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ZapuskatorAdminAPIServer).DrainContainer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Zapuskator.API.v1.ZapuskatorAdminAPI/DrainContainer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ZapuskatorAdminAPIServer).DrainContainer(ctx, req.(*Admin_Request))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ZapuskatorAdminAPI_PinContainer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Admin_Request)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ZapuskatorAdminAPIServer).PinContainer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Zapuskator.API.v1.ZapuskatorAdminAPI/PinContainer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ZapuskatorAdminAPIServer).PinContainer(ctx, req.(*Admin_Request))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ZapuskatorAdminAPI_UnpinContainer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Admin_Request)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ZapuskatorAdminAPIServer).UnpinContainer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Zapuskator.API.v1.ZapuskatorAdminAPI/UnpinContainer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ZapuskatorAdminAPIServer).UnpinContainer(ctx, req.(*Admin_Request))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ZapuskatorAdminAPI_UpgradeService_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Upgrade_Request)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ZapuskatorAdminAPIServer).UpgradeService(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Zapuskator.API.v1.ZapuskatorAdminAPI/UpgradeService",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ZapuskatorAdminAPIServer).UpgradeService(ctx, req.(*Upgrade_Request))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ZapuskatorAdminAPI_SetShadowTraffic_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Shadow_Request)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ZapuskatorAdminAPIServer).SetShadowTraffic(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Zapuskator.API.v1.ZapuskatorAdminAPI/SetShadowTraffic",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ZapuskatorAdminAPIServer).SetShadowTraffic(ctx, req.(*Shadow_Request))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ZapuskatorAdminAPI_GetShadowReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ShadowReport_Request)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ZapuskatorAdminAPIServer).GetShadowReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Zapuskator.API.v1.ZapuskatorAdminAPI/GetShadowReport",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ZapuskatorAdminAPIServer).GetShadowReport(ctx, req.(*ShadowReport_Request))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ZapuskatorAdminAPI_GetDeterminismReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Determinism_Request)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ZapuskatorAdminAPIServer).GetDeterminismReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Zapuskator.API.v1.ZapuskatorAdminAPI/GetDeterminismReport",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ZapuskatorAdminAPIServer).GetDeterminismReport(ctx, req.(*Determinism_Request))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ZapuskatorAdminAPI_ServiceDesc is the grpc.ServiceDesc for ZapuskatorAdminAPI service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ZapuskatorAdminAPI_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "Zapuskator.API.v1.ZapuskatorAdminAPI",
+	HandlerType: (*ZapuskatorAdminAPIServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "StopContainer",
+			Handler:    _ZapuskatorAdminAPI_StopContainer_Handler,
+		},
+		{
+			MethodName: "RestartContainer",
+			Handler:    _ZapuskatorAdminAPI_RestartContainer_Handler,
+		},
+		{
+			MethodName: "RecreateContainer",
+			Handler:    _ZapuskatorAdminAPI_RecreateContainer_Handler,
+		},
+		{
+			MethodName: "DrainContainer",
+			Handler:    _ZapuskatorAdminAPI_DrainContainer_Handler,
+		},
+		{
+			MethodName: "PinContainer",
+			Handler:    _ZapuskatorAdminAPI_PinContainer_Handler,
+		},
+		{
+			MethodName: "UnpinContainer",
+			Handler:    _ZapuskatorAdminAPI_UnpinContainer_Handler,
+		},
+		{
+			MethodName: "UpgradeService",
+			Handler:    _ZapuskatorAdminAPI_UpgradeService_Handler,
+		},
+		{
+			MethodName: "SetShadowTraffic",
+			Handler:    _ZapuskatorAdminAPI_SetShadowTraffic_Handler,
+		},
+		{
+			MethodName: "GetShadowReport",
+			Handler:    _ZapuskatorAdminAPI_GetShadowReport_Handler,
+		},
+		{
+			MethodName: "GetDeterminismReport",
+			Handler:    _ZapuskatorAdminAPI_GetDeterminismReport_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "api.v1.proto",